@@ -1,5 +1,9 @@
 package shared
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // Policy define the policy that controller need to respect when it reconcile resource
 type Policy struct {
 	// NoDelete is true if controller can't delete resource on remote provider
@@ -17,8 +21,64 @@ type Policy struct {
 	// +optional
 	NoUpdate bool `json:"noUpdate,omitempty"`
 
+	// Adopt is true if controller can take ownership of resource that already exist on remote provider
+	// Without it, the reconcile failed when resource already exist and it not created by controller
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
+
+	// Drift is the way to handle the changes made on remote provider outside of the controller
+	// With `enforce`, the changes are reverted. With `report-only`, they are only reported on status, events and metrics
+	// The changes of resource itself are always applied
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=enforce;report-only
+	// +kubebuilder:default=enforce
+	// +optional
+	Drift DriftMode `json:"drift,omitempty"`
+
 	// ExcludeFieldsOnDiff is the list of fields to exclude when diff step is processing
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	ExcludeFieldsOnDiff []string `json:"excludeFields,omitempty"`
 }
+
+// DriftMode is the way to handle the changes made on remote provider outside of the controller
+type DriftMode string
+
+const (
+	// DriftModeEnforce revert the changes made on remote provider
+	DriftModeEnforce DriftMode = "enforce"
+
+	// DriftModeReportOnly only report the changes made on remote provider
+	DriftModeReportOnly DriftMode = "report-only"
+)
+
+// IsDriftReportOnly return true if the changes made on remote provider need only to be reported
+func (p Policy) IsDriftReportOnly() bool {
+	return p.Drift == DriftModeReportOnly
+}
+
+// AdoptionStatus is the state of resource on remote provider before controller take ownership of it
+type AdoptionStatus struct {
+	// AdoptedAt is the time when controller take ownership of resource
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	AdoptedAt metav1.Time `json:"adoptedAt,omitempty"`
+
+	// PreviousConfiguration is the resource on each platform before adoption, zipped and encoded in base64
+	// It permit to rollback the resource if needed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	PreviousConfiguration string `json:"previousConfiguration,omitempty"`
+}
+
+// DriftStatus is the changes made on remote provider outside of the controller that are not reverted
+type DriftStatus struct {
+	// DetectedAt is the time when the drift was detected the first time
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DetectedAt metav1.Time `json:"detectedAt,omitempty"`
+
+	// Diff is the changes needed to revert the drift, without the password values
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetStatus return the status object
+func (o *CentreonEscalation) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the escalation name
+// If name is empty, it use the ressource name
+func (o *CentreonEscalation) GetExternalName() string {
+	if o.Spec.Name == "" {
+		return o.Name
+	}
+
+	return o.Spec.Name
+}
+
+// GetDescription return the escalation description
+// If description is empty, it use the escalation name
+func (o *CentreonEscalation) GetDescription() string {
+	if o.Spec.Description == "" {
+		return o.GetExternalName()
+	}
+
+	return o.Spec.Description
+}
+
+// GetPlatform return the platform where the escalation is created
+func (o *CentreonEscalation) GetPlatform() string {
+	return getPlatformRefs(o.Spec.PlatformRef, nil)[0]
+}
+
+// GetServiceRefs return the names of CentreonService resources used by the escalation
+func (o *CentreonEscalation) GetServiceRefs() []string {
+	return getServiceRefNames(o.Spec.Services)
+}
+
+// IsValid check the escalation is valid for Centreon
+func (o *CentreonEscalation) IsValid() bool {
+	return len(o.Spec.Services) > 0 && len(o.Spec.ContactGroups) > 0
+}
+
+// GetItems permit to get items
+func (o *CentreonEscalationList) GetItems() []client.Object {
+	return helper.ToSliceOfObject(o.Items)
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCentreonEscalationGetExternalName(t *testing.T) {
+	o := &CentreonEscalation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "esc1",
+		},
+	}
+
+	// When name is not set
+	assert.Equal(t, "esc1", o.GetExternalName())
+	assert.Equal(t, "esc1", o.GetDescription())
+
+	// When name and description are set
+	o.Spec.Name = "esc2"
+	o.Spec.Description = "my escalation"
+	assert.Equal(t, "esc2", o.GetExternalName())
+	assert.Equal(t, "my escalation", o.GetDescription())
+}
+
+func TestCentreonEscalationGetServiceRefs(t *testing.T) {
+	o := &CentreonEscalation{
+		Spec: CentreonEscalationSpec{
+			Services: []CentreonServiceRef{
+				{Name: "s1"},
+				{Name: "s2"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"s1", "s2"}, o.GetServiceRefs())
+}
+
+func TestCentreonEscalationIsValid(t *testing.T) {
+	// When is valid
+	o := &CentreonEscalation{
+		Spec: CentreonEscalationSpec{
+			Services:      []CentreonServiceRef{{Name: "s1"}},
+			ContactGroups: []string{"cg1"},
+		},
+	}
+	assert.True(t, o.IsValid())
+
+	// When invalid
+	o.Spec.ContactGroups = nil
+	assert.False(t, o.IsValid())
+	assert.False(t, (&CentreonEscalation{}).IsValid())
+}
@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// SetupCentreonEscalationIndexer setup indexer for CentreonEscalation
+func SetupCentreonEscalationIndexer(k8sManager manager.Manager) (err error) {
+	// Index the CentreonService resources needed by controller to reconcile escalation when service change
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonEscalation{}, "spec.serviceRefs", func(o client.Object) []string {
+		p := o.(*CentreonEscalation)
+		return p.GetServiceRefs()
+	}); err != nil {
+		return err
+	}
+
+	// Index target platform needed by controller to reconcile escalation when platform change
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonEscalation{}, "spec.targetPlatform", func(o client.Object) []string {
+		p := o.(*CentreonEscalation)
+		return []string{p.GetPlatform()}
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *APITestSuite) TestSetupCentreonEscalationIndexer() {
+	// Add CentreonEscalation to force  indexer execution
+
+	o := &CentreonEscalation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: CentreonEscalationSpec{
+			PlatformRef: "test",
+			Services: []CentreonServiceRef{
+				{
+					Name: "service1",
+				},
+			},
+			ContactGroups: []string{"cg1"},
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/monitoring-operator/api/shared"
+	"github.com/disaster37/operator-sdk-extra/pkg/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CentreonEscalationSpec defines the desired state of CentreonEscalation
+// +k8s:openapi-gen=true
+type CentreonEscalationSpec struct {
+	// PlatformRef is the target platform where to create the escalation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	PlatformRef string `json:"platformRef,omitempty"`
+
+	// The escalation name. By default, it is the resource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The escalation description. By default, it is the escalation name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The services where the notifications are escalated
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	Services []CentreonServiceRef `json:"services"`
+
+	// The contact groups notified by the escalation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	ContactGroups []string `json:"contactGroups"`
+
+	// The number of the first notification that is escalated
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	FirstNotification int `json:"firstNotification,omitempty"`
+
+	// The number of the last notification that is escalated. 0 to escalate all notifications after the first one
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	LastNotification int `json:"lastNotification,omitempty"`
+
+	// The interval between the escalated notifications, in minutes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NotificationInterval int `json:"notificationInterval,omitempty"`
+
+	// The time period when the escalation is active
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	EscalationPeriod string `json:"escalationPeriod,omitempty"`
+
+	// The states of services that are escalated
+	// w for warning, u for unknown, c for critical and r for recovery
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:items:Enum=w;u;c;r
+	// +optional
+	NotificationOptions []string `json:"notificationOptions,omitempty"`
+
+	// Policy define the policy that controller need to respect when it reconcile resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Policy shared.Policy `json:"policy,omitempty"`
+}
+
+// CentreonEscalationStatus defines the observed state of CentreonEscalation
+type CentreonEscalationStatus struct {
+	apis.BasicRemoteObjectStatus `json:",inline"`
+
+	// The escalation name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	EscalationName string `json:"escalationName,omitempty"`
+
+	// The platform ref
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PlatformRef string `json:"platformRef,omitempty"`
+
+	// The state of resource on Centreon before the operator take ownership of it (policy adopt)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// The changes made on Centreon outside of the operator that are not reverted (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Drift *shared.DriftStatus `json:"drift,omitempty"`
+
+	// The hash of expected resource on Centreon when it was reconciled the last time
+	// It used to know if the diff come from resource changes or from changes made on Centreon (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ExpectedHash string `json:"expectedHash,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// CentreonEscalation is the Schema for the centreonescalations API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:resource:shortName=mce
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Escalation",type="string",JSONPath=".status.escalationName"
+// +kubebuilder:printcolumn:name="Platform",type="string",JSONPath=".status.platformRef"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type CentreonEscalation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CentreonEscalationSpec   `json:"spec,omitempty"`
+	Status CentreonEscalationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CentreonEscalationList contains a list of CentreonEscalation
+type CentreonEscalationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CentreonEscalation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CentreonEscalation{}, &CentreonEscalationList{})
+}
@@ -1,6 +1,11 @@
 package v1
 
 import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/disaster37/operator-sdk-extra/pkg/object"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,21 +26,195 @@ func (o *CentreonService) GetExternalName() string {
 	return o.Spec.Name
 }
 
+// GetPlatform return the main platform where the service is created
 func (o *CentreonService) GetPlatform() string {
-	if o.Spec.PlatformRef == "" {
-		return "default"
+	return o.GetPlatforms()[0]
+}
+
+// GetPlatforms return all platforms where the service is created
+// The main platform is the first one
+func (o *CentreonService) GetPlatforms() []string {
+	return getPlatformRefs(o.Spec.PlatformRef, o.Spec.PlatformRefs)
+}
+
+// GetSpecWithDefaults return the spec where the fields not set are filled from platform defaults
+// It also return the defaults that are applied, or nil if no defaults are applied
+func (o *CentreonService) GetSpecWithDefaults(defaults *PlatformDefaults) (spec *CentreonServiceSpec, applied *PlatformDefaults) {
+	spec = o.Spec.DeepCopy()
+	if defaults == nil {
+		return spec, nil
+	}
+	applied = &PlatformDefaults{}
+
+	if spec.Host == "" && defaults.Host != "" {
+		spec.Host = defaults.Host
+		applied.Host = defaults.Host
+	}
+	if spec.Template == "" && spec.CheckCommand == "" && defaults.Template != "" {
+		spec.Template = defaults.Template
+		applied.Template = defaults.Template
+	}
+	if len(spec.Groups) == 0 && len(defaults.Groups) > 0 {
+		spec.Groups = append([]string{}, defaults.Groups...)
+		applied.Groups = defaults.Groups
+	}
+	if len(spec.Categories) == 0 && len(defaults.Categories) > 0 {
+		spec.Categories = append([]string{}, defaults.Categories...)
+		applied.Categories = defaults.Categories
+	}
+	if spec.NormalCheckInterval == "" && defaults.NormalCheckInterval != "" {
+		spec.NormalCheckInterval = defaults.NormalCheckInterval
+		applied.NormalCheckInterval = defaults.NormalCheckInterval
+	}
+	if spec.RetryCheckInterval == "" && defaults.RetryCheckInterval != "" {
+		spec.RetryCheckInterval = defaults.RetryCheckInterval
+		applied.RetryCheckInterval = defaults.RetryCheckInterval
+	}
+	if spec.MaxCheckAttempts == "" && defaults.MaxCheckAttempts != "" {
+		spec.MaxCheckAttempts = defaults.MaxCheckAttempts
+		applied.MaxCheckAttempts = defaults.MaxCheckAttempts
+	}
+	for name, value := range defaults.Macros {
+		if _, ok := spec.Macros[name]; ok {
+			continue
+		}
+		if spec.Macros == nil {
+			spec.Macros = map[string]string{}
+		}
+		if applied.Macros == nil {
+			applied.Macros = map[string]string{}
+		}
+		spec.Macros[name] = value
+		applied.Macros[name] = value
 	}
+	applied.Comment = defaults.Comment
 
-	return o.Spec.PlatformRef
+	if reflect.DeepEqual(applied, &PlatformDefaults{}) {
+		return spec, nil
+	}
+
+	return spec, applied
 }
 
-// IsValid check Centreon service is valid for Centreon
-func (c *CentreonService) IsValid() bool {
-	if c.Spec.Host == "" || c.Spec.Name == "" || c.Spec.Template == "" {
-		return false
+// GetHost return the host where the service is attached
+// It use the host applied from platform defaults when not set on spec
+func (o *CentreonService) GetHost() string {
+	if o.Spec.Host == "" && o.Status.AppliedDefaults != nil {
+		return o.Status.AppliedDefaults.Host
+	}
+
+	return o.Spec.Host
+}
+
+// GetHostByPlatform return the host where the service is attached on platform
+// It use the host read on platform status when not set on spec, because the platform defaults are only known when the service is reconciled
+func (o *CentreonService) GetHostByPlatform(platform string) string {
+	if o.Spec.Host != "" {
+		return o.Spec.Host
+	}
+	for _, status := range o.Status.Platforms {
+		if status.Name == platform && status.Host != "" {
+			return status.Host
+		}
+	}
+	if platform == o.GetPlatform() {
+		return o.GetHost()
+	}
+
+	return ""
+}
+
+// GetExternalNames return the identity of service on each platform, with format `platform/host/name`
+func (o *CentreonService) GetExternalNames() []string {
+	platforms := o.GetPlatforms()
+	names := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		names = append(names, fmt.Sprintf("%s/%s/%s", platform, o.GetHostByPlatform(platform), o.GetExternalName()))
+	}
+
+	return names
+}
+
+// GetMacrosFromSecretNames return the name of secrets used by macros
+func (o *CentreonService) GetMacrosFromSecretNames() (secrets []string) {
+	secrets = make([]string, 0)
+	for _, macro := range o.Spec.MacrosFrom {
+		if macro.ValueFrom.SecretKeyRef != nil && !slices.Contains(secrets, macro.ValueFrom.SecretKeyRef.Name) {
+			secrets = append(secrets, macro.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+
+	return secrets
+}
+
+// GetMacrosFromConfigMapNames return the name of configmaps used by macros
+func (o *CentreonService) GetMacrosFromConfigMapNames() (configMaps []string) {
+	configMaps = make([]string, 0)
+	for _, macro := range o.Spec.MacrosFrom {
+		if macro.ValueFrom.ConfigMapKeyRef != nil && !slices.Contains(configMaps, macro.ValueFrom.ConfigMapKeyRef.Name) {
+			configMaps = append(configMaps, macro.ValueFrom.ConfigMapKeyRef.Name)
+		}
 	}
 
-	return true
+	return configMaps
+}
+
+// GetFieldValue return the value of field from the service metadata
+// It return error if the field path is not supported or if the label / annotation not exist
+func (o *CentreonService) GetFieldValue(fieldPath string) (value string, err error) {
+	path, key, err := parseFieldPath(fieldPath)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		values map[string]string
+		ok     bool
+	)
+	switch path {
+	case "metadata.name":
+		return o.Name, nil
+	case "metadata.namespace":
+		return o.Namespace, nil
+	case "metadata.labels":
+		values = o.Labels
+	case "metadata.annotations":
+		values = o.Annotations
+	}
+
+	if value, ok = values[key]; !ok {
+		return "", fmt.Errorf("%s not found on %s", key, path)
+	}
+
+	return value, nil
+}
+
+// parseFieldPath permit to split the field path and the key of label / annotation
+// It return error if the field path is not supported
+func parseFieldPath(fieldPath string) (path string, key string, err error) {
+	switch fieldPath {
+	case "metadata.name", "metadata.namespace":
+		return fieldPath, "", nil
+	}
+
+	for _, path = range []string{"metadata.labels", "metadata.annotations"} {
+		if strings.HasPrefix(fieldPath, path+"['") && strings.HasSuffix(fieldPath, "']") {
+			key = strings.TrimSuffix(strings.TrimPrefix(fieldPath, path+"['"), "']")
+			if key == "" {
+				break
+			}
+			return path, key, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("Field path %s is not supported", fieldPath)
+}
+
+// IsValid check Centreon service is valid for Centreon
+// The host and the template are not checked, because they can be provided by the platform defaults
+// They are checked when the service is built, after the platform defaults are applied
+func (c *CentreonService) IsValid() bool {
+	return c.Spec.Name != ""
 }
 
 // GetItems permit to get items
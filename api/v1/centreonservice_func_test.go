@@ -5,6 +5,7 @@ import (
 
 	"github.com/disaster37/operator-sdk-extra/pkg/apis"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -21,16 +22,15 @@ func TestCentreonServiceIsValid(t *testing.T) {
 	}
 	assert.True(t, centreonService.IsValid())
 
-	// When invalid
+	// When host and template are provided by platform defaults
 	centreonService = &CentreonService{
 		Spec: CentreonServiceSpec{
-			Host:     "",
-			Name:     "ping",
-			Template: "template",
+			Name: "ping",
 		},
 	}
-	assert.False(t, centreonService.IsValid())
+	assert.True(t, centreonService.IsValid())
 
+	// When invalid
 	centreonService = &CentreonService{
 		Spec: CentreonServiceSpec{
 			Host:     "localhost",
@@ -40,15 +40,6 @@ func TestCentreonServiceIsValid(t *testing.T) {
 	}
 	assert.False(t, centreonService.IsValid())
 
-	centreonService = &CentreonService{
-		Spec: CentreonServiceSpec{
-			Host:     "localhost",
-			Name:     "ping",
-			Template: "",
-		},
-	}
-	assert.False(t, centreonService.IsValid())
-
 	centreonService = &CentreonService{}
 	assert.False(t, centreonService.IsValid())
 }
@@ -125,3 +116,211 @@ func TestCentreonServiceGetPlatform(t *testing.T) {
 
 	assert.Equal(t, "default", o.GetPlatform())
 }
+
+func TestCentreonServiceGetPlatforms(t *testing.T) {
+	var o *CentreonService
+
+	// When platform isn't set
+	o = &CentreonService{}
+	assert.Equal(t, []string{"default"}, o.GetPlatforms())
+
+	// When platform is set
+	o = &CentreonService{
+		Spec: CentreonServiceSpec{
+			PlatformRef: "test",
+		},
+	}
+	assert.Equal(t, []string{"test"}, o.GetPlatforms())
+
+	// When multiple platforms are set
+	o = &CentreonService{
+		Spec: CentreonServiceSpec{
+			PlatformRefs: []string{"prd", "dr", "prd"},
+		},
+	}
+	assert.Equal(t, []string{"prd", "dr"}, o.GetPlatforms())
+	assert.Equal(t, "prd", o.GetPlatform())
+}
+
+func TestCentreonServiceGetSpecWithDefaults(t *testing.T) {
+	o := &CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: CentreonServiceSpec{
+			Name:       "test",
+			Categories: []string{"cat1"},
+			Macros: map[string]string{
+				"MAC1": "value1",
+			},
+		},
+	}
+
+	// Without defaults
+	spec, applied := o.GetSpecWithDefaults(nil)
+	assert.Equal(t, &o.Spec, spec)
+	assert.Nil(t, applied)
+
+	// With defaults
+	defaults := &PlatformDefaults{
+		Host:                "localhost",
+		Template:            "template1",
+		Groups:              []string{"sg1"},
+		Categories:          []string{"cat2"},
+		NormalCheckInterval: "5",
+		RetryCheckInterval:  "1",
+		MaxCheckAttempts:    "3",
+		Macros: map[string]string{
+			"MAC1": "default1",
+			"MAC2": "default2",
+		},
+		Comment: "Managed by K8s",
+	}
+	spec, applied = o.GetSpecWithDefaults(defaults)
+	assert.Equal(t, &CentreonServiceSpec{
+		Name:                "test",
+		Host:                "localhost",
+		Template:            "template1",
+		Groups:              []string{"sg1"},
+		Categories:          []string{"cat1"},
+		NormalCheckInterval: "5",
+		RetryCheckInterval:  "1",
+		MaxCheckAttempts:    "3",
+		Macros: map[string]string{
+			"MAC1": "value1",
+			"MAC2": "default2",
+		},
+	}, spec)
+	assert.Equal(t, &PlatformDefaults{
+		Host:                "localhost",
+		Template:            "template1",
+		Groups:              []string{"sg1"},
+		NormalCheckInterval: "5",
+		RetryCheckInterval:  "1",
+		MaxCheckAttempts:    "3",
+		Macros: map[string]string{
+			"MAC2": "default2",
+		},
+		Comment: "Managed by K8s",
+	}, applied)
+
+	// Spec is not modified
+	assert.Empty(t, o.Spec.Host)
+	assert.Len(t, o.Spec.Macros, 1)
+
+	// When defaults are not used
+	spec, applied = o.GetSpecWithDefaults(&PlatformDefaults{Categories: []string{"cat2"}})
+	assert.Equal(t, &o.Spec, spec)
+	assert.Nil(t, applied)
+}
+
+func TestCentreonServiceGetHost(t *testing.T) {
+	o := &CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: CentreonServiceSpec{
+			Host: "host1",
+		},
+	}
+	assert.Equal(t, "host1", o.GetHost())
+
+	// When host come from platform defaults
+	o.Spec.Host = ""
+	o.Status.AppliedDefaults = &PlatformDefaults{Host: "host2"}
+	assert.Equal(t, "host2", o.GetHost())
+}
+
+func TestCentreonServiceGetExternalNames(t *testing.T) {
+	o := &CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: CentreonServiceSpec{
+			PlatformRefs: []string{"p1", "p2"},
+			Host:         "host1",
+			Name:         "ping",
+		},
+	}
+	assert.Equal(t, []string{"p1/host1/ping", "p2/host1/ping"}, o.GetExternalNames())
+
+	// When host come from platform defaults
+	o.Spec.Host = ""
+	o.Status.AppliedDefaults = &PlatformDefaults{Host: "host2"}
+	o.Status.Platforms = []PlatformRefStatus{{Name: "p2", Host: "host3"}}
+	assert.Equal(t, []string{"p1/host2/ping", "p2/host3/ping"}, o.GetExternalNames())
+
+	// When the service is not yet reconciled on platform
+	o.Status.Platforms = nil
+	assert.Equal(t, "", o.GetHostByPlatform("p2"))
+}
+
+func TestCentreonServiceGetFieldValue(t *testing.T) {
+	o := &CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "test",
+			Labels:      map[string]string{"app": "my-app"},
+			Annotations: map[string]string{"team": "ops"},
+		},
+	}
+
+	value, err := o.GetFieldValue("metadata.name")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", value)
+
+	value, err = o.GetFieldValue("metadata.namespace")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", value)
+
+	value, err = o.GetFieldValue("metadata.labels['app']")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-app", value)
+
+	value, err = o.GetFieldValue("metadata.annotations['team']")
+	assert.NoError(t, err)
+	assert.Equal(t, "ops", value)
+
+	// When label not exist
+	_, err = o.GetFieldValue("metadata.labels['foo']")
+	assert.Error(t, err)
+
+	// When field path is not supported
+	_, err = o.GetFieldValue("spec.host")
+	assert.Error(t, err)
+	_, err = o.GetFieldValue("metadata.labels['']")
+	assert.Error(t, err)
+}
+
+func TestCentreonServiceGetMacrosFromConfigMapNames(t *testing.T) {
+	o := &CentreonService{
+		Spec: CentreonServiceSpec{
+			MacrosFrom: []MacroFrom{
+				{
+					Name: "mac1",
+					ValueFrom: MacroValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "key1"},
+					},
+				},
+				{
+					Name: "mac2",
+					ValueFrom: MacroValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "key2"},
+					},
+				},
+				{
+					Name: "mac3",
+					ValueFrom: MacroValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "secret1"}, Key: "key1"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"cm1"}, o.GetMacrosFromConfigMapNames())
+	assert.Equal(t, []string{"secret1"}, o.GetMacrosFromSecretNames())
+}
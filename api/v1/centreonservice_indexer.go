@@ -2,7 +2,6 @@ package v1
 
 import (
 	"context"
-	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -10,10 +9,11 @@ import (
 
 // SetupCentreonServiceIndexer setup indexer for CentreonService
 func SetupCentreonServiceIndexer(k8sManager manager.Manager) (err error) {
-	// Index external name needed by webhook to controle unicity
+	// Index external name on each platform needed by webhook to controle unicity
+	// The host is resolved from the platform defaults applied on service
 	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonService{}, "spec.externalName", func(o client.Object) []string {
 		p := o.(*CentreonService)
-		return []string{fmt.Sprintf("%s/%s", p.Spec.Host, p.GetExternalName())}
+		return p.GetExternalNames()
 	}); err != nil {
 		return err
 	}
@@ -21,7 +21,23 @@ func SetupCentreonServiceIndexer(k8sManager manager.Manager) (err error) {
 	// Index target platform needed by webhook to controle unicity
 	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonService{}, "spec.targetPlatform", func(o client.Object) []string {
 		p := o.(*CentreonService)
-		return []string{p.GetPlatform()}
+		return p.GetPlatforms()
+	}); err != nil {
+		return err
+	}
+
+	// Index secrets used by macros needed to reconcile service when secret change
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonService{}, "spec.macrosFrom.secret", func(o client.Object) []string {
+		p := o.(*CentreonService)
+		return p.GetMacrosFromSecretNames()
+	}); err != nil {
+		return err
+	}
+
+	// Index configmaps used by macros needed to reconcile service when configmap change
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonService{}, "spec.macrosFrom.configMap", func(o client.Object) []string {
+		p := o.(*CentreonService)
+		return p.GetMacrosFromConfigMapNames()
 	}); err != nil {
 		return err
 	}
@@ -19,6 +19,7 @@ package v1
 import (
 	"github.com/disaster37/monitoring-operator/api/shared"
 	"github.com/disaster37/operator-sdk-extra/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,13 +37,21 @@ type CentreonServiceSpec struct {
 	// +optional
 	PlatformRef string `json:"platformRef,omitempty"`
 
+	// PlatformRefs is the list of target platforms where to mirror the service
+	// The first one is the main platform. It can't be set with platformRef
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	PlatformRefs []string `json:"platformRefs,omitempty"`
+
 	// The service name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Name string `json:"name"`
 
 	// The host to attach the service
+	// It can be omitted when the platform has default host
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	Host string `json:"host"`
+	// +optional
+	Host string `json:"host,omitempty"`
 
 	// The service templates
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -59,6 +68,12 @@ type CentreonServiceSpec struct {
 	// +optional
 	Macros map[string]string `json:"macros,omitempty"`
 
+	// The list of macros which value is read from other resources or from the service metadata
+	// It take precedence over macros with same name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MacrosFrom []MacroFrom `json:"macrosFrom,omitempty"`
+
 	// The list of arguments
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -104,12 +119,93 @@ type CentreonServiceSpec struct {
 	// +optional
 	Activated bool `json:"activate,omitempty"`
 
+	// The list of contacts notified for the service
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Contacts []string `json:"contacts,omitempty"`
+
+	// The list of contact groups notified for the service
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ContactGroups []string `json:"contactGroups,omitempty"`
+
+	// The states of service that are notified
+	// w for warning, u for unknown, c for critical, r for recovery, f for flapping, s for downtime and n for none
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:items:Enum=w;u;c;r;f;s;n
+	// +optional
+	NotificationOptions []string `json:"notificationOptions,omitempty"`
+
+	// The notification interval
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NotificationInterval string `json:"notificationInterval,omitempty"`
+
+	// The time period when the notifications are sent
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NotificationPeriod string `json:"notificationPeriod,omitempty"`
+
+	// The notifications enable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NotificationsEnabled *bool `json:"notificationsEnabled,omitempty"`
+
 	// Policy define the policy that controller need to respect when it reconcile resource
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Policy shared.Policy `json:"policy,omitempty"`
 }
 
+// MacroFrom is a macro which value is read from another resource
+type MacroFrom struct {
+	// Name is the macro name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+
+	// ValueFrom is the source of the macro value
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ValueFrom MacroValueSource `json:"valueFrom"`
+}
+
+// MacroValueSource is the source of macro value
+type MacroValueSource struct {
+	// SecretKeyRef is the Secret key on the service namespace
+	// The macro is set as password macro on Centreon
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef is the ConfigMap key on the service namespace
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// FieldRef is the field of the service metadata
+	// It support `metadata.name`, `metadata.namespace`, `metadata.labels['<KEY>']` and `metadata.annotations['<KEY>']`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FieldRef *corev1.ObjectFieldSelector `json:"fieldRef,omitempty"`
+}
+
+// CentreonServiceIdentity is the host and the name that identify a service on Centreon
+type CentreonServiceIdentity struct {
+	// The host of service
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Host string `json:"host"`
+
+	// The service name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ServiceName string `json:"serviceName"`
+}
+
+// CentreonServiceRef is the reference to a CentreonService resource on the same namespace
+type CentreonServiceRef struct {
+	// The CentreonService name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+}
+
 // CentreonServiceStatus defines the observed state of CentreonService
 type CentreonServiceStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -125,9 +221,47 @@ type CentreonServiceStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	ServiceName string `json:"serviceName,omitempty"`
 
+	// The target host and service name when the service is renamed or moved to another host
+	// It is set before the operation start and removed when it is completed, so the service can be found if the operation is interrupted
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	PendingMove *CentreonServiceIdentity `json:"pendingMove,omitempty"`
+
 	// The platform ref
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	PlatformRef string `json:"platformRef,omitempty"`
+
+	// The platform default values applied on service because there are not set on spec
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	AppliedDefaults *PlatformDefaults `json:"appliedDefaults,omitempty"`
+
+	// The service status on each platform when it mirrored on multiple platforms
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Platforms []PlatformRefStatus `json:"platforms,omitempty"`
+
+	// The hash of the secrets versions where the password macros applied on Centreon are read
+	// Centreon not return the password macros values, so it used to know when they change
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	PasswordMacrosHash string `json:"passwordMacrosHash,omitempty"`
+
+	// The state of resource on Centreon before the operator take ownership of it (policy adopt)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// The changes made on Centreon outside of the operator that are not reverted (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Drift *shared.DriftStatus `json:"drift,omitempty"`
+
+	// The hash of expected resource on Centreon when it was reconciled the last time
+	// It used to know if the diff come from resource changes or from changes made on Centreon (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ExpectedHash string `json:"expectedHash,omitempty"`
 }
 
 //+kubebuilder:object:root=true
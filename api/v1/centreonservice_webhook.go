@@ -46,40 +46,110 @@ func SetupCentreonServiceWebhookWithManager(mgr ctrl.Manager, client client.Clie
 var _ webhook.Validator = &CentreonService{}
 
 // validateField permit to validate the centreonService fields
+// The fields can be omitted when they are provided by the platform defaults
 func (r *CentreonService) validateField() *field.Error {
-	if r.Spec.CheckCommand == "" && r.Spec.Template == "" {
-		return field.Required(field.NewPath("spec"), "You need to provide 'spec.checkCommand' or 'spec.template' field")
+	if (r.Spec.CheckCommand != "" || r.Spec.Template != "") && r.Spec.Host != "" {
+		return nil
+	}
+
+	// Each target platform need to provide the missing fields
+	for _, platformRef := range r.GetPlatforms() {
+		spec := r.getSpecWithPlatformDefaults(platformRef)
+
+		if spec.CheckCommand == "" && spec.Template == "" {
+			return field.Required(field.NewPath("spec"), "You need to provide 'spec.checkCommand' or 'spec.template' field")
+		}
+		if spec.Host == "" {
+			return field.Required(field.NewPath("spec").Child("host"), "You need to provide 'spec.host' field")
+		}
 	}
 
 	return nil
 }
 
+// validateMacrosFrom permit to validate the macros read from other resources
+func (r *CentreonService) validateMacrosFrom() (errs field.ErrorList) {
+	for i, macro := range r.Spec.MacrosFrom {
+		fldPath := field.NewPath("spec").Child("macrosFrom").Index(i)
+		if macro.Name == "" {
+			errs = append(errs, field.Required(fldPath.Child("name"), "You need to provide the macro name"))
+		}
+		fldPath = fldPath.Child("valueFrom")
+		nbSources := 0
+		if ref := macro.ValueFrom.SecretKeyRef; ref != nil {
+			nbSources++
+			if ref.Name == "" || ref.Key == "" {
+				errs = append(errs, field.Required(fldPath.Child("secretKeyRef"), "You need to provide the secret name and key"))
+			}
+		}
+		if ref := macro.ValueFrom.ConfigMapKeyRef; ref != nil {
+			nbSources++
+			if ref.Name == "" || ref.Key == "" {
+				errs = append(errs, field.Required(fldPath.Child("configMapKeyRef"), "You need to provide the configmap name and key"))
+			}
+		}
+		if ref := macro.ValueFrom.FieldRef; ref != nil {
+			nbSources++
+			if _, _, err := parseFieldPath(ref.FieldPath); err != nil {
+				errs = append(errs, field.Invalid(fldPath.Child("fieldRef").Child("fieldPath"), ref.FieldPath, err.Error()))
+			}
+		}
+		switch nbSources {
+		case 0:
+			errs = append(errs, field.Required(fldPath, "You need to provide the source of macro value"))
+		case 1:
+		default:
+			errs = append(errs, field.Forbidden(fldPath, "You need to provide only one source of macro value"))
+		}
+	}
+
+	return errs
+}
+
+// getSpecWithPlatformDefaults return the spec where the fields not set are filled from the defaults of target platform
+func (r *CentreonService) getSpecWithPlatformDefaults(platformRef string) *CentreonServiceSpec {
+	var defaults *PlatformDefaults
+	if platformRef == defaultPlatformRef {
+		platformRef = ""
+	}
+	if p := getTargetPlatform(platformRef, r.Namespace); p != nil {
+		defaults = p.Spec.Defaults
+	}
+	spec, _ := r.GetSpecWithDefaults(defaults)
+
+	return spec
+}
+
 func (r *CentreonService) validateImmatablePlatform(current, old *CentreonService) *field.Error {
 	if current.GetPlatform() != old.GetPlatform() {
-		return field.Forbidden(field.NewPath("spec").Child("platformRef"), "The field 'spec.platformRef' is immutable")
+		return field.Forbidden(field.NewPath("spec").Child("platformRef"), "The main platform ('spec.platformRef' or the first of 'spec.platformRefs') is immutable")
 	}
 	return nil
 }
 
 func (r *CentreonService) validateResourceUnicity() *field.Error {
 	// Check if resource already exist with same name on some remote target platform
-	listObjects := &CentreonServiceList{}
-	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s/%s,spec.targetPlatform=%s", r.Spec.Host, r.GetExternalName(), r.GetPlatform()))
-	if err := shared.Client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
-		panic(err)
-	}
-	if len(listObjects.Items) > 0 {
-		isError := false
-		existingResources := make([]string, 0, len(listObjects.Items))
-		for _, ag := range listObjects.Items {
-			// exclude themself
-			if ag.UID != r.UID {
-				existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
-				isError = true
-			}
+	// The host can be provided by the platform defaults
+	for _, platformRef := range r.GetPlatforms() {
+		listObjects := &CentreonServiceList{}
+		host := r.getSpecWithPlatformDefaults(platformRef).Host
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s/%s/%s", platformRef, host, r.GetExternalName()))
+		if err := shared.Client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+			panic(err)
 		}
-		if isError {
-			return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same monitoring platform with the same name: %s", strings.Join(existingResources, ", ")))
+		if len(listObjects.Items) > 0 {
+			isError := false
+			existingResources := make([]string, 0, len(listObjects.Items))
+			for _, ag := range listObjects.Items {
+				// exclude themself
+				if ag.UID != r.UID {
+					existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
+					isError = true
+				}
+			}
+			if isError {
+				return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same monitoring platform with the same name: %s", strings.Join(existingResources, ", ")))
+			}
 		}
 	}
 
@@ -99,6 +169,10 @@ func (r *CentreonService) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, r.validateMacrosFrom()...)
+
+	allErrs = append(allErrs, validateTargetPlatforms(r.Spec.PlatformRef, r.Spec.PlatformRefs, r.Namespace)...)
+
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
 			r.GroupVersionKind().GroupKind(),
@@ -126,6 +200,10 @@ func (r *CentreonService) ValidateUpdate(old runtime.Object) (admission.Warnings
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, r.validateMacrosFrom()...)
+
+	allErrs = append(allErrs, validateTargetPlatforms(r.Spec.PlatformRef, r.Spec.PlatformRefs, r.Namespace)...)
+
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
 			r.GroupVersionKind().GroupKind(),
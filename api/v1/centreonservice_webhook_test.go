@@ -99,4 +99,37 @@ func (t *APITestSuite) TestSetupCentreonServiceWebhook() {
 	o.Spec.PlatformRef = "test2"
 	err = t.k8sClient.Update(context.Background(), o)
 	assert.Error(t.T(), err)
+
+	// Need failed when namespace is not allowed to use the platform
+	p := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook-restricted",
+			Namespace: "default",
+		},
+		Spec: PlatformSpec{
+			PlatformType: "centreon",
+			CentreonSettings: &PlatformSpecCentreonSettings{
+				URL:    "http://localhost",
+				Secret: "test",
+			},
+			AllowedNamespaces: []string{"tenant1"},
+		},
+	}
+	if err = t.k8sClient.Create(context.Background(), p); err != nil {
+		t.T().Fatal(err)
+	}
+	o = &CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: CentreonServiceSpec{
+			PlatformRef: "test-webhook-restricted",
+			Template:    "test",
+			Host:        "localhost",
+			Name:        "test6",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
 }
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"slices"
+
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetStatus return the status object
+func (o *CentreonServiceDependency) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the dependency name
+// If name is empty, it use the ressource name
+func (o *CentreonServiceDependency) GetExternalName() string {
+	if o.Spec.Name == "" {
+		return o.Name
+	}
+
+	return o.Spec.Name
+}
+
+// GetDescription return the dependency description
+// If description is empty, it use the dependency name
+func (o *CentreonServiceDependency) GetDescription() string {
+	if o.Spec.Description == "" {
+		return o.GetExternalName()
+	}
+
+	return o.Spec.Description
+}
+
+// GetPlatform return the platform where the dependency is created
+func (o *CentreonServiceDependency) GetPlatform() string {
+	return getPlatformRefs(o.Spec.PlatformRef, nil)[0]
+}
+
+// GetServiceRefs return the names of CentreonService resources used by the dependency
+func (o *CentreonServiceDependency) GetServiceRefs() []string {
+	return getServiceRefNames(o.Spec.Services, o.Spec.DependentServices)
+}
+
+// IsValid check the dependency is valid for Centreon
+func (o *CentreonServiceDependency) IsValid() bool {
+	return len(o.Spec.Services) > 0 && len(o.Spec.DependentServices) > 0
+}
+
+// GetItems permit to get items
+func (o *CentreonServiceDependencyList) GetItems() []client.Object {
+	return helper.ToSliceOfObject(o.Items)
+}
+
+// getServiceRefNames return the unique names of CentreonService references
+func getServiceRefNames(refsList ...[]CentreonServiceRef) (names []string) {
+	names = make([]string, 0)
+	for _, refs := range refsList {
+		for _, ref := range refs {
+			if !slices.Contains(names, ref.Name) {
+				names = append(names, ref.Name)
+			}
+		}
+	}
+
+	return names
+}
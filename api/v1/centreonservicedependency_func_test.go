@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCentreonServiceDependencyGetExternalName(t *testing.T) {
+	o := &CentreonServiceDependency{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dep1",
+		},
+	}
+
+	// When name is not set
+	assert.Equal(t, "dep1", o.GetExternalName())
+	assert.Equal(t, "dep1", o.GetDescription())
+
+	// When name and description are set
+	o.Spec.Name = "dep2"
+	assert.Equal(t, "dep2", o.GetExternalName())
+	assert.Equal(t, "dep2", o.GetDescription())
+	o.Spec.Description = "my dependency"
+	assert.Equal(t, "my dependency", o.GetDescription())
+}
+
+func TestCentreonServiceDependencyGetPlatform(t *testing.T) {
+	o := &CentreonServiceDependency{}
+	assert.Equal(t, "default", o.GetPlatform())
+
+	o.Spec.PlatformRef = "test"
+	assert.Equal(t, "test", o.GetPlatform())
+}
+
+func TestCentreonServiceDependencyGetServiceRefs(t *testing.T) {
+	o := &CentreonServiceDependency{
+		Spec: CentreonServiceDependencySpec{
+			Services: []CentreonServiceRef{
+				{Name: "s1"},
+				{Name: "s2"},
+			},
+			DependentServices: []CentreonServiceRef{
+				{Name: "s3"},
+				{Name: "s1"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"s1", "s2", "s3"}, o.GetServiceRefs())
+}
+
+func TestCentreonServiceDependencyIsValid(t *testing.T) {
+	// When is valid
+	o := &CentreonServiceDependency{
+		Spec: CentreonServiceDependencySpec{
+			Services:          []CentreonServiceRef{{Name: "s1"}},
+			DependentServices: []CentreonServiceRef{{Name: "s2"}},
+		},
+	}
+	assert.True(t, o.IsValid())
+
+	// When invalid
+	o.Spec.DependentServices = nil
+	assert.False(t, o.IsValid())
+	assert.False(t, (&CentreonServiceDependency{}).IsValid())
+}
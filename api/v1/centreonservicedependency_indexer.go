@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// SetupCentreonServiceDependencyIndexer setup indexer for CentreonServiceDependency
+func SetupCentreonServiceDependencyIndexer(k8sManager manager.Manager) (err error) {
+	// Index the CentreonService resources needed by controller to reconcile dependency when service change
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonServiceDependency{}, "spec.serviceRefs", func(o client.Object) []string {
+		p := o.(*CentreonServiceDependency)
+		return p.GetServiceRefs()
+	}); err != nil {
+		return err
+	}
+
+	// Index target platform needed by controller to reconcile dependency when platform change
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonServiceDependency{}, "spec.targetPlatform", func(o client.Object) []string {
+		p := o.(*CentreonServiceDependency)
+		return []string{p.GetPlatform()}
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
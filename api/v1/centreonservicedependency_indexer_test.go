@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *APITestSuite) TestSetupCentreonServiceDependencyIndexer() {
+	// Add CentreonServiceDependency to force  indexer execution
+
+	o := &CentreonServiceDependency{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: CentreonServiceDependencySpec{
+			PlatformRef: "test",
+			Services: []CentreonServiceRef{
+				{
+					Name: "service1",
+				},
+			},
+			DependentServices: []CentreonServiceRef{
+				{
+					Name: "service2",
+				},
+			},
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/monitoring-operator/api/shared"
+	"github.com/disaster37/operator-sdk-extra/pkg/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CentreonServiceDependencySpec defines the desired state of CentreonServiceDependency
+// +k8s:openapi-gen=true
+type CentreonServiceDependencySpec struct {
+	// PlatformRef is the target platform where to create the dependency
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	PlatformRef string `json:"platformRef,omitempty"`
+
+	// The dependency name. By default, it is the resource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The dependency description. By default, it is the dependency name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The services that others depend on (the parents)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	Services []CentreonServiceRef `json:"services"`
+
+	// The services that depend on the parents (the children)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	DependentServices []CentreonServiceRef `json:"dependentServices"`
+
+	// Inherit the dependencies of parent services
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	InheritsParent bool `json:"inheritsParent,omitempty"`
+
+	// The states of parent services that prevent the checks of dependent services
+	// o for ok, w for warning, u for unknown, c for critical, p for pending and n for none
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:items:Enum=o;w;u;c;p;n
+	// +optional
+	ExecutionFailureCriteria []string `json:"executionFailureCriteria,omitempty"`
+
+	// The states of parent services that prevent the notifications of dependent services
+	// o for ok, w for warning, u for unknown, c for critical, p for pending and n for none
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:items:Enum=o;w;u;c;p;n
+	// +optional
+	NotificationFailureCriteria []string `json:"notificationFailureCriteria,omitempty"`
+
+	// Policy define the policy that controller need to respect when it reconcile resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Policy shared.Policy `json:"policy,omitempty"`
+}
+
+// CentreonServiceDependencyStatus defines the observed state of CentreonServiceDependency
+type CentreonServiceDependencyStatus struct {
+	apis.BasicRemoteObjectStatus `json:",inline"`
+
+	// The dependency name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DependencyName string `json:"dependencyName,omitempty"`
+
+	// The platform ref
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PlatformRef string `json:"platformRef,omitempty"`
+
+	// The state of resource on Centreon before the operator take ownership of it (policy adopt)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// The changes made on Centreon outside of the operator that are not reverted (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Drift *shared.DriftStatus `json:"drift,omitempty"`
+
+	// The hash of expected resource on Centreon when it was reconciled the last time
+	// It used to know if the diff come from resource changes or from changes made on Centreon (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ExpectedHash string `json:"expectedHash,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// CentreonServiceDependency is the Schema for the centreonservicedependencies API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:resource:shortName=mcsd
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Dependency",type="string",JSONPath=".status.dependencyName"
+// +kubebuilder:printcolumn:name="Platform",type="string",JSONPath=".status.platformRef"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type CentreonServiceDependency struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CentreonServiceDependencySpec   `json:"spec,omitempty"`
+	Status CentreonServiceDependencyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CentreonServiceDependencyList contains a list of CentreonServiceDependency
+type CentreonServiceDependencyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CentreonServiceDependency `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CentreonServiceDependency{}, &CentreonServiceDependencyList{})
+}
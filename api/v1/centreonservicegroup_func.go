@@ -21,12 +21,15 @@ func (o *CentreonServiceGroup) GetExternalName() string {
 	return o.Spec.Name
 }
 
+// GetPlatform return the main platform where the serviceGroup is created
 func (o *CentreonServiceGroup) GetPlatform() string {
-	if o.Spec.PlatformRef == "" {
-		return "default"
-	}
+	return o.GetPlatforms()[0]
+}
 
-	return o.Spec.PlatformRef
+// GetPlatforms return all platforms where the serviceGroup is created
+// The main platform is the first one
+func (o *CentreonServiceGroup) GetPlatforms() []string {
+	return getPlatformRefs(o.Spec.PlatformRef, o.Spec.PlatformRefs)
 }
 
 // IsValid check Centreon service is valid for Centreon
@@ -113,3 +113,13 @@ func TestCentreonServiceGroupGetPlatform(t *testing.T) {
 
 	assert.Equal(t, "default", o.GetPlatform())
 }
+
+func TestCentreonServiceGroupGetPlatforms(t *testing.T) {
+	o := &CentreonServiceGroup{
+		Spec: CentreonServiceGroupSpec{
+			PlatformRefs: []string{"prd", "dr"},
+		},
+	}
+	assert.Equal(t, []string{"prd", "dr"}, o.GetPlatforms())
+	assert.Equal(t, "prd", o.GetPlatform())
+}
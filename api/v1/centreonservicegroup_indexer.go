@@ -20,7 +20,7 @@ func SetupCentreonServiceGroupIndexer(k8sManager manager.Manager) (err error) {
 	// Index target platform needed by webhook to controle unicity
 	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &CentreonServiceGroup{}, "spec.targetPlatform", func(o client.Object) []string {
 		p := o.(*CentreonServiceGroup)
-		return []string{p.GetPlatform()}
+		return p.GetPlatforms()
 	}); err != nil {
 		return err
 	}
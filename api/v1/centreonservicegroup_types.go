@@ -36,6 +36,12 @@ type CentreonServiceGroupSpec struct {
 	// +optional
 	PlatformRef string `json:"platformRef,omitempty"`
 
+	// PlatformRefs is the list of target platforms where to mirror the serviceGroup
+	// The first one is the main platform. It can't be set with platformRef
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	PlatformRefs []string `json:"platformRefs,omitempty"`
+
 	// The serviceGroup name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Name string `json:"name"`
@@ -70,6 +76,27 @@ type CentreonServiceGroupStatus struct {
 	// The platform ref
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	PlatformRef string `json:"platformRef,omitempty"`
+
+	// The service group status on each platform when it mirrored on multiple platforms
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Platforms []PlatformRefStatus `json:"platforms,omitempty"`
+
+	// The state of resource on Centreon before the operator take ownership of it (policy adopt)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// The changes made on Centreon outside of the operator that are not reverted (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Drift *shared.DriftStatus `json:"drift,omitempty"`
+
+	// The hash of expected resource on Centreon when it was reconciled the last time
+	// It used to know if the diff come from resource changes or from changes made on Centreon (drift report-only)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ExpectedHash string `json:"expectedHash,omitempty"`
 }
 
 //+kubebuilder:object:root=true
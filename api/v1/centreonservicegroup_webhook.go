@@ -47,23 +47,25 @@ var _ webhook.Validator = &CentreonServiceGroup{}
 
 func (r *CentreonServiceGroup) validateResourceUnicity() *field.Error {
 	// Check if resource already exist with same name on some remote target platform
-	listObjects := &CentreonServiceGroupList{}
-	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s,spec.targetPlatform=%s", r.GetExternalName(), r.GetPlatform()))
-	if err := shared.Client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
-		panic(err)
-	}
-	if len(listObjects.Items) > 0 {
-		isError := false
-		existingResources := make([]string, 0, len(listObjects.Items))
-		for _, ag := range listObjects.Items {
-			// exclude themself
-			if ag.UID != r.UID {
-				existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
-				isError = true
-			}
+	for _, platformRef := range r.GetPlatforms() {
+		listObjects := &CentreonServiceGroupList{}
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s,spec.targetPlatform=%s", r.GetExternalName(), platformRef))
+		if err := shared.Client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+			panic(err)
 		}
-		if isError {
-			return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same monitoring platform with the same name: %s", strings.Join(existingResources, ", ")))
+		if len(listObjects.Items) > 0 {
+			isError := false
+			existingResources := make([]string, 0, len(listObjects.Items))
+			for _, ag := range listObjects.Items {
+				// exclude themself
+				if ag.UID != r.UID {
+					existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
+					isError = true
+				}
+			}
+			if isError {
+				return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same monitoring platform with the same name: %s", strings.Join(existingResources, ", ")))
+			}
 		}
 	}
 
@@ -72,7 +74,7 @@ func (r *CentreonServiceGroup) validateResourceUnicity() *field.Error {
 
 func (r *CentreonServiceGroup) validateImmatablePlatform(current, old *CentreonServiceGroup) *field.Error {
 	if current.GetPlatform() != old.GetPlatform() {
-		return field.Forbidden(field.NewPath("spec").Child("platformRef"), "The field 'spec.platformRef' is immutable")
+		return field.Forbidden(field.NewPath("spec").Child("platformRef"), "The main platform ('spec.platformRef' or the first of 'spec.platformRefs') is immutable")
 	}
 	return nil
 }
@@ -86,6 +88,8 @@ func (r *CentreonServiceGroup) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, validateTargetPlatforms(r.Spec.PlatformRef, r.Spec.PlatformRefs, r.Namespace)...)
+
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
 			r.GroupVersionKind().GroupKind(),
@@ -109,6 +113,8 @@ func (r *CentreonServiceGroup) ValidateUpdate(old runtime.Object) (admission.War
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, validateTargetPlatforms(r.Spec.PlatformRef, r.Spec.PlatformRefs, r.Namespace)...)
+
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
 			r.GroupVersionKind().GroupKind(),
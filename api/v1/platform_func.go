@@ -1,6 +1,28 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/pkg/object"
+import (
+	"slices"
+	"time"
+
+	"github.com/disaster37/operator-sdk-extra/pkg/object"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default interval between two health checks of platform
+	DefaultHealthCheckInterval = 5 * time.Minute
+
+	// DefaultOrphanScanInterval is the default interval between two scans of orphan services
+	DefaultOrphanScanInterval = 1 * time.Hour
+
+	// DefaultOrphanGracePeriod is the default duration a service need to be orphan before to be deleted
+	DefaultOrphanGracePeriod = 24 * time.Hour
+
+	// DefaultComment is the comment set on services created by the operator when platform not provide default comment
+	DefaultComment = "Managed by monitoring-operator"
+
+	// defaultPlatformRef is the platform ref used when resource not provide it
+	defaultPlatformRef = "default"
+)
 
 // GetStatus implement the object.MultiPhaseObject
 func (h *Platform) GetStatus() object.RemoteObjectStatus {
@@ -22,3 +44,158 @@ func (h *Platform) IsDebug() bool {
 
 	return false
 }
+
+// GetHealthCheckInterval return the interval between two health checks
+// It return the default interval if not set
+func (h *Platform) GetHealthCheckInterval() time.Duration {
+	if h.Spec.HealthCheckInterval != nil && h.Spec.HealthCheckInterval.Duration > 0 {
+		return h.Spec.HealthCheckInterval.Duration
+	}
+
+	return DefaultHealthCheckInterval
+}
+
+// IsOrphanDetectionEnabled return true if the platform need to be scanned to find orphan services
+func (h *Platform) IsOrphanDetectionEnabled() bool {
+	return h.Spec.OrphanDetection != nil && h.Spec.OrphanDetection.Enabled
+}
+
+// GetOrphanScanInterval return the interval between two scans of orphan services
+// It return the default interval if not set
+func (h *Platform) GetOrphanScanInterval() time.Duration {
+	if h.Spec.OrphanDetection != nil && h.Spec.OrphanDetection.Interval != nil && h.Spec.OrphanDetection.Interval.Duration > 0 {
+		return h.Spec.OrphanDetection.Interval.Duration
+	}
+
+	return DefaultOrphanScanInterval
+}
+
+// GetOrphanGracePeriod return the duration a service need to be orphan before to be deleted
+// It return the default grace period if not set
+func (h *Platform) GetOrphanGracePeriod() time.Duration {
+	if h.Spec.OrphanDetection != nil && h.Spec.OrphanDetection.GracePeriod != nil && h.Spec.OrphanDetection.GracePeriod.Duration >= 0 {
+		return h.Spec.OrphanDetection.GracePeriod.Duration
+	}
+
+	return DefaultOrphanGracePeriod
+}
+
+// GetManagedComment return the comment set on services created by the operator on this platform
+func (h *Platform) GetManagedComment() string {
+	if h.Spec.Defaults != nil && h.Spec.Defaults.Comment != "" {
+		return h.Spec.Defaults.Comment
+	}
+
+	return DefaultComment
+}
+
+// IsAllowedNamespace return true if resources on namespace can use this platform
+func (h *Platform) IsAllowedNamespace(namespace string) bool {
+	if len(h.Spec.AllowedNamespaces) == 0 {
+		return true
+	}
+
+	return slices.Contains(h.Spec.AllowedNamespaces, namespace)
+}
+
+// IsDefaultForNamespace return true if the platform is the default platform of namespace
+func (h *Platform) IsDefaultForNamespace(namespace string) bool {
+	return slices.Contains(h.Spec.DefaultForNamespaces, namespace)
+}
+
+// GetSecretNames return the name of secrets used by the platform
+func (h *Platform) GetSecretNames() (secrets []string) {
+	secrets = make([]string, 0)
+	if h.Spec.CentreonSettings == nil {
+		return secrets
+	}
+
+	if h.Spec.CentreonSettings.Secret != "" {
+		secrets = append(secrets, h.Spec.CentreonSettings.Secret)
+	}
+	if h.Spec.CentreonSettings.CASecretRef != nil && !slices.Contains(secrets, h.Spec.CentreonSettings.CASecretRef.Name) {
+		secrets = append(secrets, h.Spec.CentreonSettings.CASecretRef.Name)
+	}
+	if h.Spec.CentreonSettings.ClientCertificateSecretRef != nil && !slices.Contains(secrets, h.Spec.CentreonSettings.ClientCertificateSecretRef.Name) {
+		secrets = append(secrets, h.Spec.CentreonSettings.ClientCertificateSecretRef.Name)
+	}
+
+	return secrets
+}
+
+// GetConfigMapNames return the name of configmaps used by the platform
+func (h *Platform) GetConfigMapNames() (configMaps []string) {
+	configMaps = make([]string, 0)
+	if h.Spec.CentreonSettings == nil {
+		return configMaps
+	}
+
+	if h.Spec.CentreonSettings.CAConfigMapRef != nil {
+		configMaps = append(configMaps, h.Spec.CentreonSettings.CAConfigMapRef.Name)
+	}
+
+	return configMaps
+}
+
+// GetCentreonURLs return the ordered list of URL to access on Centreon API
+func (h *Platform) GetCentreonURLs() []string {
+	if h.Spec.CentreonSettings == nil {
+		return nil
+	}
+	if len(h.Spec.CentreonSettings.URLs) > 0 {
+		return h.Spec.CentreonSettings.URLs
+	}
+	if h.Spec.CentreonSettings.URL != "" {
+		return []string{h.Spec.CentreonSettings.URL}
+	}
+
+	return nil
+}
+
+// getPlatformRefs return the target platforms of resource without duplicate
+// It return the default platform when no platform is provided
+func getPlatformRefs(platformRef string, platformRefs []string) []string {
+	refs := make([]string, 0, len(platformRefs)+1)
+	if platformRef != "" {
+		refs = append(refs, platformRef)
+	}
+	for _, ref := range platformRefs {
+		if ref != "" && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		refs = append(refs, defaultPlatformRef)
+	}
+
+	return refs
+}
+
+// GetPlatformRefStatus return the status of resource on platform
+// It return nil if not found
+func GetPlatformRefStatus(statuses []PlatformRefStatus, name string) *PlatformRefStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+
+	return nil
+}
+
+// SetPlatformRefStatus add or replace the status of resource on platform
+func SetPlatformRefStatus(statuses *[]PlatformRefStatus, status PlatformRefStatus) {
+	if current := GetPlatformRefStatus(*statuses, status.Name); current != nil {
+		*current = status
+		return
+	}
+
+	*statuses = append(*statuses, status)
+}
+
+// RemovePlatformRefStatus remove the status of resource on platform
+func RemovePlatformRefStatus(statuses *[]PlatformRefStatus, name string) {
+	*statuses = slices.DeleteFunc(*statuses, func(status PlatformRefStatus) bool {
+		return status.Name == name
+	})
+}
@@ -2,9 +2,11 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/disaster37/operator-sdk-extra/pkg/apis"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -59,3 +61,174 @@ func TestPlateformIsDebug(t *testing.T) {
 	o.Spec.Debug = ptr.To(false)
 	assert.False(t, o.IsDebug())
 }
+
+func TestPlatformGetHealthCheckInterval(t *testing.T) {
+	// With default value
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{},
+	}
+	assert.Equal(t, DefaultHealthCheckInterval, o.GetHealthCheckInterval())
+
+	// When set
+	o.Spec.HealthCheckInterval = &metav1.Duration{Duration: 30 * time.Second}
+	assert.Equal(t, 30*time.Second, o.GetHealthCheckInterval())
+}
+
+func TestPlatformOrphanDetection(t *testing.T) {
+	// With default value
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{},
+	}
+	assert.False(t, o.IsOrphanDetectionEnabled())
+	assert.Equal(t, DefaultOrphanScanInterval, o.GetOrphanScanInterval())
+	assert.Equal(t, DefaultOrphanGracePeriod, o.GetOrphanGracePeriod())
+
+	// When set
+	o.Spec.OrphanDetection = &PlatformOrphanDetection{
+		Enabled:     true,
+		Interval:    &metav1.Duration{Duration: 10 * time.Minute},
+		GracePeriod: &metav1.Duration{Duration: 0},
+	}
+	assert.True(t, o.IsOrphanDetectionEnabled())
+	assert.Equal(t, 10*time.Minute, o.GetOrphanScanInterval())
+	assert.Equal(t, time.Duration(0), o.GetOrphanGracePeriod())
+}
+
+func TestPlatformGetManagedComment(t *testing.T) {
+	// With default value
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{},
+	}
+	assert.Equal(t, DefaultComment, o.GetManagedComment())
+
+	// When set on defaults
+	o.Spec.Defaults = &PlatformDefaults{Comment: "Managed by team A"}
+	assert.Equal(t, "Managed by team A", o.GetManagedComment())
+}
+
+func TestPlatformIsAllowedNamespace(t *testing.T) {
+	// When all namespaces are allowed
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{},
+	}
+	assert.True(t, o.IsAllowedNamespace("tenant1"))
+
+	// When namespaces are restricted
+	o.Spec.AllowedNamespaces = []string{"tenant1", "tenant2"}
+	assert.True(t, o.IsAllowedNamespace("tenant1"))
+	assert.False(t, o.IsAllowedNamespace("tenant3"))
+}
+
+func TestPlatformIsDefaultForNamespace(t *testing.T) {
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{},
+	}
+	assert.False(t, o.IsDefaultForNamespace("tenant1"))
+
+	o.Spec.DefaultForNamespaces = []string{"tenant1"}
+	assert.True(t, o.IsDefaultForNamespace("tenant1"))
+	assert.False(t, o.IsDefaultForNamespace("tenant2"))
+}
+
+func TestPlatformGetSecretNames(t *testing.T) {
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{},
+	}
+	assert.Empty(t, o.GetSecretNames())
+
+	o.Spec.CentreonSettings = &PlatformSpecCentreonSettings{
+		Secret: "credentials",
+		CASecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "tls"},
+			Key:                  "ca.crt",
+		},
+		ClientCertificateSecretRef: &corev1.LocalObjectReference{Name: "tls"},
+	}
+	assert.Equal(t, []string{"credentials", "tls"}, o.GetSecretNames())
+}
+
+func TestPlatformGetConfigMapNames(t *testing.T) {
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{
+			CentreonSettings: &PlatformSpecCentreonSettings{},
+		},
+	}
+	assert.Empty(t, o.GetConfigMapNames())
+
+	o.Spec.CentreonSettings.CAConfigMapRef = &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "ca"},
+		Key:                  "ca.crt",
+	}
+	assert.Equal(t, []string{"ca"}, o.GetConfigMapNames())
+}
+
+func TestPlatformGetCentreonURLs(t *testing.T) {
+	o := &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: PlatformSpec{},
+	}
+	assert.Empty(t, o.GetCentreonURLs())
+
+	// With URL
+	o.Spec.CentreonSettings = &PlatformSpecCentreonSettings{
+		URL: "http://primary",
+	}
+	assert.Equal(t, []string{"http://primary"}, o.GetCentreonURLs())
+
+	// With URLs
+	o.Spec.CentreonSettings.URLs = []string{"http://primary", "http://standby"}
+	assert.Equal(t, []string{"http://primary", "http://standby"}, o.GetCentreonURLs())
+}
+
+func TestPlatformRefStatus(t *testing.T) {
+	var statuses []PlatformRefStatus
+
+	// When not found
+	assert.Nil(t, GetPlatformRefStatus(statuses, "prd"))
+
+	// Add status
+	SetPlatformRefStatus(&statuses, PlatformRefStatus{Name: "prd", IsSync: true})
+	SetPlatformRefStatus(&statuses, PlatformRefStatus{Name: "dr", LastErrorMessage: "error"})
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "error", GetPlatformRefStatus(statuses, "dr").LastErrorMessage)
+
+	// Replace status
+	SetPlatformRefStatus(&statuses, PlatformRefStatus{Name: "dr", IsSync: true})
+	assert.Len(t, statuses, 2)
+	assert.True(t, GetPlatformRefStatus(statuses, "dr").IsSync)
+
+	// Remove status
+	RemovePlatformRefStatus(&statuses, "prd")
+	assert.Equal(t, []PlatformRefStatus{{Name: "dr", IsSync: true}}, statuses)
+}
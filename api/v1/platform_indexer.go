@@ -10,9 +10,18 @@ import (
 
 // SetupPlatformIndexer setup indexer for platform
 func SetupPlatformIndexer(k8sManager manager.Manager) (err error) {
+	// Add indexer to get platforms that use secret (credentials, CA or client certificate)
 	if err := k8sManager.GetFieldIndexer().IndexField(context.Background(), &Platform{}, "spec.centreonSettings.secret", func(o client.Object) []string {
 		p := o.(*Platform)
-		return []string{p.Spec.CentreonSettings.Secret}
+		return p.GetSecretNames()
+	}); err != nil {
+		return err
+	}
+
+	// Add indexer to get platforms that use configmap
+	if err := k8sManager.GetFieldIndexer().IndexField(context.Background(), &Platform{}, "spec.centreonSettings.configMap", func(o client.Object) []string {
+		p := o.(*Platform)
+		return p.GetConfigMapNames()
 	}); err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"github.com/disaster37/operator-sdk-extra/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -49,12 +50,150 @@ type PlatformSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Debug *bool `json:"debug,omitempty"`
+
+	// HealthCheckInterval is the interval between two health checks of the plateform API
+	// Default to 5m
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	HealthCheckInterval *metav1.Duration `json:"healthCheckInterval,omitempty"`
+
+	// RateLimit permit to limit the calls on plateform API
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RateLimit *PlatformRateLimit `json:"rateLimit,omitempty"`
+
+	// AllowedNamespaces is the list of namespaces where resources can use this plateform
+	// All namespaces are allowed when empty
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// DefaultForNamespaces is the list of namespaces where this plateform is used when is not specify on resource to create
+	// It take precedence over the plateform set as default
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	DefaultForNamespaces []string `json:"defaultForNamespaces,omitempty"`
+
+	// Defaults is the default values used on CentreonService when they are not set on spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Defaults *PlatformDefaults `json:"defaults,omitempty"`
+
+	// OrphanDetection permit to find the services created by the operator on plateform that are not handled anymore by any resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	OrphanDetection *PlatformOrphanDetection `json:"orphanDetection,omitempty"`
+}
+
+// PlatformOrphanDetection is the settings to find and garbage collect orphan services on plateform
+// A service is orphan when it has the comment set by the operator and no resource reference it
+type PlatformOrphanDetection struct {
+	// Enabled is true to scan periodically the plateform to find orphan services
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval is the interval between two scans
+	// Default to 1h
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Delete is true to delete the orphan services after the grace period
+	// Default to false, the orphan services are only reported
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Delete bool `json:"delete,omitempty"`
+
+	// GracePeriod is the duration a service need to be orphan before to be deleted
+	// Default to 24h
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// PlatformOrphan is a service on plateform that is not handled anymore by any resource
+type PlatformOrphan struct {
+	// Host is the host where the service is attached
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Host string `json:"host"`
+
+	// Name is the service name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// DetectedAt is the time when the service is detected as orphan for the first time
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// Owned is true when the service has the owner of this cluster
+	// Only the owned services can be deleted, the others are found by their comment and are only reported
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Owned bool `json:"owned,omitempty"`
+}
+
+// PlatformDefaults is the default values used on CentreonService
+type PlatformDefaults struct {
+	// The host to attach the service
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// The service templates
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// The list of service groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// The list of categories
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Categories []string `json:"categories,omitempty"`
+
+	// The map of macros. The macros set on service take precedence
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Macros map[string]string `json:"macros,omitempty"`
+
+	// The normal check interval
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NormalCheckInterval string `json:"normalCheckInterval,omitempty"`
+
+	// The retry check interval
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RetryCheckInterval string `json:"retryCheckInterval,omitempty"`
+
+	// The max check attemps
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxCheckAttempts string `json:"maxCheckAttempts,omitempty"`
+
+	// The comment set on service
+	// Default to `Managed by monitoring-operator`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Comment string `json:"comment,omitempty"`
 }
 
 type PlatformSpecCentreonSettings struct {
 	// URL is the full URL to access on Centreon API
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	URL string `json:"url"`
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLs is the ordered list of full URL to access on Centreon API, like primary and standby central
+	// The operator switch on next URL when the current one is not healthy
+	// It take precedence over URL
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	URLs []string `json:"urls,omitempty"`
 
 	// SelfSignedCertificat is true if you shouldn't check Centreon API certificate
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -63,12 +202,128 @@ type PlatformSpecCentreonSettings struct {
 	// Secret is the secret that store the (username and password) or permanent token to access on Centreon API
 	// It need to have ()`username` and `password`) or token key
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	Secret string `json:"secret"`
+	// +optional
+	Secret string `json:"secret,omitempty"`
+
+	// CredentialsPath is the directory on operator container that store the files (`username` and `password`) or `token` to access on Centreon API
+	// It permit to use projected credentials like Vault agent or CSI secrets store. The files are reloaded when they change.
+	// It take precedence over secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CredentialsPath string `json:"credentialsPath,omitempty"`
+
+	// CASecretRef is the secret key that store the CA bundle (PEM) to trust Centreon API certificate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// CAConfigMapRef is the configmap key that store the CA bundle (PEM) to trust Centreon API certificate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CAConfigMapRef *corev1.ConfigMapKeySelector `json:"caConfigMapRef,omitempty"`
+
+	// ClientCertificateSecretRef is the secret of type TLS that store the client certificate (`tls.crt` and `tls.key`) to use mTLS with Centreon API
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ClientCertificateSecretRef *corev1.LocalObjectReference `json:"clientCertificateSecretRef,omitempty"`
+
+	// Proxy is the HTTP proxy URL to access on Centreon API
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Proxy string `json:"proxy,omitempty"`
+
+	// Timeout is the timeout of each request on Centreon API
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PlatformRateLimit is the client side limits to access on plateform API
+type PlatformRateLimit struct {
+	// QPS is the maximum number of requests per second on plateform API
+	// Default to unlimited
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst is the maximum number of requests that can exceed the QPS
+	// Default to QPS
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+
+	// MaxInFlight is the maximum number of concurrent requests on plateform API
+	// Default to unlimited
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxInFlight int32 `json:"maxInFlight,omitempty"`
+
+	// MaxRetries is the maximum number of retries with backoff when plateform API is overloaded (HTTP 429 or 5xx)
+	// Default to 3
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// PlatformRefStatus is the status of resource on one of its target platforms
+type PlatformRefStatus struct {
+	// Name is the platform name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Host is the host where the resource is attached on platform
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// ExternalName is the resource name on platform
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ExternalName string `json:"externalName,omitempty"`
+
+	// IsSync is true if the resource is up to date on platform
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	IsSync bool `json:"isSync"`
+
+	// LastErrorMessage is the last error when reconcile the resource on platform
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastErrorMessage string `json:"lastErrorMessage,omitempty"`
 }
 
 // PlatformStatus defines the observed state of Platform
 type PlatformStatus struct {
 	apis.BasicRemoteObjectStatus `json:",inline"`
+
+	// Version is the version of the monitoring platform detected by the last health check
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// CredentialsHash is the hash of the credentials currently used to access on plateform API
+	// It change when the credentials are rotated
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	CredentialsHash string `json:"credentialsHash,omitempty"`
+
+	// ActiveEndpoint is the URL currently used to access on plateform API
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ActiveEndpoint string `json:"activeEndpoint,omitempty"`
+
+	// LastOrphanScan is the time of the last scan of orphan services
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastOrphanScan *metav1.Time `json:"lastOrphanScan,omitempty"`
+
+	// Orphans is the list of orphan services found by the last scan
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Orphans []PlatformOrphan `json:"orphans,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -80,6 +335,9 @@ type PlatformStatus struct {
 // +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
 // +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Authenticated",type="string",JSONPath=".status.conditions[?(@.type=='Authenticated')].status",description="Is authenticated on platform"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Platform version"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.activeEndpoint",description="Active endpoint",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Platform struct {
 	metav1.TypeMeta   `json:",inline"`
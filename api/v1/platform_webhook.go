@@ -26,6 +26,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,6 +53,12 @@ func (r *Platform) validateRequiredFields() *field.Error {
 		if r.Spec.CentreonSettings == nil {
 			return field.Required(field.NewPath("spec").Child("centreonSettings"), "You need to provide the Centreon settings")
 		}
+		if len(r.GetCentreonURLs()) == 0 {
+			return field.Required(field.NewPath("spec").Child("centreonSettings").Child("url"), "You need to provide 'url' or 'urls' to access on Centreon API")
+		}
+		if r.Spec.CentreonSettings.Secret == "" && r.Spec.CentreonSettings.CredentialsPath == "" {
+			return field.Required(field.NewPath("spec").Child("centreonSettings").Child("secret"), "You need to provide 'secret' or 'credentialsPath' to access on Centreon API")
+		}
 	}
 
 	return nil
@@ -93,6 +100,117 @@ func (r *Platform) validateUnicityOfDefaultPlatform() *field.Error {
 	return nil
 }
 
+func (r *Platform) validateDefaultForNamespaces() *field.Error {
+	path := field.NewPath("spec").Child("defaultForNamespaces")
+	for _, ns := range r.Spec.DefaultForNamespaces {
+		if !r.IsAllowedNamespace(ns) {
+			return field.Invalid(path, ns, "The namespace need to be on 'spec.allowedNamespaces'")
+		}
+	}
+
+	if len(r.Spec.DefaultForNamespaces) == 0 {
+		return nil
+	}
+
+	// Check that only one platform is the default on each namespace
+	listObjects := &PlatformList{}
+	if err := shared.Client.List(context.Background(), listObjects, &client.ListOptions{Namespace: r.Namespace}); err != nil {
+		panic(err)
+	}
+	existingResources := make([]string, 0)
+	for _, p := range listObjects.Items {
+		// exclude themself
+		if p.UID == r.UID {
+			continue
+		}
+		for _, ns := range r.Spec.DefaultForNamespaces {
+			if p.IsDefaultForNamespace(ns) {
+				existingResources = append(existingResources, fmt.Sprintf("'%s/%s' on namespace %s", p.Namespace, p.Name, ns))
+			}
+		}
+	}
+	if len(existingResources) > 0 {
+		return field.Duplicate(path, fmt.Sprintf("There are some other platform set as default platform: %s", strings.Join(existingResources, ", ")))
+	}
+
+	return nil
+}
+
+// getTargetPlatform permit to get the platform used by resource on namespace
+// It return nil if the platform not yet exist
+func getTargetPlatform(platformRef string, namespace string) *Platform {
+	ns, err := helpers.GetOperatorNamespace()
+	if err != nil {
+		panic(err)
+	}
+
+	if platformRef != "" {
+		p := &Platform{}
+		if err = shared.Client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: platformRef}, p); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			panic(err)
+		}
+		return p
+	}
+
+	// Search the default platform of namespace, else the default platform
+	listObjects := &PlatformList{}
+	if err = shared.Client.List(context.Background(), listObjects, &client.ListOptions{Namespace: ns}); err != nil {
+		panic(err)
+	}
+	var defaultPlatform *Platform
+	for i, p := range listObjects.Items {
+		if p.IsDefaultForNamespace(namespace) {
+			return &listObjects.Items[i]
+		}
+		if p.Spec.IsDefault {
+			defaultPlatform = &listObjects.Items[i]
+		}
+	}
+
+	return defaultPlatform
+}
+
+// validateTargetPlatform permit to check that the resource namespace is allowed to use the target platform
+// It do nothing if the platform not yet exist
+func validateTargetPlatform(platformRef string, namespace string, fldPath *field.Path) *field.Error {
+	if platformRef == "" {
+		return nil
+	}
+
+	p := getTargetPlatform(platformRef, namespace)
+	if p == nil {
+		return nil
+	}
+
+	if !p.IsAllowedNamespace(namespace) {
+		return field.Forbidden(fldPath, fmt.Sprintf("The platform %s can't be used on namespace %s", platformRef, namespace))
+	}
+
+	return nil
+}
+
+// validateTargetPlatforms check that resource can use all its target platforms
+// The platformRef and platformRefs can't be set together
+func validateTargetPlatforms(platformRef string, platformRefs []string, namespace string) (errs field.ErrorList) {
+	if platformRef != "" && len(platformRefs) > 0 {
+		errs = append(errs, field.Forbidden(field.NewPath("spec").Child("platformRefs"), "The fields 'spec.platformRef' and 'spec.platformRefs' can't be set together"))
+	}
+
+	if err := validateTargetPlatform(platformRef, namespace, field.NewPath("spec").Child("platformRef")); err != nil {
+		errs = append(errs, err)
+	}
+	for i, ref := range platformRefs {
+		if err := validateTargetPlatform(ref, namespace, field.NewPath("spec").Child("platformRefs").Index(i)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Platform) ValidateCreate() (admission.Warnings, error) {
 	shared.Logger.Debugf("validate create %s/%s", r.Namespace, r.Name)
@@ -110,6 +228,10 @@ func (r *Platform) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateDefaultForNamespaces(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
 			r.GroupVersionKind().GroupKind(),
@@ -136,6 +258,10 @@ func (r *Platform) ValidateUpdate(old runtime.Object) (admission.Warnings, error
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateDefaultForNamespaces(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
 			r.GroupVersionKind().GroupKind(),
@@ -81,4 +81,65 @@ func (t *APITestSuite) TestSetupPlatformWebhook() {
 	}
 	err = t.k8sClient.Create(context.Background(), o)
 	assert.Error(t.T(), err)
+
+	// Need failed when default namespace is not allowed
+	o = &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook4",
+			Namespace: "default",
+		},
+		Spec: PlatformSpec{
+			PlatformType: "centreon",
+			CentreonSettings: &PlatformSpecCentreonSettings{
+				URL:    "http://localhost",
+				Secret: "test",
+			},
+			AllowedNamespaces:    []string{"tenant1"},
+			DefaultForNamespaces: []string{"tenant2"},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when multiple default platform on same namespace
+	o.Spec.DefaultForNamespaces = []string{"tenant1"}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	o = &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook5",
+			Namespace: "default",
+		},
+		Spec: PlatformSpec{
+			PlatformType: "centreon",
+			CentreonSettings: &PlatformSpecCentreonSettings{
+				URL:    "http://localhost",
+				Secret: "test",
+			},
+			DefaultForNamespaces: []string{"tenant1"},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when not provide secret or credentialsPath
+	o = &Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: PlatformSpec{
+			PlatformType: "centreon",
+			CentreonSettings: &PlatformSpecCentreonSettings{
+				URL: "http://localhost",
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	o.Spec.CentreonSettings.CredentialsPath = "/vault/secrets/centreon"
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
 }
@@ -101,11 +101,14 @@ func (t *APITestSuite) SetupSuite() {
 		SetupPlatformIndexer,
 		SetupCentreonServiceIndexer,
 		SetupCentreonServiceGroupIndexer,
+		SetupCentreonServiceDependencyIndexer,
+		SetupCentreonEscalationIndexer,
 		SetupCertificateIndexer,
 		SetupIngressIndexer,
 		SetupNamespaceIndexer,
 		SetupNodeIndexer,
 		SetupRouteIndexer,
+		SetupPersistentVolumeClaimIndexer,
 	); err != nil {
 		panic(err)
 	}
@@ -64,9 +64,51 @@ func SetupNodeIndexer(k8sManager manager.Manager) (err error) {
 }
 
 // SetupCertificateIndexer setup indexer for secret (certificate)
+// It also index the services targeted by the route backends on `spec.services`
 func SetupRouteIndexer(k8sManager manager.Manager) (err error) {
 	if err := k8sManager.GetFieldIndexer().IndexField(context.Background(), &routev1.Route{}, fmt.Sprintf("%s.templates", MonitoringAnnotationKey), templateIndexer); err != nil {
 		return err
 	}
+	if err := k8sManager.GetFieldIndexer().IndexField(context.Background(), &routev1.Route{}, "spec.services", RouteServicesIndexer); err != nil {
+		return err
+	}
 	return nil
 }
+
+// RouteServicesIndexer return the name of services targeted by the route and its alternate backends
+func RouteServicesIndexer(o client.Object) []string {
+	r := o.(*routev1.Route)
+
+	res := make([]string, 0, len(r.Spec.AlternateBackends)+1)
+	for _, target := range append([]routev1.RouteTargetReference{r.Spec.To}, r.Spec.AlternateBackends...) {
+		if target.Name != "" && (target.Kind == "" || target.Kind == "Service") {
+			res = append(res, target.Name)
+		}
+	}
+	return res
+}
+
+// SetupPersistentVolumeClaimIndexer setup indexer for persistent volume claim
+// It also index the claims used by pods on `spec.volumes.persistentVolumeClaim`
+func SetupPersistentVolumeClaimIndexer(k8sManager manager.Manager) (err error) {
+	if err := k8sManager.GetFieldIndexer().IndexField(context.Background(), &corev1.PersistentVolumeClaim{}, fmt.Sprintf("%s.templates", MonitoringAnnotationKey), templateIndexer); err != nil {
+		return err
+	}
+	if err := k8sManager.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, "spec.volumes.persistentVolumeClaim", PodClaimsIndexer); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PodClaimsIndexer return the name of persistent volume claims used by the pod
+func PodClaimsIndexer(o client.Object) []string {
+	pod := o.(*corev1.Pod)
+
+	res := make([]string, 0)
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName != "" {
+			res = append(res, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	return res
+}
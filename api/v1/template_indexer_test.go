@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -159,3 +160,26 @@ func (t *APITestSuite) TestSetupRouteIndexer() {
 	err := t.k8sClient.Create(context.Background(), route)
 	assert.NoError(t.T(), err)
 }
+
+func (t *APITestSuite) TestSetupPersistentVolumeClaimIndexer() {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			Annotations: map[string]string{
+				fmt.Sprintf("%s/templates", MonitoringAnnotationKey): `[{"namespace": "default", "name": "template1"}, {"namespace": "default", "name": "template2"}]`,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), pvc)
+	assert.NoError(t.T(), err)
+}
@@ -21,9 +21,128 @@ limitations under the License.
 package v1
 
 import (
+	"github.com/disaster37/monitoring-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonEscalation) DeepCopyInto(out *CentreonEscalation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonEscalation.
+func (in *CentreonEscalation) DeepCopy() *CentreonEscalation {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonEscalation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CentreonEscalation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonEscalationList) DeepCopyInto(out *CentreonEscalationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CentreonEscalation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonEscalationList.
+func (in *CentreonEscalationList) DeepCopy() *CentreonEscalationList {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonEscalationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CentreonEscalationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonEscalationSpec) DeepCopyInto(out *CentreonEscalationSpec) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]CentreonServiceRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContactGroups != nil {
+		in, out := &in.ContactGroups, &out.ContactGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotificationOptions != nil {
+		in, out := &in.NotificationOptions, &out.NotificationOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Policy.DeepCopyInto(&out.Policy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonEscalationSpec.
+func (in *CentreonEscalationSpec) DeepCopy() *CentreonEscalationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonEscalationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonEscalationStatus) DeepCopyInto(out *CentreonEscalationStatus) {
+	*out = *in
+	in.BasicRemoteObjectStatus.DeepCopyInto(&out.BasicRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(shared.DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonEscalationStatus.
+func (in *CentreonEscalationStatus) DeepCopy() *CentreonEscalationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonEscalationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CentreonService) DeepCopyInto(out *CentreonService) {
 	*out = *in
@@ -51,6 +170,127 @@ func (in *CentreonService) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonServiceDependency) DeepCopyInto(out *CentreonServiceDependency) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceDependency.
+func (in *CentreonServiceDependency) DeepCopy() *CentreonServiceDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonServiceDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CentreonServiceDependency) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonServiceDependencyList) DeepCopyInto(out *CentreonServiceDependencyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CentreonServiceDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceDependencyList.
+func (in *CentreonServiceDependencyList) DeepCopy() *CentreonServiceDependencyList {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonServiceDependencyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CentreonServiceDependencyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonServiceDependencySpec) DeepCopyInto(out *CentreonServiceDependencySpec) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]CentreonServiceRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependentServices != nil {
+		in, out := &in.DependentServices, &out.DependentServices
+		*out = make([]CentreonServiceRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecutionFailureCriteria != nil {
+		in, out := &in.ExecutionFailureCriteria, &out.ExecutionFailureCriteria
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotificationFailureCriteria != nil {
+		in, out := &in.NotificationFailureCriteria, &out.NotificationFailureCriteria
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Policy.DeepCopyInto(&out.Policy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceDependencySpec.
+func (in *CentreonServiceDependencySpec) DeepCopy() *CentreonServiceDependencySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonServiceDependencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonServiceDependencyStatus) DeepCopyInto(out *CentreonServiceDependencyStatus) {
+	*out = *in
+	in.BasicRemoteObjectStatus.DeepCopyInto(&out.BasicRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(shared.DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceDependencyStatus.
+func (in *CentreonServiceDependencyStatus) DeepCopy() *CentreonServiceDependencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonServiceDependencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CentreonServiceGroup) DeepCopyInto(out *CentreonServiceGroup) {
 	*out = *in
@@ -113,6 +353,11 @@ func (in *CentreonServiceGroupList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CentreonServiceGroupSpec) DeepCopyInto(out *CentreonServiceGroupSpec) {
 	*out = *in
+	if in.PlatformRefs != nil {
+		in, out := &in.PlatformRefs, &out.PlatformRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Policy.DeepCopyInto(&out.Policy)
 }
 
@@ -130,6 +375,21 @@ func (in *CentreonServiceGroupSpec) DeepCopy() *CentreonServiceGroupSpec {
 func (in *CentreonServiceGroupStatus) DeepCopyInto(out *CentreonServiceGroupStatus) {
 	*out = *in
 	in.BasicRemoteObjectStatus.DeepCopyInto(&out.BasicRemoteObjectStatus)
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]PlatformRefStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(shared.DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceGroupStatus.
@@ -142,6 +402,21 @@ func (in *CentreonServiceGroupStatus) DeepCopy() *CentreonServiceGroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonServiceIdentity) DeepCopyInto(out *CentreonServiceIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceIdentity.
+func (in *CentreonServiceIdentity) DeepCopy() *CentreonServiceIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonServiceIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CentreonServiceList) DeepCopyInto(out *CentreonServiceList) {
 	*out = *in
@@ -174,9 +449,29 @@ func (in *CentreonServiceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentreonServiceRef) DeepCopyInto(out *CentreonServiceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceRef.
+func (in *CentreonServiceRef) DeepCopy() *CentreonServiceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CentreonServiceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CentreonServiceSpec) DeepCopyInto(out *CentreonServiceSpec) {
 	*out = *in
+	if in.PlatformRefs != nil {
+		in, out := &in.PlatformRefs, &out.PlatformRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Groups != nil {
 		in, out := &in.Groups, &out.Groups
 		*out = make([]string, len(*in))
@@ -189,6 +484,13 @@ func (in *CentreonServiceSpec) DeepCopyInto(out *CentreonServiceSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.MacrosFrom != nil {
+		in, out := &in.MacrosFrom, &out.MacrosFrom
+		*out = make([]MacroFrom, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Arguments != nil {
 		in, out := &in.Arguments, &out.Arguments
 		*out = make([]string, len(*in))
@@ -209,6 +511,26 @@ func (in *CentreonServiceSpec) DeepCopyInto(out *CentreonServiceSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Contacts != nil {
+		in, out := &in.Contacts, &out.Contacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContactGroups != nil {
+		in, out := &in.ContactGroups, &out.ContactGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotificationOptions != nil {
+		in, out := &in.NotificationOptions, &out.NotificationOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotificationsEnabled != nil {
+		in, out := &in.NotificationsEnabled, &out.NotificationsEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	in.Policy.DeepCopyInto(&out.Policy)
 }
 
@@ -226,6 +548,31 @@ func (in *CentreonServiceSpec) DeepCopy() *CentreonServiceSpec {
 func (in *CentreonServiceStatus) DeepCopyInto(out *CentreonServiceStatus) {
 	*out = *in
 	in.BasicRemoteObjectStatus.DeepCopyInto(&out.BasicRemoteObjectStatus)
+	if in.PendingMove != nil {
+		in, out := &in.PendingMove, &out.PendingMove
+		*out = new(CentreonServiceIdentity)
+		**out = **in
+	}
+	if in.AppliedDefaults != nil {
+		in, out := &in.AppliedDefaults, &out.AppliedDefaults
+		*out = new(PlatformDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]PlatformRefStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(shared.DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentreonServiceStatus.
@@ -238,6 +585,52 @@ func (in *CentreonServiceStatus) DeepCopy() *CentreonServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MacroFrom) DeepCopyInto(out *MacroFrom) {
+	*out = *in
+	in.ValueFrom.DeepCopyInto(&out.ValueFrom)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MacroFrom.
+func (in *MacroFrom) DeepCopy() *MacroFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(MacroFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MacroValueSource) DeepCopyInto(out *MacroValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FieldRef != nil {
+		in, out := &in.FieldRef, &out.FieldRef
+		*out = new(corev1.ObjectFieldSelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MacroValueSource.
+func (in *MacroValueSource) DeepCopy() *MacroValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MacroValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
@@ -265,6 +658,38 @@ func (in *Platform) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformDefaults) DeepCopyInto(out *PlatformDefaults) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Macros != nil {
+		in, out := &in.Macros, &out.Macros
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformDefaults.
+func (in *PlatformDefaults) DeepCopy() *PlatformDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformList) DeepCopyInto(out *PlatformList) {
 	*out = *in
@@ -297,19 +722,125 @@ func (in *PlatformList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformOrphan) DeepCopyInto(out *PlatformOrphan) {
+	*out = *in
+	in.DetectedAt.DeepCopyInto(&out.DetectedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformOrphan.
+func (in *PlatformOrphan) DeepCopy() *PlatformOrphan {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformOrphan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformOrphanDetection) DeepCopyInto(out *PlatformOrphanDetection) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformOrphanDetection.
+func (in *PlatformOrphanDetection) DeepCopy() *PlatformOrphanDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformOrphanDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformRateLimit) DeepCopyInto(out *PlatformRateLimit) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformRateLimit.
+func (in *PlatformRateLimit) DeepCopy() *PlatformRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformRefStatus) DeepCopyInto(out *PlatformRefStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformRefStatus.
+func (in *PlatformRefStatus) DeepCopy() *PlatformRefStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformRefStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformSpec) DeepCopyInto(out *PlatformSpec) {
 	*out = *in
 	if in.CentreonSettings != nil {
 		in, out := &in.CentreonSettings, &out.CentreonSettings
 		*out = new(PlatformSpecCentreonSettings)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Debug != nil {
 		in, out := &in.Debug, &out.Debug
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HealthCheckInterval != nil {
+		in, out := &in.HealthCheckInterval, &out.HealthCheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(PlatformRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultForNamespaces != nil {
+		in, out := &in.DefaultForNamespaces, &out.DefaultForNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(PlatformDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanDetection != nil {
+		in, out := &in.OrphanDetection, &out.OrphanDetection
+		*out = new(PlatformOrphanDetection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformSpec.
@@ -325,6 +856,31 @@ func (in *PlatformSpec) DeepCopy() *PlatformSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformSpecCentreonSettings) DeepCopyInto(out *PlatformSpecCentreonSettings) {
 	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CAConfigMapRef != nil {
+		in, out := &in.CAConfigMapRef, &out.CAConfigMapRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformSpecCentreonSettings.
@@ -341,6 +897,17 @@ func (in *PlatformSpecCentreonSettings) DeepCopy() *PlatformSpecCentreonSettings
 func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 	*out = *in
 	in.BasicRemoteObjectStatus.DeepCopyInto(&out.BasicRemoteObjectStatus)
+	if in.LastOrphanScan != nil {
+		in, out := &in.LastOrphanScan, &out.LastOrphanScan
+		*out = (*in).DeepCopy()
+	}
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = make([]PlatformOrphan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
@@ -53,8 +53,10 @@ import (
 	namespacecontroller "github.com/disaster37/monitoring-operator/internal/controller/namespace"
 	"github.com/disaster37/monitoring-operator/internal/controller/network"
 	nodecontroller "github.com/disaster37/monitoring-operator/internal/controller/node"
+	persistentvolumeclaimcontroller "github.com/disaster37/monitoring-operator/internal/controller/persistentvolumeclaim"
 	platformcontroller "github.com/disaster37/monitoring-operator/internal/controller/platform"
 	routecontroller "github.com/disaster37/monitoring-operator/internal/controller/route"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -79,6 +81,7 @@ func main() {
 	var enableLeaderElection bool
 	var secureMetrics bool
 	var probeAddr string
+	var enableDefaultPlatformReadiness bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -87,6 +90,9 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	flag.BoolVar(&enableDefaultPlatformReadiness, "default-platform-readiness", false,
+		"If set, the readiness probe failed while the default platform is not ready. "+
+			"Only the leader check the default platform, the other replicas are always ready.")
 	opts := zap.Options{
 		Development: true,
 		Level:       helper.GetZapLogLevelFromEnv(),
@@ -192,10 +198,13 @@ func main() {
 		centreoncrd.SetupPlatformIndexer,
 		centreoncrd.SetupCentreonServiceIndexer,
 		centreoncrd.SetupCentreonServiceGroupIndexer,
+		centreoncrd.SetupCentreonServiceDependencyIndexer,
+		centreoncrd.SetupCentreonEscalationIndexer,
 		centreoncrd.SetupCertificateIndexer,
 		centreoncrd.SetupIngressIndexer,
 		centreoncrd.SetupNamespaceIndexer,
 		centreoncrd.SetupNodeIndexer,
+		centreoncrd.SetupPersistentVolumeClaimIndexer,
 	}
 	if hasRouteCapability {
 		indexers = append(indexers, centreoncrd.SetupRouteIndexer)
@@ -233,35 +242,78 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Get the cluster ID, it is stored on Centreon objects to know the resource that manage them
+	clusterID, err := helpers.GetClusterID(context.Background(), cl)
+	if err != nil {
+		setupLog.Error(err, "unable to get cluster ID")
+		os.Exit(1)
+	}
+	log.Infof("Cluster ID: %s", clusterID)
+
 	// Get platforms
 	// Not block if errors, maybee not yet platform available
-	platforms, err := platformcontroller.ComputedPlatformList(context.Background(), cl, logrus.NewEntry(log))
+	computedPlatforms, err := platformcontroller.ComputedPlatformList(context.Background(), cl, logrus.NewEntry(log))
 	if err != nil {
 		log.Errorf("Error when get platforms, we start controller with empty platform list: %s", err.Error())
-		platforms = map[string]*platformcontroller.ComputedPlatform{}
+		computedPlatforms = map[string]*platformcontroller.ComputedPlatform{}
 	}
+	platforms := platformcontroller.NewPlatformRegistry(computedPlatforms)
 
 	// Set platform controllers
-	platfromController := platformcontroller.NewPlatformReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("platform-controller"), platforms)
+	platfromController := platformcontroller.NewPlatformReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("platform-controller"), platforms, clusterID)
 	if err = platfromController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Platform")
 		os.Exit(1)
 	}
 
 	// Set CentreonService controller
-	centreonServiceController := centreoncontroller.NewCentreonServiceReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("centreon-service-controller"), platforms)
+	centreonServiceResyncInterval, err := helpers.GetResyncIntervalFromEnv("CENTREONSERVICE_RESYNC_INTERVAL")
+	if err != nil {
+		setupLog.Error(err, "unable to get resync interval", "controller", "CentreonService")
+		os.Exit(1)
+	}
+	centreonServiceController := centreoncontroller.NewCentreonServiceReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("centreon-service-controller"), platforms, clusterID, centreonServiceResyncInterval)
 	if err = centreonServiceController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CentreonService")
 		os.Exit(1)
 	}
 
 	// Set CentreonServiceGroup controller
-	centreonServiceGroupController := centreoncontroller.NewCentreonServiceGroupReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("centreon-service-group-controller"), platforms)
+	centreonServiceGroupResyncInterval, err := helpers.GetResyncIntervalFromEnv("CENTREONSERVICEGROUP_RESYNC_INTERVAL")
+	if err != nil {
+		setupLog.Error(err, "unable to get resync interval", "controller", "CentreonServiceGroup")
+		os.Exit(1)
+	}
+	centreonServiceGroupController := centreoncontroller.NewCentreonServiceGroupReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("centreon-service-group-controller"), platforms, centreonServiceGroupResyncInterval)
 	if err = centreonServiceGroupController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CentreonServiceGroup")
 		os.Exit(1)
 	}
 
+	// Set CentreonServiceDependency controller
+	centreonServiceDependencyResyncInterval, err := helpers.GetResyncIntervalFromEnv("CENTREONSERVICEDEPENDENCY_RESYNC_INTERVAL")
+	if err != nil {
+		setupLog.Error(err, "unable to get resync interval", "controller", "CentreonServiceDependency")
+		os.Exit(1)
+	}
+	centreonServiceDependencyController := centreoncontroller.NewCentreonServiceDependencyReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("centreon-service-dependency-controller"), platforms, centreonServiceDependencyResyncInterval)
+	if err = centreonServiceDependencyController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CentreonServiceDependency")
+		os.Exit(1)
+	}
+
+	// Set CentreonEscalation controller
+	centreonEscalationResyncInterval, err := helpers.GetResyncIntervalFromEnv("CENTREONESCALATION_RESYNC_INTERVAL")
+	if err != nil {
+		setupLog.Error(err, "unable to get resync interval", "controller", "CentreonEscalation")
+		os.Exit(1)
+	}
+	centreonEscalationController := centreoncontroller.NewCentreonEscalationReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("centreon-escalation-controller"), platforms, centreonEscalationResyncInterval)
+	if err = centreonEscalationController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CentreonEscalation")
+		os.Exit(1)
+	}
+
 	// Set Ingress controller
 	ingressController := ingresscontroller.NewIngressReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("ingress-controller"))
 	if err = ingressController.SetupWithManager(mgr); err != nil {
@@ -299,13 +351,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Set persistent volume claim
+	persistentVolumeClaimController := persistentvolumeclaimcontroller.NewPersistentVolumeClaimReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("persistentvolumeclaim-controller"))
+	if err = persistentVolumeClaimController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PersistentVolumeClaim")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	readyChecker := healthz.Ping
+	if enableDefaultPlatformReadiness {
+		readyChecker = platformcontroller.NewDefaultPlatformChecker(mgr.GetClient(), platforms, mgr.Elected())
+	}
+	if err := mgr.AddReadyzCheck("readyz", readyChecker); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
@@ -0,0 +1,57 @@
+package centreon
+
+import (
+	"emperror.dev/errors"
+	"github.com/disaster37/monitoring-operator/api/shared"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// isManagedKey is the data key to know if the resource was already handled by the operator before the current reconcile
+	isManagedKey string = "isManaged"
+)
+
+// errNeedAdoption is returned when the resource already exist on Centreon and it not created by the operator
+var errNeedAdoption = errors.New("The resource already exist on Centreon and it is not managed by the operator, set 'spec.policy.adopt' to take ownership of it")
+
+// isManaged return true if the resource was already handled by the operator before the current reconcile
+func isManaged(data map[string]any) bool {
+	managed, ok := data[isManagedKey].(bool)
+
+	return !ok || managed
+}
+
+// adopt permit to take ownership of the resource that already exist on Centreon
+// It return the state of resource before adoption on each platform, or error if the policy not permit it
+// The resource is not adopted with policy noCreate, because it already expect to handle existing resource
+func adopt(policy shared.Policy, current any) (adoption *shared.AdoptionStatus, err error) {
+	if policy.NoCreate {
+		return nil, nil
+	}
+	if !policy.Adopt {
+		return nil, errNeedAdoption
+	}
+
+	previousConfiguration, err := helper.ZipAndBase64Encode(current)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when generate 'previousConfiguration'")
+	}
+
+	return &shared.AdoptionStatus{
+		AdoptedAt:             metav1.Now(),
+		PreviousConfiguration: previousConfiguration,
+	}, nil
+}
+
+// hasPlatformIdentity return true if the resource was already handled on one of the platforms
+func hasPlatformIdentity(statuses []centreoncrd.PlatformRefStatus) bool {
+	for _, status := range statuses {
+		if status.ExternalName != "" {
+			return true
+		}
+	}
+
+	return false
+}
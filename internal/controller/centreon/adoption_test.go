@@ -0,0 +1,59 @@
+package centreon
+
+import (
+	"testing"
+
+	"github.com/disaster37/monitoring-operator/api/shared"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdopt(t *testing.T) {
+	current := &CentreonService{
+		CentreonService: &centreonhandler.CentreonService{
+			Host: "host1",
+			Name: "s1",
+		},
+		Mirrors: map[string]*CentreonService{
+			"dr": {
+				CentreonService: &centreonhandler.CentreonService{
+					Host: "host2",
+					Name: "s1",
+				},
+			},
+		},
+	}
+
+	// When policy not permit adoption
+	_, err := adopt(shared.Policy{}, current)
+	assert.ErrorIs(t, err, errNeedAdoption)
+
+	// When policy noCreate, there are nothing to adopt
+	adoption, err := adopt(shared.Policy{NoCreate: true}, current)
+	assert.NoError(t, err)
+	assert.Nil(t, adoption)
+
+	// When policy adopt
+	adoption, err = adopt(shared.Policy{Adopt: true}, current.getByPlatform("default"))
+	assert.NoError(t, err)
+	assert.NotNil(t, adoption)
+	assert.False(t, adoption.AdoptedAt.IsZero())
+	previous := map[string]*centreonhandler.CentreonService{}
+	assert.NoError(t, helper.UnZipBase64Decode(adoption.PreviousConfiguration, &previous))
+	assert.Equal(t, map[string]*centreonhandler.CentreonService{
+		"default": current.CentreonService,
+		"dr":      current.Mirrors["dr"].CentreonService,
+	}, previous)
+}
+
+func TestIsManaged(t *testing.T) {
+	assert.True(t, isManaged(map[string]any{}))
+	assert.True(t, isManaged(map[string]any{isManagedKey: true}))
+	assert.False(t, isManaged(map[string]any{isManagedKey: false}))
+
+	assert.False(t, hasPlatformIdentity(nil))
+	assert.False(t, hasPlatformIdentity([]centreoncrd.PlatformRefStatus{{Name: "default"}}))
+	assert.True(t, hasPlatformIdentity([]centreoncrd.PlatformRefStatus{{Name: "default", ExternalName: "s1"}}))
+}
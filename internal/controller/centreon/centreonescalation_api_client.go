@@ -0,0 +1,120 @@
+package centreon
+
+import (
+	"strconv"
+
+	"github.com/disaster37/generic-objectmatcher/patch"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	json "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type centreonEscalationApiClient struct {
+	*controller.BasicRemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler]
+	logger *logrus.Entry
+
+	// services are the services on Centreon resolved from CentreonService references
+	services []string
+}
+
+func newCentreonEscalationApiClient(client centreonhandler.CentreonHandler, services []string, logger *logrus.Entry) controller.RemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler] {
+	return &centreonEscalationApiClient{
+		BasicRemoteExternalReconciler: controller.NewBasicRemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler](client),
+		logger:                        logger,
+		services:                      services,
+	}
+}
+
+func (h *centreonEscalationApiClient) Build(o *centreoncrd.CentreonEscalation) (ce *CentreonEscalation, err error) {
+	return &CentreonEscalation{
+		CentreonEscalation: &centreonhandler.CentreonEscalation{
+			Name:                 o.GetExternalName(),
+			Description:          o.GetDescription(),
+			FirstNotification:    strconv.Itoa(o.Spec.FirstNotification),
+			LastNotification:     strconv.Itoa(o.Spec.LastNotification),
+			NotificationInterval: strconv.Itoa(o.Spec.NotificationInterval),
+			Period:               o.Spec.EscalationPeriod,
+			NotificationOptions:  joinOptions(o.Spec.NotificationOptions),
+			Comment:              centreoncrd.DefaultComment,
+			ContactGroups:        o.Spec.ContactGroups,
+			Services:             h.services,
+		},
+	}, nil
+}
+
+func (h *centreonEscalationApiClient) Get(o *centreoncrd.CentreonEscalation) (object *CentreonEscalation, err error) {
+	ce, err := h.Client().GetEscalation(getEscalationName(o))
+	if err != nil {
+		return nil, err
+	}
+	if ce == nil {
+		return nil, nil
+	}
+
+	return &CentreonEscalation{
+		CentreonEscalation: ce,
+	}, nil
+}
+
+func (h *centreonEscalationApiClient) Create(object *CentreonEscalation, o *centreoncrd.CentreonEscalation) (err error) {
+	// Check policy
+	if o.Spec.Policy.NoCreate {
+		h.logger.Info("Skip create escalation (policy NoCreate)")
+		return nil
+	}
+
+	return h.Client().CreateEscalation(object.CentreonEscalation)
+}
+
+func (h *centreonEscalationApiClient) Update(object *CentreonEscalation, o *centreoncrd.CentreonEscalation) (err error) {
+	// Check policy
+	if o.Spec.Policy.NoUpdate {
+		h.logger.Info("Skip update escalation (policy NoUpdate)")
+		return nil
+	}
+
+	return h.Client().UpdateEscalation(object.CentreonEscalationDiff)
+}
+
+func (h *centreonEscalationApiClient) Delete(o *centreoncrd.CentreonEscalation) (err error) {
+	// Check policy
+	if o.Spec.Policy.NoDelete {
+		h.logger.Info("Skip delete escalation (policy NoDelete)")
+		return nil
+	}
+
+	return h.Client().DeleteEscalation(getEscalationName(o))
+}
+
+func (h *centreonEscalationApiClient) Diff(currentOject *CentreonEscalation, expectedObject *CentreonEscalation, originalObject *CentreonEscalation, o *centreoncrd.CentreonEscalation, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	patchResult = &patch.PatchResult{}
+
+	ceDiff, err := h.Client().DiffEscalation(currentOject.CentreonEscalation, expectedObject.CentreonEscalation, o.Spec.Policy.ExcludeFieldsOnDiff)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when diff CentreonEscalation")
+	}
+
+	if ceDiff.IsDiff {
+		patchDiff, err := json.ConfigCompatibleWithStandardLibrary.Marshal(ceDiff)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert patched object to byte sequence")
+		}
+
+		patchResult.Patch = patchDiff
+	}
+
+	return patchResult, nil
+}
+
+// getEscalationName return the name of escalation on Centreon
+// It use the status when escalation already exist, because the name can be changed
+func getEscalationName(o *centreoncrd.CentreonEscalation) string {
+	if o.Status.EscalationName != "" {
+		return o.Status.EscalationName
+	}
+
+	return o.GetExternalName()
+}
@@ -0,0 +1,47 @@
+package centreon
+
+import (
+	"testing"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCentreonEscalationBuild(t *testing.T) {
+	client := &centreonEscalationApiClient{
+		services: []string{"host1,service1"},
+	}
+
+	o := &centreoncrd.CentreonEscalation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "esc1",
+		},
+		Spec: centreoncrd.CentreonEscalationSpec{
+			Description:          "my escalation",
+			ContactGroups:        []string{"cg1", "cg2"},
+			FirstNotification:    2,
+			NotificationInterval: 30,
+			EscalationPeriod:     "24x7",
+			NotificationOptions:  []string{"w", "c"},
+		},
+	}
+
+	expectedCE := &centreonhandler.CentreonEscalation{
+		Name:                 "esc1",
+		Description:          "my escalation",
+		FirstNotification:    "2",
+		LastNotification:     "0",
+		NotificationInterval: "30",
+		Period:               "24x7",
+		NotificationOptions:  "c,w",
+		Comment:              "Managed by monitoring-operator",
+		ContactGroups:        []string{"cg1", "cg2"},
+		Services:             []string{"host1,service1"},
+	}
+
+	ce, err := client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCE, ce.CentreonEscalation)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package centreon
+
+import (
+	"context"
+	"time"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/platform"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	centreonEscalationName string = "centreonEscalation"
+)
+
+// CentreonEscalationReconciler reconciles a CentreonEscalation object
+type CentreonEscalationReconciler struct {
+	controller.Controller
+	controller.RemoteReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler]
+	controller.RemoteReconcilerAction[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler]
+	name      string
+	platforms *platform.PlatformRegistry
+}
+
+func NewCentreonEscalationReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder, platforms *platform.PlatformRegistry, resyncInterval time.Duration) controller.Controller {
+	return &CentreonEscalationReconciler{
+		Controller: controller.NewBasicController(),
+		RemoteReconciler: controller.NewBasicRemoteReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler](
+			client,
+			centreonEscalationName,
+			"escalation.monitor.k8s.webcenter.fr/finalizer",
+			logger,
+			recorder,
+		),
+		RemoteReconcilerAction: newCentreonEscalationReconciler(
+			centreonEscalationName,
+			client,
+			recorder,
+			platforms,
+			resyncInterval,
+		),
+		name:      centreonEscalationName,
+		platforms: platforms,
+	}
+}
+
+//+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonescalations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonescalations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonescalations/finalizers,verbs=update
+//+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservices,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.10.0/pkg/reconcile
+func (r *CentreonEscalationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	o := &centreoncrd.CentreonEscalation{}
+	data := map[string]any{}
+
+	return r.RemoteReconciler.Reconcile(
+		ctx,
+		req,
+		o,
+		data,
+		r,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CentreonEscalationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(r.name).
+		For(&centreoncrd.CentreonEscalation{}).
+		WithOptions(k8scontroller.Options{
+			RateLimiter: helper.DefaultControllerRateLimiter[reconcile.Request](),
+		}).
+		WatchesRawSource(source.Channel(r.platforms.Subscribe(), handler.EnqueueRequestsFromMapFunc(platform.WatchPlatform(r.Client(), &centreoncrd.CentreonEscalationList{})))).
+		Watches(&centreoncrd.CentreonService{}, handler.EnqueueRequestsFromMapFunc(watchCentreonServiceRefs(r.Client(), &centreoncrd.CentreonEscalationList{}))).
+		Complete(r)
+}
@@ -0,0 +1,10 @@
+package centreon
+
+import "github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+
+// CentreonEscalation wrap the original model because we haven't unique model on each step.
+// Sometime, we need to have centreonhandler.CentreonEscalation, sometime we need to have centreonhandler.CentreonEscalationDiff
+type CentreonEscalation struct {
+	*centreonhandler.CentreonEscalation
+	*centreonhandler.CentreonEscalationDiff
+}
@@ -0,0 +1,193 @@
+package centreon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/common"
+	"github.com/disaster37/monitoring-operator/internal/controller/platform"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/object"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type centreonEscalationReconciler struct {
+	controller.RemoteReconcilerAction[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler]
+	name           string
+	platforms      *platform.PlatformRegistry
+	resyncInterval time.Duration
+}
+
+func newCentreonEscalationReconciler(name string, client client.Client, recorder record.EventRecorder, platforms *platform.PlatformRegistry, resyncInterval time.Duration) controller.RemoteReconcilerAction[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler] {
+	return &centreonEscalationReconciler{
+		RemoteReconcilerAction: controller.NewRemoteReconcilerAction[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler](
+			client,
+			recorder,
+		),
+		name:           name,
+		platforms:      platforms,
+		resyncInterval: resyncInterval,
+	}
+}
+
+func (h *centreonEscalationReconciler) GetRemoteHandler(ctx context.Context, req ctrl.Request, o object.RemoteObject, logger *logrus.Entry) (handler controller.RemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler], res ctrl.Result, err error) {
+	ce := o.(*centreoncrd.CentreonEscalation)
+
+	meta, _, err := platform.GetClient(ce.GetPlatform(), ce.Namespace, h.platforms)
+	if err != nil {
+		return nil, res, err
+	}
+
+	// Read the services from CentreonService resources
+	// They are not needed to delete the escalation
+	var services []string
+	if ce.DeletionTimestamp.IsZero() {
+		if services, err = resolveServiceRefs(ctx, h.Client(), ce.Namespace, ce.GetPlatform(), ce.Spec.Services); err != nil {
+			return nil, res, errors.Wrap(err, "Error when resolve services")
+		}
+	}
+
+	handler = newCentreonEscalationApiClient(meta.(centreonhandler.CentreonHandler), services, logger)
+
+	return handler, res, nil
+}
+
+func (h *centreonEscalationReconciler) Configure(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler], logger *logrus.Entry) (res ctrl.Result, err error) {
+	// Set prometheus Metrics
+	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(1)
+
+	// Set plaformRef status
+	ce := o.(*centreoncrd.CentreonEscalation)
+	ce.Status.PlatformRef = ce.GetPlatform()
+
+	// Keep if the escalation is already handled, before read it on platform
+	data[isManagedKey] = ce.Status.EscalationName != ""
+
+	return h.RemoteReconcilerAction.Configure(ctx, o, data, handler, logger)
+}
+
+func (h *centreonEscalationReconciler) Delete(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler], logger *logrus.Entry) (err error) {
+	// Set prometheus Metrics
+	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
+	common.ControllerDrift.DeleteLabelValues(h.name, o.GetNamespace(), o.GetName())
+
+	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
+}
+
+func (h *centreonEscalationReconciler) OnError(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler], currentErr error, logger *logrus.Entry) (res ctrl.Result, err error) {
+	common.TotalErrors.Inc()
+	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Inc()
+
+	return h.RemoteReconcilerAction.OnError(ctx, o, data, handler, currentErr, logger)
+}
+
+func (h *centreonEscalationReconciler) OnSuccess(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler], diff controller.RemoteDiff[*CentreonEscalation], logger *logrus.Entry) (res ctrl.Result, err error) {
+	ce := o.(*centreoncrd.CentreonEscalation)
+
+	// Reset the current cluster errors
+	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
+
+	if diff.NeedCreate() || diff.NeedUpdate() {
+		ce.Status.EscalationName = ce.GetExternalName()
+	}
+
+	// Keep the expected resource to know on next reconcile if the diff come from changes made on Centreon
+	if hash, ok := data[expectedHashKey].(string); ok {
+		ce.Status.ExpectedHash = hash
+	}
+
+	if res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Reconcile periodically to detect the changes made on Centreon
+	if !res.Requeue && res.RequeueAfter == 0 {
+		res.RequeueAfter = resyncAfter(h.resyncInterval)
+	}
+
+	return res, nil
+}
+
+func (h *centreonEscalationReconciler) Diff(ctx context.Context, o object.RemoteObject, read controller.RemoteRead[*CentreonEscalation], data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonEscalation, *CentreonEscalation, centreonhandler.CentreonHandler], logger *logrus.Entry, ignoreDiff ...patch.CalculateOption) (diff controller.RemoteDiff[*CentreonEscalation], res ctrl.Result, err error) {
+	// Get the original object from status to use 3-way diff
+	originalObject := new(CentreonEscalation)
+	if o.GetStatus().GetLastAppliedConfiguration() != "" {
+		if err = helper.UnZipBase64Decode(o.GetStatus().GetLastAppliedConfiguration(), originalObject); err != nil {
+			return diff, res, errors.Wrap(err, "Error when create object from 'lastAppliedConfiguration'")
+		}
+	}
+
+	diff = controller.NewBasicRemoteDiff[*CentreonEscalation]()
+	currentObject := read.GetCurrentObject()
+	expectedObject := read.GetExpectedObject()
+	ce := o.(*centreoncrd.CentreonEscalation)
+
+	// Keep the expected escalation to know on next reconcile if the diff come from changes made on Centreon
+	hash, err := hashExpected(expectedObject.CentreonEscalation)
+	if err != nil {
+		return diff, res, err
+	}
+	data[expectedHashKey] = hash
+
+	// Check if need to create object
+	if currentObject == nil || currentObject.CentreonEscalation == nil {
+		diff.AddDiff(fmt.Sprintf("Need to create new object %s on remote target", o.GetName()))
+		diff.SetObjectToCreate(expectedObject)
+		return diff, res, nil
+	}
+
+	// Take ownership of the escalation that already exist on Centreon
+	if !isManaged(data) && ce.Status.Adoption == nil {
+		adoption, err := adopt(ce.Spec.Policy, currentObject.CentreonEscalation)
+		if err != nil {
+			return diff, res, err
+		}
+		if adoption != nil {
+			ce.Status.Adoption = adoption
+			ce.Status.EscalationName = currentObject.CentreonEscalation.Name
+			logger.Infof("Take ownership of escalation that already exist on Centreon")
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "Adopted", "Escalation already exist on Centreon, the operator take ownership of it")
+		}
+	}
+
+	differ, err := handler.Diff(currentObject, expectedObject, originalObject, ce, ignoreDiff...)
+	if err != nil {
+		return diff, res, errors.Wrapf(err, "Error when diffing %s for remote target", o.GetName())
+	}
+	if differ.IsEmpty() {
+		ce.Status.Drift = nil
+		resetDrift(o, h.name)
+		return diff, res, nil
+	}
+	diff.AddDiff(string(differ.Patch))
+
+	// Only report the changes made on Centreon outside of the operator, the changes of resource are always applied
+	if ce.Spec.Policy.IsDriftReportOnly() && isManaged(data) && !isExpectedChanged(o, ce.Status.ExpectedHash, hash) {
+		ce.Status.Drift = reportDrift(h.Recorder(), o, ce.Status.Drift, h.name, diff.Diff(), logger)
+		return controller.NewBasicRemoteDiff[*CentreonEscalation](), res, nil
+	}
+	ce.Status.Drift = nil
+	resetDrift(o, h.name)
+
+	ceDiff := &centreonhandler.CentreonEscalationDiff{}
+	if err = json.Unmarshal(differ.Patch, ceDiff); err != nil {
+		return diff, res, errors.Wrap(err, "Error when unmarshall the CentreonEscalation patch")
+	}
+	diff.SetObjectToUpdate(&CentreonEscalation{
+		CentreonEscalation:     expectedObject.CentreonEscalation,
+		CentreonEscalationDiff: ceDiff,
+	})
+
+	return diff, res, nil
+}
@@ -1,6 +1,8 @@
 package centreon
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/disaster37/generic-objectmatcher/patch"
@@ -12,42 +14,121 @@ import (
 	json "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	// defaultComment is the comment set on service when platform not provide default comment
+	defaultComment string = centreoncrd.DefaultComment
 )
 
 type centreonServiceApiClient struct {
 	*controller.BasicRemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler]
-	logger *logrus.Entry
+	logger   *logrus.Entry
+	defaults *centreoncrd.PlatformDefaults
+	mirrors  platformMirrors[centreonServiceApiClient]
+
+	// macros is the macros values read from macrosFrom
+	macros resolvedMacros
+
+	// owner is the identity of resource stored on service
+	// The owner is not checked when nil
+	owner *centreonhandler.Owner
+
+	// ownerConflicts is the services not deleted because they are managed by another resource
+	ownerConflicts []error
+
+	// appliedDefaults is the platform defaults applied on expected service
+	// They are recorded on status when the service is reconciled
+	appliedDefaults *centreoncrd.PlatformDefaults
 }
 
-func newCentreonServiceApiClient(client centreonhandler.CentreonHandler, logger *logrus.Entry) controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler] {
+// centreonServiceMirror permit to handle the service on one of the other target platforms
+type centreonServiceMirror = platformMirror[centreonServiceApiClient]
+
+func newCentreonServiceApiClient(client centreonhandler.CentreonHandler, defaults *centreoncrd.PlatformDefaults, macros resolvedMacros, owner *centreonhandler.Owner, logger *logrus.Entry, mirrors ...*centreonServiceMirror) controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler] {
 	return &centreonServiceApiClient{
 		BasicRemoteExternalReconciler: controller.NewBasicRemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler](client),
 		logger:                        logger,
+		defaults:                      defaults,
+		mirrors:                       mirrors,
+		macros:                        macros,
+		owner:                         owner,
 	}
 }
 
+// Build permit to build the expected service
+// The platform defaults are applied on fields not set
 func (h *centreonServiceApiClient) Build(o *centreoncrd.CentreonService) (cs *CentreonService, err error) {
+	if cs, h.appliedDefaults, err = h.build(o); err != nil {
+		return nil, err
+	}
+
+	for _, m := range h.mirrors.active() {
+		if cs.Mirrors == nil {
+			cs.Mirrors = map[string]*CentreonService{}
+		}
+		if cs.Mirrors[m.platform], _, err = m.client.build(o); err != nil {
+			return nil, errors.Wrapf(err, "Error when build service on platform %s", m.platform)
+		}
+	}
+
+	return cs, nil
+}
+
+// AppliedDefaults return the platform defaults applied on expected service
+func (h *centreonServiceApiClient) AppliedDefaults() *centreoncrd.PlatformDefaults {
+	return h.appliedDefaults
+}
+
+// build permit to build the expected service with the platform defaults
+// It return an error if the host or the template are not provided by spec or by platform defaults
+func (h *centreonServiceApiClient) build(o *centreoncrd.CentreonService) (cs *CentreonService, applied *centreoncrd.PlatformDefaults, err error) {
+	spec, applied := o.GetSpecWithDefaults(h.defaults)
+	if spec.Host == "" {
+		return nil, nil, errors.Errorf("Service %s has no host, you need to set 'spec.host' or the host on platform defaults", o.GetExternalName())
+	}
+	if spec.Template == "" && spec.CheckCommand == "" {
+		return nil, nil, errors.Errorf("Service %s has no template or check command, you need to set 'spec.template', 'spec.checkCommand' or the template on platform defaults", o.GetExternalName())
+	}
+
+	comment := defaultComment
+	if applied != nil && applied.Comment != "" {
+		comment = applied.Comment
+	}
+
 	cs = &CentreonService{
 		CentreonService: &centreonhandler.CentreonService{
-			Host:                o.Spec.Host,
-			Name:                o.GetExternalName(),
-			CheckCommand:        o.Spec.CheckCommand,
-			CheckCommandArgs:    helpers.CheckArgumentsToString(o.Spec.Arguments),
-			NormalCheckInterval: o.Spec.NormalCheckInterval,
-			RetryCheckInterval:  o.Spec.RetryCheckInterval,
-			MaxCheckAttempts:    o.Spec.MaxCheckAttempts,
-			ActiveCheckEnabled:  helpers.BoolToString(o.Spec.ActiveCheckEnabled),
-			PassiveCheckEnabled: helpers.BoolToString(o.Spec.PassiveCheckEnabled),
-			Activated:           helpers.BoolToString(&o.Spec.Activated),
-			Template:            o.Spec.Template,
-			Comment:             "Managed by monitoring-operator",
-			Groups:              o.Spec.Groups,
-			Categories:          o.Spec.Categories,
-			Macros:              make([]*models.Macro, 0, len(o.Spec.Macros)),
+			Host:                 spec.Host,
+			Name:                 o.GetExternalName(),
+			CheckCommand:         spec.CheckCommand,
+			CheckCommandArgs:     helpers.CheckArgumentsToString(spec.Arguments),
+			NormalCheckInterval:  spec.NormalCheckInterval,
+			RetryCheckInterval:   spec.RetryCheckInterval,
+			MaxCheckAttempts:     spec.MaxCheckAttempts,
+			ActiveCheckEnabled:   helpers.BoolToString(spec.ActiveCheckEnabled),
+			PassiveCheckEnabled:  helpers.BoolToString(spec.PassiveCheckEnabled),
+			Activated:            helpers.BoolToString(&spec.Activated),
+			Template:             spec.Template,
+			Comment:              comment,
+			Groups:               spec.Groups,
+			Categories:           spec.Categories,
+			Contacts:             spec.Contacts,
+			ContactGroups:        spec.ContactGroups,
+			NotificationOptions:  joinOptions(spec.NotificationOptions),
+			NotificationInterval: spec.NotificationInterval,
+			NotificationPeriod:   spec.NotificationPeriod,
+			NotificationsEnabled: notificationsEnabled(spec.NotificationsEnabled),
+			Macros:               make([]*models.Macro, 0, len(spec.Macros)),
+			Owner:                h.owner,
 		},
 	}
 
-	for name, value := range o.Spec.Macros {
+	// Macros from other resources take precedence over macros with same name
+	for name, value := range spec.Macros {
+		if h.macros.contains(strings.ToUpper(name)) {
+			continue
+		}
 		macro := &models.Macro{
 			Name:       strings.ToUpper(name),
 			Value:      value,
@@ -55,36 +136,78 @@ func (h *centreonServiceApiClient) Build(o *centreoncrd.CentreonService) (cs *Ce
 		}
 		cs.Macros = append(cs.Macros, macro)
 	}
+	for name, value := range h.macros.values {
+		if _, ok := h.macros.passwords[name]; ok {
+			continue
+		}
+		macro := &models.Macro{
+			Name:       name,
+			Value:      value,
+			IsPassword: "0",
+		}
+		cs.Macros = append(cs.Macros, macro)
+	}
+	for name, value := range h.macros.passwords {
+		macro := &models.Macro{
+			Name:       name,
+			Value:      value,
+			IsPassword: "1",
+		}
+		cs.Macros = append(cs.Macros, macro)
+	}
 
-	return cs, nil
+	// Macros are sorted to always build the same service
+	slices.SortFunc(cs.Macros, func(a, b *models.Macro) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return cs, applied, nil
 }
 
 func (h *centreonServiceApiClient) Get(o *centreoncrd.CentreonService) (object *CentreonService, err error) {
-	var (
-		host        string
-		serviceName string
-	)
-
-	// Check if the current service name and host is right before to search on Centreon
-	if o.Status.Host != "" && o.Status.ServiceName != "" {
-		host = o.Status.Host
-		serviceName = o.Status.ServiceName
-	} else {
-		host = o.Spec.Host
-		serviceName = o.GetExternalName()
-	}
-
-	cs, err := h.Client().GetService(host, serviceName)
+	cs, err := findService(h.Client(), h.getIdentities(o))
 	if err != nil {
 		return nil, err
 	}
+	if err = getServiceContacts(h.Client(), cs, o); err != nil {
+		return nil, err
+	}
+	unmanaged := cs != nil && !h.isManagedService(cs, h.getRecordedIdentities(o))
 
-	if cs == nil {
+	// The service already handled can be found on the target of a rename or a move that was interrupted, or the status was not written after it was created
+	// So the status is set with the identity where the service is really, and the diff will complete the operation
+	if cs != nil && !unmanaged {
+		o.Status.Host = cs.Host
+		o.Status.ServiceName = cs.Name
+		o.Status.PendingMove = nil
+	}
+
+	// Read the service on other platforms
+	// A platform on error not block the others
+	mirrors := readMirrors(h.mirrors, &o.Status.Platforms, "service", func(m *centreonServiceMirror) (*CentreonService, error) {
+		mcs, err := findService(m.client.Client(), getServiceMirrorIdentities(m, o))
+		if err == nil {
+			err = getServiceContacts(m.client.Client(), mcs, o)
+		}
+		if err != nil || mcs == nil {
+			return nil, err
+		}
+		return &CentreonService{
+			CentreonService: mcs,
+			unmanaged:       !m.client.isManagedService(mcs, getServiceMirrorRecordedIdentities(m, o)),
+		}, nil
+	}, identifyService)
+
+	if cs == nil && len(mirrors) == 0 {
 		return nil, nil
 	}
 
 	object = &CentreonService{
 		CentreonService: cs,
+		unmanaged:       unmanaged,
+	}
+	if len(mirrors) > 0 {
+		object.Mirrors = mirrors
 	}
 
 	return object, nil
@@ -98,7 +221,15 @@ func (h *centreonServiceApiClient) Create(object *CentreonService, o *centreoncr
 	}
 
 	// Create service on Centreon
-	return h.Client().CreateService(object.CentreonService)
+	if object.CentreonService != nil {
+		err = h.Client().CreateService(object.CentreonService)
+	}
+
+	applyMirrors(h.mirrors, &o.Status.Platforms, "create service", object.Mirrors, func(m *centreonServiceMirror, mcs *CentreonService) error {
+		return m.client.Client().CreateService(mcs.CentreonService)
+	}, identifyService)
+
+	return err
 }
 
 func (h *centreonServiceApiClient) Update(object *CentreonService, o *centreoncrd.CentreonService) (err error) {
@@ -109,7 +240,15 @@ func (h *centreonServiceApiClient) Update(object *CentreonService, o *centreoncr
 	}
 
 	// Update service on Centreon
-	return h.Client().UpdateService(object.CentreonServiceDiff)
+	if object.CentreonServiceDiff != nil {
+		err = h.Client().UpdateService(object.CentreonServiceDiff)
+	}
+
+	applyMirrors(h.mirrors, &o.Status.Platforms, "update service", object.Mirrors, func(m *centreonServiceMirror, mcs *CentreonService) error {
+		return m.client.Client().UpdateService(mcs.CentreonServiceDiff)
+	}, identifyService)
+
+	return err
 }
 
 func (h *centreonServiceApiClient) Delete(o *centreoncrd.CentreonService) (err error) {
@@ -119,7 +258,200 @@ func (h *centreonServiceApiClient) Delete(o *centreoncrd.CentreonService) (err e
 		return nil
 	}
 
-	return h.Client().DeleteService(o.Spec.Host, o.GetExternalName())
+	errs := make([]error, 0)
+	if err = h.deleteService(o.GetHost(), o.GetExternalName()); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Delete service on other platforms
+	// The service is kept until it is deleted on all platforms
+	errs = append(errs, deleteMirrors(h.mirrors, "service", func(m *centreonServiceMirror) error {
+		return deleteServiceMirror(m, o)
+	})...)
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// deleteService permit to delete the service if it is not managed by another resource
+func (h *centreonServiceApiClient) deleteService(host, serviceName string) (err error) {
+	if h.owner != nil {
+		owner, err := h.Client().GetServiceOwner(host, serviceName)
+		if err != nil {
+			return errors.Wrapf(err, "Error when get owner of service %s/%s", host, serviceName)
+		}
+		// The service is managed by another resource, maybee on another cluster, so it is kept on Centreon
+		// The resource can be deleted, else it will never be removed
+		if err = centreonhandler.CheckOwner(fmt.Sprintf("Service %s/%s", host, serviceName), owner, h.owner); err != nil {
+			h.logger.Warnf("Skip delete service: %s", err.Error())
+			h.ownerConflicts = append(h.ownerConflicts, err)
+			return nil
+		}
+	}
+
+	return h.Client().DeleteService(host, serviceName)
+}
+
+// OwnerConflicts return the services not deleted on all platforms because they are managed by another resource
+func (h *centreonServiceApiClient) OwnerConflicts() (errs []error) {
+	errs = append(errs, h.ownerConflicts...)
+	for _, m := range h.mirrors {
+		if m.client != nil {
+			errs = append(errs, m.client.ownerConflicts...)
+		}
+	}
+
+	return errs
+}
+
+// DeleteRemovedMirrors permit to delete service on platforms that are not anymore a target
+func (h *centreonServiceApiClient) DeleteRemovedMirrors(o *centreoncrd.CentreonService) (err error) {
+	return deleteRemovedMirrors(h.mirrors, &o.Status.Platforms, "service", o.Spec.Policy.NoDelete, h.logger, func(m *centreonServiceMirror) error {
+		return deleteServiceMirror(m, o)
+	})
+}
+
+// MirrorErrors return the errors when handle service on the other platforms
+func (h *centreonServiceApiClient) MirrorErrors() (errs []error) {
+	return h.mirrors.errors()
+}
+
+// getIdentities return the identities where the service can be on Centreon, by priority
+// The service is on its recorded identities, or on its expected identity
+func (h *centreonServiceApiClient) getIdentities(o *centreoncrd.CentreonService) (identities []centreoncrd.CentreonServiceIdentity) {
+	spec, _ := o.GetSpecWithDefaults(h.defaults)
+
+	return appendIdentity(h.getRecordedIdentities(o), spec.Host, o.GetExternalName())
+}
+
+// getRecordedIdentities return the identities of service already handled by the resource
+// The service is on its current identity, or on the target of a rename or a move that was interrupted
+func (h *centreonServiceApiClient) getRecordedIdentities(o *centreoncrd.CentreonService) (identities []centreoncrd.CentreonServiceIdentity) {
+	identities = make([]centreoncrd.CentreonServiceIdentity, 0, 3)
+	identities = appendIdentity(identities, o.Status.Host, o.Status.ServiceName)
+	if o.Status.PendingMove != nil {
+		identities = appendIdentity(identities, o.Status.PendingMove.Host, o.Status.PendingMove.ServiceName)
+	}
+
+	return identities
+}
+
+// isManagedService return true if the service found on Centreon is handled by the resource
+// The service is handled if it is on its recorded identities, or if it is owned by the resource, like when the status was not written after it was created
+// Else, it is another service that need to be adopted
+func (h *centreonServiceApiClient) isManagedService(cs *centreonhandler.CentreonService, recorded []centreoncrd.CentreonServiceIdentity) bool {
+	if slices.Contains(recorded, centreoncrd.CentreonServiceIdentity{Host: cs.Host, ServiceName: cs.Name}) {
+		return true
+	}
+
+	return h.owner != nil && h.owner.IsSameResource(cs.Owner)
+}
+
+// appendIdentity permit to add the identity if it is complete and not already in the list
+func appendIdentity(identities []centreoncrd.CentreonServiceIdentity, host, serviceName string) []centreoncrd.CentreonServiceIdentity {
+	identity := centreoncrd.CentreonServiceIdentity{
+		Host:        host,
+		ServiceName: serviceName,
+	}
+	if host == "" || serviceName == "" || slices.Contains(identities, identity) {
+		return identities
+	}
+
+	return append(identities, identity)
+}
+
+// findService return the service from the first identity where it exist on Centreon
+// It return nil if the service not exist on any identity
+func findService(client centreonhandler.CentreonHandler, identities []centreoncrd.CentreonServiceIdentity) (cs *centreonhandler.CentreonService, err error) {
+	for _, identity := range identities {
+		if cs, err = client.GetService(identity.Host, identity.ServiceName); err != nil {
+			return nil, err
+		}
+		if cs != nil {
+			return cs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getServiceContacts read the contacts and the contact groups of service
+// They are only read when they are managed by spec, because it need extra calls to Centreon
+func getServiceContacts(client centreonhandler.CentreonHandler, cs *centreonhandler.CentreonService, o *centreoncrd.CentreonService) (err error) {
+	if cs == nil {
+		return nil
+	}
+
+	if o.Spec.Contacts != nil {
+		if cs.Contacts, err = client.GetServiceContacts(cs.Host, cs.Name); err != nil {
+			return errors.Wrap(err, "Error when get service contacts")
+		}
+	}
+	if o.Spec.ContactGroups != nil {
+		if cs.ContactGroups, err = client.GetServiceContactGroups(cs.Host, cs.Name); err != nil {
+			return errors.Wrap(err, "Error when get service contact groups")
+		}
+	}
+
+	return nil
+}
+
+// notificationsEnabled convert the notifications enable setting
+// It return empty string when it not set, so the setting is not managed
+func notificationsEnabled(value *bool) string {
+	if value == nil {
+		return ""
+	}
+
+	return helpers.BoolToString(value)
+}
+
+// getServiceMirrorIdentity return the host and the name of service on platform
+// It use the status when service already exist on platform
+func getServiceMirrorIdentity(m *centreonServiceMirror, o *centreoncrd.CentreonService) (host, serviceName string) {
+	if status := centreoncrd.GetPlatformRefStatus(o.Status.Platforms, m.platform); status != nil && status.Host != "" && status.ExternalName != "" {
+		return status.Host, status.ExternalName
+	}
+	spec, _ := o.GetSpecWithDefaults(m.client.defaults)
+
+	return spec.Host, o.GetExternalName()
+}
+
+// getServiceMirrorIdentities return the identities where the service can be on platform, by priority
+// The service is on its current identity, or on its expected identity when a rename or a move was interrupted
+func getServiceMirrorIdentities(m *centreonServiceMirror, o *centreoncrd.CentreonService) (identities []centreoncrd.CentreonServiceIdentity) {
+	spec, _ := o.GetSpecWithDefaults(m.client.defaults)
+
+	return appendIdentity(getServiceMirrorRecordedIdentities(m, o), spec.Host, o.GetExternalName())
+}
+
+// getServiceMirrorRecordedIdentities return the identity of service already handled by the resource on platform
+func getServiceMirrorRecordedIdentities(m *centreonServiceMirror, o *centreoncrd.CentreonService) (identities []centreoncrd.CentreonServiceIdentity) {
+	identities = make([]centreoncrd.CentreonServiceIdentity, 0, 2)
+	if status := centreoncrd.GetPlatformRefStatus(o.Status.Platforms, m.platform); status != nil {
+		identities = appendIdentity(identities, status.Host, status.ExternalName)
+	}
+
+	return identities
+}
+
+// deleteServiceMirror permit to delete the service on platform
+func deleteServiceMirror(m *centreonServiceMirror, o *centreoncrd.CentreonService) (err error) {
+	host, serviceName := getServiceMirrorIdentity(m, o)
+	if host == "" || serviceName == "" {
+		return nil
+	}
+
+	return m.client.deleteService(host, serviceName)
+}
+
+// identifyService return the identity of service to set on platform status
+// The identity of service not handled by the resource is not recorded, so it still need to be adopted
+func identifyService(cs *CentreonService) (host, name string) {
+	if cs.unmanaged {
+		return "", ""
+	}
+
+	return cs.CentreonService.Host, cs.CentreonService.Name
 }
 
 func (h *centreonServiceApiClient) Diff(currentOject *CentreonService, expectedObject *CentreonService, originalObject *CentreonService, o *centreoncrd.CentreonService, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
@@ -130,6 +462,17 @@ func (h *centreonServiceApiClient) Diff(currentOject *CentreonService, expectedO
 		return nil, errors.Wrap(err, "Error when diff CentreonService")
 	}
 
+	// Centreon not return the password macros values
+	// So we need to set them when they change since last reconcile
+	if o.Status.PasswordMacrosHash != hashPasswordMacros(h.macros) {
+		for _, macro := range expectedObject.Macros {
+			if macro.IsPassword == "1" && !slices.ContainsFunc(csDiff.MacrosToSet, func(m *models.Macro) bool { return m.Name == macro.Name }) {
+				csDiff.MacrosToSet = append(csDiff.MacrosToSet, macro)
+				csDiff.IsDiff = true
+			}
+		}
+	}
+
 	if csDiff.IsDiff {
 		patchDiff, err := json.ConfigCompatibleWithStandardLibrary.Marshal(csDiff)
 		if err != nil {
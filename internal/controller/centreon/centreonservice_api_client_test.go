@@ -1,12 +1,17 @@
 package centreon
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/disaster37/go-centreon-rest/v21/models"
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 func TestCentreonServiceBuild(t *testing.T) {
@@ -21,15 +26,21 @@ func TestCentreonServiceBuild(t *testing.T) {
 			Macros: map[string]string{
 				"MAC1": "value1",
 			},
-			Arguments:           []string{"arg1"},
-			Categories:          []string{"cat1"},
-			CheckCommand:        "check",
-			NormalCheckInterval: "1s",
-			RetryCheckInterval:  "2s",
-			MaxCheckAttempts:    "3s",
-			ActiveCheckEnabled:  nil,
-			PassiveCheckEnabled: nil,
-			Activated:           true,
+			Arguments:            []string{"arg1"},
+			Categories:           []string{"cat1"},
+			CheckCommand:         "check",
+			NormalCheckInterval:  "1s",
+			RetryCheckInterval:   "2s",
+			MaxCheckAttempts:     "3s",
+			ActiveCheckEnabled:   nil,
+			PassiveCheckEnabled:  nil,
+			Activated:            true,
+			Contacts:             []string{"user1"},
+			ContactGroups:        []string{"team1"},
+			NotificationOptions:  []string{"w", "c", "r"},
+			NotificationInterval: "30",
+			NotificationPeriod:   "24x7",
+			NotificationsEnabled: ptr.To(true),
 		},
 	}
 
@@ -53,11 +64,374 @@ func TestCentreonServiceBuild(t *testing.T) {
 				IsPassword: "0",
 			},
 		},
+		Contacts:             []string{"user1"},
+		ContactGroups:        []string{"team1"},
+		NotificationOptions:  "c,r,w",
+		NotificationInterval: "30",
+		NotificationPeriod:   "24x7",
+		NotificationsEnabled: "1",
+		Activated:            "1",
+		Comment:              "Managed by monitoring-operator",
+	}
+
+	cs, err := client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCS, cs.CentreonService)
+}
+
+func TestCentreonServiceBuildWithDefaults(t *testing.T) {
+	client := &centreonServiceApiClient{
+		defaults: &centreoncrd.PlatformDefaults{
+			Host:                "host1",
+			Template:            "template1",
+			Groups:              []string{"group1"},
+			Categories:          []string{"cat2"},
+			NormalCheckInterval: "5",
+			Macros: map[string]string{
+				"MAC1": "default1",
+			},
+			Comment: "Managed by K8s",
+		},
+	}
+
+	o := &centreoncrd.CentreonService{
+		Spec: centreoncrd.CentreonServiceSpec{
+			Name:       "s1",
+			Categories: []string{"cat1"},
+			Activated:  true,
+		},
+	}
+
+	expectedCS := &centreonhandler.CentreonService{
+		Host:                 "host1",
+		Name:                 "s1",
+		NormalCheckInterval:  "5",
+		ActiveCheckEnabled:   "2",
+		PassiveCheckEnabled:  "2",
+		NotificationsEnabled: "",
+		Template:             "template1",
+		Groups:               []string{"group1"},
+		Categories:           []string{"cat1"},
+		Macros: []*models.Macro{
+			{
+				Name:       "MAC1",
+				Value:      "default1",
+				IsPassword: "0",
+			},
+		},
 		Activated: "1",
-		Comment:   "Managed by monitoring-operator",
+		Comment:   "Managed by K8s",
 	}
 
 	cs, err := client.Build(o)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCS, cs.CentreonService)
+	assert.Equal(t, "host1", client.AppliedDefaults().Host)
+	assert.Empty(t, client.AppliedDefaults().Categories)
+	assert.Nil(t, o.Status.AppliedDefaults)
+
+	// When the host is not provided by spec or by platform defaults
+	client.defaults = &centreoncrd.PlatformDefaults{Template: "template1"}
+	_, err = client.Build(o)
+	assert.ErrorContains(t, err, "no host")
+
+	// When the template is not provided by spec or by platform defaults
+	client.defaults = &centreoncrd.PlatformDefaults{Host: "host1"}
+	_, err = client.Build(o)
+	assert.ErrorContains(t, err, "no template")
+
+	// When the check command is set instead of template
+	o.Spec.CheckCommand = "ping"
+	_, err = client.Build(o)
+	assert.NoError(t, err)
+}
+
+func TestCentreonServiceBuildWithMirrors(t *testing.T) {
+	client := &centreonServiceApiClient{
+		defaults: &centreoncrd.PlatformDefaults{
+			Host: "host-prd",
+		},
+		mirrors: []*centreonServiceMirror{
+			{
+				platform: "dr",
+				client: &centreonServiceApiClient{
+					defaults: &centreoncrd.PlatformDefaults{
+						Host: "host-dr",
+					},
+				},
+			},
+			{
+				platform: "unavailable",
+				err:      errors.New("Platform unavailable not found"),
+			},
+			{
+				platform: "old",
+				client:   &centreonServiceApiClient{},
+				removed:  true,
+			},
+		},
+	}
+
+	o := &centreoncrd.CentreonService{
+		Spec: centreoncrd.CentreonServiceSpec{
+			PlatformRefs: []string{"prd", "dr", "unavailable"},
+			Name:         "s1",
+			Template:     "template1",
+		},
+	}
+
+	cs, err := client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, "host-prd", cs.CentreonService.Host)
+	assert.Len(t, cs.Mirrors, 1)
+	assert.Equal(t, "host-dr", cs.Mirrors["dr"].CentreonService.Host)
+	assert.Equal(t, "s1", cs.Mirrors["dr"].CentreonService.Name)
+	assert.Equal(t, "host-prd", client.AppliedDefaults().Host)
+	assert.Len(t, client.MirrorErrors(), 1)
+}
+
+func TestCentreonServiceBuildWithPasswordMacros(t *testing.T) {
+	client := &centreonServiceApiClient{
+		macros: resolvedMacros{
+			values: map[string]string{
+				"MAC2": "value3",
+			},
+			passwords: map[string]string{
+				"MAC1": "secret1",
+			},
+		},
+	}
+
+	o := &centreoncrd.CentreonService{
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:     "host1",
+			Name:     "s1",
+			Template: "template1",
+			Macros: map[string]string{
+				"mac1": "value1",
+				"MAC2": "value2",
+			},
+			Activated: true,
+		},
+	}
+
+	cs, err := client.Build(o)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*models.Macro{
+		{
+			Name:       "MAC1",
+			Value:      "secret1",
+			IsPassword: "1",
+		},
+		{
+			Name:       "MAC2",
+			Value:      "value3",
+			IsPassword: "0",
+		},
+	}, cs.Macros)
+
+	// The password macros values are not leaked
+	redacted := redactPasswordMacros(cs)
+	assert.ElementsMatch(t, []*models.Macro{
+		{
+			Name:       "MAC1",
+			Value:      redactedValue,
+			IsPassword: "1",
+		},
+		{
+			Name:       "MAC2",
+			Value:      "value3",
+			IsPassword: "0",
+		},
+	}, redacted.Macros)
+	assert.Contains(t, cs.Macros, &models.Macro{
+		Name:       "MAC1",
+		Value:      "secret1",
+		IsPassword: "1",
+	})
+}
+
+func TestHashPasswordMacros(t *testing.T) {
+	assert.Empty(t, hashPasswordMacros(resolvedMacros{}))
+
+	macros := resolvedMacros{
+		passwords:       map[string]string{"MAC1": "secret1", "MAC2": "secret2"},
+		passwordSources: map[string]string{"MAC1": "secret1/uid1/1/key1", "MAC2": "secret1/uid1/1/key2"},
+	}
+	hash := hashPasswordMacros(macros)
+	assert.NotEmpty(t, hash)
+
+	// The hash not depend of the password values
+	macros.passwords = map[string]string{"MAC1": "secret3", "MAC2": "secret4"}
+	assert.Equal(t, hash, hashPasswordMacros(macros))
+
+	// The hash change when the secret change
+	macros.passwordSources["MAC2"] = "secret1/uid1/2/key2"
+	assert.NotEqual(t, hash, hashPasswordMacros(macros))
+}
+
+func TestCentreonServiceOwner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	owner := &centreonhandler.Owner{ClusterID: "cluster1", Namespace: "default", Name: "s1", UID: "uid1"}
+	client := newCentreonServiceApiClient(mockCentreon, nil, resolvedMacros{}, owner, logrus.NewEntry(logrus.New())).(*centreonServiceApiClient)
+
+	o := &centreoncrd.CentreonService{
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:     "host1",
+			Name:     "s1",
+			Template: "template1",
+		},
+	}
+
+	// The owner is set on service
+	cs, err := client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, owner, cs.Owner)
+
+	// Delete service managed by the resource
+	mockCentreon.EXPECT().GetServiceOwner("host1", "s1").Return(&centreonhandler.Owner{ClusterID: "cluster1", Namespace: "default", Name: "s1", UID: "uid0"}, nil)
+	mockCentreon.EXPECT().DeleteService("host1", "s1").Return(nil)
+	assert.NoError(t, client.Delete(o))
+
+	// Delete service without owner
+	mockCentreon.EXPECT().GetServiceOwner("host1", "s1").Return(nil, nil)
+	mockCentreon.EXPECT().DeleteService("host1", "s1").Return(nil)
+	assert.NoError(t, client.Delete(o))
+
+	// Not delete service managed by another cluster, but not block the resource deletion
+	mockCentreon.EXPECT().GetServiceOwner("host1", "s1").Return(&centreonhandler.Owner{ClusterID: "cluster2", Namespace: "default", Name: "s1", UID: "uid1"}, nil)
+	assert.NoError(t, client.Delete(o))
+	conflicts := client.OwnerConflicts()
+	assert.Len(t, conflicts, 1)
+	assert.True(t, centreonhandler.IsOwnerConflict(conflicts[0]))
+}
+
+func TestCentreonServiceGetMoved(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	owner := &centreonhandler.Owner{ClusterID: "cluster1", Namespace: "default", Name: "s3", UID: "uid1"}
+	client := newCentreonServiceApiClient(mockCentreon, nil, resolvedMacros{}, owner, logrus.NewEntry(logrus.New()))
+
+	o := &centreoncrd.CentreonService{
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:     "host3",
+			Name:     "s3",
+			Template: "template1",
+		},
+	}
+	o.Status.Host = "host1"
+	o.Status.ServiceName = "s1"
+	o.Status.PendingMove = &centreoncrd.CentreonServiceIdentity{
+		Host:        "host2",
+		ServiceName: "s2",
+	}
+
+	// When the move was interrupted after the service is moved
+	gomock.InOrder(
+		mockCentreon.EXPECT().GetService("host1", "s1").Return(nil, nil),
+		mockCentreon.EXPECT().GetService("host2", "s2").Return(&centreonhandler.CentreonService{Host: "host2", Name: "s2"}, nil),
+	)
+	cs, err := client.Get(o)
+	assert.NoError(t, err)
+	assert.Equal(t, "s2", cs.CentreonService.Name)
+	assert.Equal(t, "host2", o.Status.Host)
+	assert.Equal(t, "s2", o.Status.ServiceName)
+	assert.Nil(t, o.Status.PendingMove)
+
+	// When the service exist only on expected identity and it is not owned by resource, it need to be adopted
+	gomock.InOrder(
+		mockCentreon.EXPECT().GetService("host2", "s2").Return(nil, nil),
+		mockCentreon.EXPECT().GetService("host3", "s3").Return(&centreonhandler.CentreonService{Host: "host3", Name: "s3"}, nil),
+	)
+	cs, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3", cs.CentreonService.Name)
+	assert.True(t, cs.hasUnmanaged())
+	assert.Equal(t, "host2", o.Status.Host)
+	assert.Equal(t, "s2", o.Status.ServiceName)
+
+	// When the service exist only on expected identity and it is owned by resource
+	gomock.InOrder(
+		mockCentreon.EXPECT().GetService("host2", "s2").Return(nil, nil),
+		mockCentreon.EXPECT().GetService("host3", "s3").Return(&centreonhandler.CentreonService{Host: "host3", Name: "s3", Owner: owner}, nil),
+	)
+	cs, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.False(t, cs.hasUnmanaged())
+	assert.Equal(t, "host3", o.Status.Host)
+	assert.Equal(t, "s3", o.Status.ServiceName)
+
+	// When the service not exist
+	mockCentreon.EXPECT().GetService("host3", "s3").Return(nil, nil)
+	cs, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.Nil(t, cs)
+
+	// When the service is not yet handled, the status is not set
+	o.Status.Host = ""
+	o.Status.ServiceName = ""
+	mockCentreon.EXPECT().GetService("host3", "s3").Return(&centreonhandler.CentreonService{Host: "host3", Name: "s3"}, nil)
+	_, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.Empty(t, o.Status.ServiceName)
+
+	// When the service was created by the resource but the status was not written
+	mockCentreon.EXPECT().GetService("host3", "s3").Return(&centreonhandler.CentreonService{Host: "host3", Name: "s3", Owner: owner}, nil)
+	cs, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.False(t, cs.hasUnmanaged())
+	assert.Equal(t, "host3", o.Status.Host)
+	assert.Equal(t, "s3", o.Status.ServiceName)
+}
+
+func TestCentreonServiceGetContacts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	client := newCentreonServiceApiClient(mockCentreon, nil, resolvedMacros{}, nil, logrus.NewEntry(logrus.New()))
+
+	o := &centreoncrd.CentreonService{
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:     "central",
+			Name:     "ping",
+			Template: "template1",
+		},
+	}
+
+	// When contacts are not managed, they are not read
+	mockCentreon.EXPECT().GetService("central", "ping").Return(&centreonhandler.CentreonService{Host: "central", Name: "ping"}, nil)
+	mockCentreon.EXPECT().GetServiceContacts(gomock.Any(), gomock.Any()).Times(0)
+	mockCentreon.EXPECT().GetServiceContactGroups(gomock.Any(), gomock.Any()).Times(0)
+	cs, err := client.Get(o)
+	assert.NoError(t, err)
+	assert.Nil(t, cs.Contacts)
+	assert.Nil(t, cs.ContactGroups)
+
+	// When contacts are managed
+	o.Spec.Contacts = []string{"user1"}
+	o.Spec.ContactGroups = []string{"team1"}
+	mockCentreon.EXPECT().GetService("central", "ping").Return(&centreonhandler.CentreonService{Host: "central", Name: "ping"}, nil)
+	mockCentreon.EXPECT().GetServiceContacts("central", "ping").Return([]string{"user2"}, nil)
+	mockCentreon.EXPECT().GetServiceContactGroups("central", "ping").Return([]string{"team2"}, nil)
+	cs, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user2"}, cs.Contacts)
+	assert.Equal(t, []string{"team2"}, cs.ContactGroups)
+}
+
+func TestGetMoveTarget(t *testing.T) {
+	assert.Nil(t, getMoveTarget(nil))
+	assert.Nil(t, getMoveTarget(&centreonhandler.CentreonServiceDiff{Host: "host1", Name: "s1", ParamsToSet: map[string]string{"template": "t1"}}))
+
+	// Rename
+	assert.Equal(t, &centreoncrd.CentreonServiceIdentity{Host: "host1", ServiceName: "s2"}, getMoveTarget(&centreonhandler.CentreonServiceDiff{Host: "host1", Name: "s1", ParamsToSet: map[string]string{"description": "s2"}}))
+
+	// Move
+	assert.Equal(t, &centreoncrd.CentreonServiceIdentity{Host: "host2", ServiceName: "s1"}, getMoveTarget(&centreonhandler.CentreonServiceDiff{Host: "host1", Name: "s1", HostToSet: "host2"}))
+
+	// Rename and move
+	assert.Equal(t, &centreoncrd.CentreonServiceIdentity{Host: "host2", ServiceName: "s2"}, getMoveTarget(&centreonhandler.CentreonServiceDiff{Host: "host1", Name: "s1", HostToSet: "host2", ParamsToSet: map[string]string{"description": "s2"}}))
 }
@@ -18,6 +18,7 @@ package centreon
 
 import (
 	"context"
+	"time"
 
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/internal/controller/platform"
@@ -25,11 +26,14 @@ import (
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -41,10 +45,11 @@ type CentreonServiceReconciler struct {
 	controller.Controller
 	controller.RemoteReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler]
 	controller.RemoteReconcilerAction[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler]
-	name string
+	name      string
+	platforms *platform.PlatformRegistry
 }
 
-func NewCentreonServiceReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder, platforms map[string]*platform.ComputedPlatform) controller.Controller {
+func NewCentreonServiceReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder, platforms *platform.PlatformRegistry, clusterID string, resyncInterval time.Duration) controller.Controller {
 	return &CentreonServiceReconciler{
 		Controller: controller.NewBasicController(),
 		RemoteReconciler: controller.NewBasicRemoteReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler](
@@ -59,8 +64,11 @@ func NewCentreonServiceReconciler(client client.Client, logger *logrus.Entry, re
 			client,
 			recorder,
 			platforms,
+			clusterID,
+			resyncInterval,
 		),
-		name: centreonServiceName,
+		name:      centreonServiceName,
+		platforms: platforms,
 	}
 }
 
@@ -68,6 +76,8 @@ func NewCentreonServiceReconciler(client client.Client, logger *logrus.Entry, re
 //+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservices/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -99,5 +109,8 @@ func (r *CentreonServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		WithOptions(k8scontroller.Options{
 			RateLimiter: helper.DefaultControllerRateLimiter[reconcile.Request](),
 		}).
+		WatchesRawSource(source.Channel(r.platforms.Subscribe(), handler.EnqueueRequestsFromMapFunc(platform.WatchPlatform(r.Client(), &centreoncrd.CentreonServiceList{})))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(watchCentreonServiceSecret(r.Client()))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(watchCentreonServiceConfigMap(r.Client()))).
 		Complete(r)
 }
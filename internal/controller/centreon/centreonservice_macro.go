@@ -0,0 +1,288 @@
+package centreon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// redactedValue is set in place of password macros values on status and events
+	redactedValue string = "******"
+
+	// MacrosResolvedCondition is the condition to know if the macros from other resources are resolved
+	MacrosResolvedCondition string = "MacrosResolved"
+)
+
+// resolvedMacros is the macros values read from macrosFrom
+// The macro name is set in upper case like on Centreon
+type resolvedMacros struct {
+	// values is the macros values read from configmaps and service metadata
+	values map[string]string
+
+	// passwords is the password macros values read from secrets
+	passwords map[string]string
+
+	// passwordSources is the version of secrets where the password macros values are read
+	// It permit to know when the password macros change without keeping their values
+	passwordSources map[string]string
+}
+
+// contains return true if the macro is read from macrosFrom
+func (h resolvedMacros) contains(name string) bool {
+	_, isValue := h.values[name]
+	_, isPassword := h.passwords[name]
+
+	return isValue || isPassword
+}
+
+// resolveMacros permit to read the macros values from secrets, configmaps and service metadata
+// It return error if the resource or key not exist, except when the reference is optional
+func resolveMacros(ctx context.Context, c client.Client, o *centreoncrd.CentreonService) (macros resolvedMacros, err error) {
+	macros = resolvedMacros{
+		values:          map[string]string{},
+		passwords:       map[string]string{},
+		passwordSources: map[string]string{},
+	}
+	secrets := map[string]*corev1.Secret{}
+	configMaps := map[string]*corev1.ConfigMap{}
+
+	for _, macro := range o.Spec.MacrosFrom {
+		name := strings.ToUpper(macro.Name)
+
+		switch {
+		case macro.ValueFrom.SecretKeyRef != nil:
+			ref := macro.ValueFrom.SecretKeyRef
+			secret, ok := secrets[ref.Name]
+			if !ok {
+				if secret, err = getMacroSource(ctx, c, o.Namespace, ref.Name, &corev1.Secret{}); err != nil {
+					return macros, err
+				}
+				secrets[ref.Name] = secret
+			}
+			value, found := []byte(nil), false
+			if secret != nil {
+				value, found = secret.Data[ref.Key]
+			}
+			if err = checkMacroSource(macro.Name, "secret", ref.Name, ref.Key, secret != nil, found, ref.Optional); err != nil {
+				return macros, err
+			}
+			if found {
+				macros.passwords[name] = string(value)
+				macros.passwordSources[name] = fmt.Sprintf("%s/%s/%s/%s", secret.Name, secret.UID, secret.ResourceVersion, ref.Key)
+			}
+
+		case macro.ValueFrom.ConfigMapKeyRef != nil:
+			ref := macro.ValueFrom.ConfigMapKeyRef
+			configMap, ok := configMaps[ref.Name]
+			if !ok {
+				if configMap, err = getMacroSource(ctx, c, o.Namespace, ref.Name, &corev1.ConfigMap{}); err != nil {
+					return macros, err
+				}
+				configMaps[ref.Name] = configMap
+			}
+			value, found := "", false
+			if configMap != nil {
+				value, found = configMap.Data[ref.Key]
+			}
+			if err = checkMacroSource(macro.Name, "configmap", ref.Name, ref.Key, configMap != nil, found, ref.Optional); err != nil {
+				return macros, err
+			}
+			if found {
+				macros.values[name] = value
+			}
+
+		case macro.ValueFrom.FieldRef != nil:
+			value, err := o.GetFieldValue(macro.ValueFrom.FieldRef.FieldPath)
+			if err != nil {
+				return macros, errors.Wrapf(err, "Error when read field for macro %s", macro.Name)
+			}
+			macros.values[name] = value
+		}
+	}
+
+	return macros, nil
+}
+
+// setMacrosResolvedCondition permit to report the macros resolution on service conditions
+// The condition is only set when service use macrosFrom
+func setMacrosResolvedCondition(o *centreoncrd.CentreonService, err error) {
+	conditions := o.Status.GetConditions()
+
+	switch {
+	case len(o.Spec.MacrosFrom) == 0:
+		meta.RemoveStatusCondition(&conditions, MacrosResolvedCondition)
+	case err != nil:
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:    MacrosResolvedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ResolutionFailed",
+			Message: err.Error(),
+		})
+	default:
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:   MacrosResolvedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: "Resolved",
+		})
+	}
+
+	o.Status.SetConditions(conditions)
+}
+
+// getMacroSource permit to read the resource used by macros
+// It return nil if the resource not exist
+func getMacroSource[T client.Object](ctx context.Context, c client.Client, namespace, name string, o T) (T, error) {
+	var empty T
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, o); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return empty, nil
+		}
+		return empty, errors.Wrapf(err, "Error when get %s", name)
+	}
+
+	return o, nil
+}
+
+// checkMacroSource return error if the resource or the key used by macro not exist and the reference is not optional
+func checkMacroSource(macro, kind, name, key string, exist, found bool, optional *bool) error {
+	if optional != nil && *optional {
+		return nil
+	}
+	if !exist {
+		return errors.Errorf("The %s %s not found for macro %s", kind, name, macro)
+	}
+	if !found {
+		return errors.Errorf("Key %s not found on %s %s for macro %s", key, kind, name, macro)
+	}
+
+	return nil
+}
+
+// hashPasswordMacros return the hash of password macros
+// It is computed from the version of secrets where they are read, so the status never contain a digest of the secret values
+// It return empty string when there are no password macros
+func hashPasswordMacros(macros resolvedMacros) string {
+	if len(macros.passwordSources) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(macros.passwordSources))
+	for name := range macros.passwordSources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	sha := sha256.New()
+	for _, name := range names {
+		sha.Write([]byte(name))
+		sha.Write([]byte{0})
+		sha.Write([]byte(macros.passwordSources[name]))
+		sha.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(sha.Sum(nil))
+}
+
+// watchCentreonServiceSecret permit to reconcile services when the secret used by macros change
+// It use the index `spec.macrosFrom.secret`
+func watchCentreonServiceSecret(c client.Client) handler.MapFunc {
+	return watchCentreonServiceReference(c, "spec.macrosFrom.secret")
+}
+
+// watchCentreonServiceConfigMap permit to reconcile services when the configmap used by macros change
+// It use the index `spec.macrosFrom.configMap`
+func watchCentreonServiceConfigMap(c client.Client) handler.MapFunc {
+	return watchCentreonServiceReference(c, "spec.macrosFrom.configMap")
+}
+
+// watchCentreonServiceReference permit to reconcile the services that reference the object with the index
+func watchCentreonServiceReference(c client.Client, index string) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		reconcileRequests := make([]reconcile.Request, 0)
+		listServices := &centreoncrd.CentreonServiceList{}
+
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("%s=%s", index, a.GetName()))
+
+		// Get all services that use the current object
+		if err := c.List(ctx, listServices, &client.ListOptions{Namespace: a.GetNamespace(), FieldSelector: fs}); err != nil {
+			panic(err)
+		}
+
+		for _, cs := range listServices.Items {
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: cs.Name, Namespace: cs.Namespace}})
+		}
+
+		return reconcileRequests
+	}
+}
+
+// redactPasswordMacros return a copy of service without the password macros values
+// It permit to not leak secret values on status and events
+func redactPasswordMacros(cs *CentreonService) *CentreonService {
+	if cs == nil {
+		return nil
+	}
+
+	redacted := &CentreonService{}
+	if cs.CentreonService != nil {
+		service := *cs.CentreonService
+		service.Macros = redactMacros(service.Macros)
+		redacted.CentreonService = &service
+	}
+	if cs.CentreonServiceDiff != nil {
+		redacted.CentreonServiceDiff = redactServiceDiff(cs.CentreonServiceDiff)
+	}
+	for platform, mirror := range cs.Mirrors {
+		if redacted.Mirrors == nil {
+			redacted.Mirrors = map[string]*CentreonService{}
+		}
+		redacted.Mirrors[platform] = redactPasswordMacros(mirror)
+	}
+
+	return redacted
+}
+
+// redactServiceDiff return a copy of service diff without the password macros values
+func redactServiceDiff(csDiff *centreonhandler.CentreonServiceDiff) *centreonhandler.CentreonServiceDiff {
+	redacted := *csDiff
+	redacted.MacrosToSet = redactMacros(redacted.MacrosToSet)
+	redacted.MacrosToDelete = redactMacros(redacted.MacrosToDelete)
+
+	return &redacted
+}
+
+// redactMacros return a copy of macros without the password macros values
+func redactMacros(macros []*models.Macro) []*models.Macro {
+	if macros == nil {
+		return nil
+	}
+
+	redacted := make([]*models.Macro, 0, len(macros))
+	for _, macro := range macros {
+		m := *macro
+		if m.IsPassword == "1" && m.Value != "" {
+			m.Value = redactedValue
+		}
+		redacted = append(redacted, &m)
+	}
+
+	return redacted
+}
@@ -0,0 +1,104 @@
+package centreon
+
+import (
+	"context"
+	"testing"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveMacros(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "secret1"},
+				Data:       map[string][]byte{"token": []byte("secret")},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm1"},
+				Data:       map[string]string{"url": "https://app"},
+			},
+		).
+		Build()
+
+	o := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+			Labels:    map[string]string{"app": "my-app"},
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			MacrosFrom: []centreoncrd.MacroFrom{
+				{
+					Name: "token",
+					ValueFrom: centreoncrd.MacroValueSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "secret1"}, Key: "token"},
+					},
+				},
+				{
+					Name: "url",
+					ValueFrom: centreoncrd.MacroValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "url"},
+					},
+				},
+				{
+					Name: "app",
+					ValueFrom: centreoncrd.MacroValueSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['app']"},
+					},
+				},
+				{
+					Name: "optional",
+					ValueFrom: centreoncrd.MacroValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm2"}, Key: "url", Optional: ptr.To(true)},
+					},
+				},
+			},
+		},
+	}
+
+	macros, err := resolveMacros(context.Background(), c, o)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"TOKEN": "secret"}, macros.passwords)
+	assert.Regexp(t, "^secret1/.*/token$", macros.passwordSources["TOKEN"])
+	assert.Equal(t, map[string]string{"URL": "https://app", "APP": "my-app"}, macros.values)
+
+	setMacrosResolvedCondition(o, err)
+	assert.True(t, meta.IsStatusConditionTrue(o.Status.Conditions, MacrosResolvedCondition))
+
+	// When key not exist
+	o.Spec.MacrosFrom[1].ValueFrom.ConfigMapKeyRef.Key = "foo"
+	_, err = resolveMacros(context.Background(), c, o)
+	assert.Error(t, err)
+
+	setMacrosResolvedCondition(o, err)
+	assert.True(t, meta.IsStatusConditionFalse(o.Status.Conditions, MacrosResolvedCondition))
+
+	// When secret not exist
+	o.Spec.MacrosFrom[1].ValueFrom.ConfigMapKeyRef.Key = "url"
+	o.Spec.MacrosFrom[0].ValueFrom.SecretKeyRef.Name = "secret2"
+	_, err = resolveMacros(context.Background(), c, o)
+	assert.Error(t, err)
+
+	// When label not exist
+	o.Spec.MacrosFrom[0].ValueFrom.SecretKeyRef.Name = "secret1"
+	o.Labels = nil
+	_, err = resolveMacros(context.Background(), c, o)
+	assert.Error(t, err)
+
+	// When service not use macrosFrom
+	o.Spec.MacrosFrom = nil
+	setMacrosResolvedCondition(o, nil)
+	assert.Nil(t, meta.FindStatusCondition(o.Status.Conditions, MacrosResolvedCondition))
+}
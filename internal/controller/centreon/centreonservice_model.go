@@ -4,7 +4,47 @@ import "github.com/disaster37/monitoring-operator/pkg/centreonhandler"
 
 // CentreonService wrap the original model because we haven't unique model on each step.
 // Sometime, we need to have centreonhandler.CentreonService, sometime we need to have centreonhandler.CentreonServiceDiff
+// Mirrors is the same service on the other target platforms, by platform name
 type CentreonService struct {
 	*centreonhandler.CentreonService
 	*centreonhandler.CentreonServiceDiff
+	Mirrors map[string]*CentreonService `json:"mirrors,omitempty"`
+
+	// unmanaged is true when the service exist on Centreon but it is not handled by the resource
+	// It need to be adopted before the resource can handle it
+	unmanaged bool
+}
+
+// isEmpty return true if there are nothing to handle on any platform
+func (h *CentreonService) isEmpty() bool {
+	return h.CentreonService == nil && len(h.Mirrors) == 0
+}
+
+// hasUnmanaged return true if the service exist on one of the platforms but it is not handled by the resource
+func (h *CentreonService) hasUnmanaged() bool {
+	if h.CentreonService != nil && h.unmanaged {
+		return true
+	}
+	for _, mirror := range h.Mirrors {
+		if mirror.hasUnmanaged() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getByPlatform return the service on each platform, by platform name
+func (h *CentreonService) getByPlatform(platform string) map[string]*centreonhandler.CentreonService {
+	services := make(map[string]*centreonhandler.CentreonService, len(h.Mirrors)+1)
+	if h.CentreonService != nil {
+		services[platform] = h.CentreonService
+	}
+	for platformRef, mirror := range h.Mirrors {
+		if mirror.CentreonService != nil {
+			services[platformRef] = mirror.CentreonService
+		}
+	}
+
+	return services
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/disaster37/generic-objectmatcher/patch"
@@ -15,41 +17,100 @@ import (
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/disaster37/operator-sdk-extra/pkg/object"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// errServiceMirrorNotSync is returned when the service can't be handled on some of the other target platforms
+var errServiceMirrorNotSync = errors.New("Service is not up to date on some platforms")
+
 type centreonServiceReconciler struct {
 	controller.RemoteReconcilerAction[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler]
-	name      string
-	platforms map[string]*platform.ComputedPlatform
+	name           string
+	platforms      *platform.PlatformRegistry
+	clusterID      string
+	resyncInterval time.Duration
 }
 
-func newCentreonServiceReconciler(name string, client client.Client, recorder record.EventRecorder, platforms map[string]*platform.ComputedPlatform) controller.RemoteReconcilerAction[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler] {
+func newCentreonServiceReconciler(name string, client client.Client, recorder record.EventRecorder, platforms *platform.PlatformRegistry, clusterID string, resyncInterval time.Duration) controller.RemoteReconcilerAction[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler] {
 	return &centreonServiceReconciler{
 		RemoteReconcilerAction: controller.NewRemoteReconcilerAction[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler](
 			client,
 			recorder,
 		),
-		name:      name,
-		platforms: platforms,
+		name:           name,
+		platforms:      platforms,
+		clusterID:      clusterID,
+		resyncInterval: resyncInterval,
 	}
 }
 
 func (h *centreonServiceReconciler) GetRemoteHandler(ctx context.Context, req ctrl.Request, o object.RemoteObject, logger *logrus.Entry) (handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], res ctrl.Result, err error) {
 	cs := o.(*centreoncrd.CentreonService)
+	platforms := cs.GetPlatforms()
 
-	meta, _, err := platform.GetClient(cs.GetPlatform(), h.platforms)
+	meta, p, err := platform.GetClient(platforms[0], cs.Namespace, h.platforms)
 	if err != nil {
 		return nil, res, err
 	}
 
-	handler = newCentreonServiceApiClient(meta.(centreonhandler.CentreonHandler), logger)
+	// Read the macros from secrets, configmaps and service metadata
+	// They are not needed to delete the service
+	var macros resolvedMacros
+	if cs.DeletionTimestamp.IsZero() {
+		macros, err = resolveMacros(ctx, h.Client(), cs)
+		setMacrosResolvedCondition(cs, err)
+		if err != nil {
+			return nil, res, errors.Wrap(err, "Error when resolve macros")
+		}
+	}
+
+	// The owner is stored on service to not handle service managed by another resource
+	owner := &centreonhandler.Owner{
+		ClusterID: h.clusterID,
+		Namespace: cs.Namespace,
+		Name:      cs.Name,
+		UID:       string(cs.UID),
+	}
+
+	// The service is mirrored on the other target platforms
+	// It also need to be deleted from platforms that are not anymore a target
+	mirrors := make([]*centreonServiceMirror, 0, len(platforms)-1)
+	for _, platformRef := range platforms[1:] {
+		mirrors = append(mirrors, h.newMirror(platformRef, cs.Namespace, false, macros, owner, logger))
+	}
+	for _, status := range cs.Status.Platforms {
+		if !slices.Contains(platforms, status.Name) {
+			mirrors = append(mirrors, h.newMirror(status.Name, cs.Namespace, true, resolvedMacros{}, owner, logger))
+		}
+	}
+
+	handler = newCentreonServiceApiClient(meta.(centreonhandler.CentreonHandler), p.Spec.Defaults, macros, owner, logger, mirrors...)
 
 	return handler, res, nil
 }
 
+// newMirror permit to get the api client to handle the service on another platform
+// The error is kept on mirror to not block the others platforms
+func (h *centreonServiceReconciler) newMirror(platformRef string, namespace string, removed bool, macros resolvedMacros, owner *centreonhandler.Owner, logger *logrus.Entry) *centreonServiceMirror {
+	m := &centreonServiceMirror{
+		platform: platformRef,
+		removed:  removed,
+	}
+
+	meta, p, err := platform.GetClient(platformRef, namespace, h.platforms)
+	if err != nil {
+		m.err = errors.Wrapf(err, "Error when get platform %s", platformRef)
+		return m
+	}
+	m.client = newCentreonServiceApiClient(meta.(centreonhandler.CentreonHandler), p.Spec.Defaults, macros, owner, logger.WithField("platform", platformRef)).(*centreonServiceApiClient)
+
+	return m
+}
+
 func (h *centreonServiceReconciler) Configure(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], logger *logrus.Entry) (res ctrl.Result, err error) {
 	// Set prometheus Metrics
 	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(1)
@@ -58,20 +119,113 @@ func (h *centreonServiceReconciler) Configure(ctx context.Context, o object.Remo
 	cs := o.(*centreoncrd.CentreonService)
 	cs.Status.PlatformRef = cs.GetPlatform()
 
+	// Keep if the service is already handled, before read it on platforms
+	data[isManagedKey] = cs.Status.ServiceName != "" || hasPlatformIdentity(cs.Status.Platforms)
+
 	return h.RemoteReconcilerAction.Configure(ctx, o, data, handler, logger)
 }
 
+func (h *centreonServiceReconciler) Create(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], object *CentreonService, logger *logrus.Entry) (res ctrl.Result, err error) {
+	if res, err = h.RemoteReconcilerAction.Create(ctx, o, data, handler, object, logger); err != nil {
+		return res, err
+	}
+
+	return res, setLastAppliedConfiguration(o, object)
+}
+
+func (h *centreonServiceReconciler) Update(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], object *CentreonService, logger *logrus.Entry) (res ctrl.Result, err error) {
+	// Record the target of rename or move before to apply it, so the service can be found if the operation is interrupted
+	cs := o.(*centreoncrd.CentreonService)
+	if target := getMoveTarget(object.CentreonServiceDiff); target != nil && !cs.Spec.Policy.NoUpdate {
+		cs.Status.PendingMove = target
+		if err = h.Client().Status().Update(ctx, cs); err != nil {
+			return res, errors.Wrap(err, "Error when record the service move on status")
+		}
+		logger.Infof("Move service %s/%s to %s/%s", cs.Status.Host, cs.Status.ServiceName, target.Host, target.ServiceName)
+	}
+
+	if res, err = h.RemoteReconcilerAction.Update(ctx, o, data, handler, object, logger); err != nil {
+		return res, err
+	}
+
+	return res, setLastAppliedConfiguration(o, object)
+}
+
+// getMoveTarget return the new identity of service when the diff rename it or move it to another host
+// It return nil if the service keep its identity
+func getMoveTarget(csDiff *centreonhandler.CentreonServiceDiff) *centreoncrd.CentreonServiceIdentity {
+	if csDiff == nil {
+		return nil
+	}
+
+	target := &centreoncrd.CentreonServiceIdentity{
+		Host:        csDiff.Host,
+		ServiceName: csDiff.Name,
+	}
+	if name := csDiff.ParamsToSet["description"]; name != "" {
+		target.ServiceName = name
+	}
+	if csDiff.HostToSet != "" {
+		target.Host = csDiff.HostToSet
+	}
+	if target.Host == csDiff.Host && target.ServiceName == csDiff.Name {
+		return nil
+	}
+
+	return target
+}
+
+// setLastAppliedConfiguration permit to set the applied service on status without the password macros values
+func setLastAppliedConfiguration(o object.RemoteObject, object *CentreonService) (err error) {
+	zip, err := helper.ZipAndBase64Encode(redactPasswordMacros(object))
+	if err != nil {
+		return errors.Wrapf(err, "Error when generate 'lastAppliedConfiguration' from %s", o.GetName())
+	}
+	o.GetStatus().SetLastAppliedConfiguration(zip)
+
+	return nil
+}
+
 func (h *centreonServiceReconciler) Delete(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], logger *logrus.Entry) (err error) {
 	// Set prometheus Metrics
 	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
+	common.ControllerDrift.DeleteLabelValues(h.name, o.GetNamespace(), o.GetName())
 
-	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
+	if err = h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger); err != nil {
+		return err
+	}
+
+	// The services managed by another resource are kept on Centreon
+	if apiClient, ok := handler.(*centreonServiceApiClient); ok {
+		for _, conflict := range apiClient.OwnerConflicts() {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "OwnerConflict", "Service is not deleted on Centreon because it is managed by another resource: %s", conflict.Error())
+		}
+	}
+
+	return nil
 }
 
 func (h *centreonServiceReconciler) OnError(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], currentErr error, logger *logrus.Entry) (res ctrl.Result, err error) {
 	common.TotalErrors.Inc()
 	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Inc()
 
+	// The service is managed by another resource, maybee on another cluster
+	if centreonhandler.IsOwnerConflict(currentErr) {
+		h.Recorder().Eventf(o, corev1.EventTypeWarning, "OwnerConflict", "Service is managed by another resource: %s", currentErr.Error())
+	}
+
+	// Report the error on main platform when service is mirrored
+	cs := o.(*centreoncrd.CentreonService)
+	if len(cs.GetPlatforms()) > 1 && !errors.Is(currentErr, errServiceMirrorNotSync) {
+		status := centreoncrd.PlatformRefStatus{
+			Name:             cs.GetPlatform(),
+			Host:             cs.Status.Host,
+			ExternalName:     cs.Status.ServiceName,
+			LastErrorMessage: currentErr.Error(),
+		}
+		centreoncrd.SetPlatformRefStatus(&cs.Status.Platforms, status)
+	}
+
 	return h.RemoteReconcilerAction.OnError(ctx, o, data, handler, currentErr, logger)
 }
 
@@ -81,12 +235,63 @@ func (h *centreonServiceReconciler) OnSuccess(ctx context.Context, o object.Remo
 	// Reset the current cluster errors
 	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
 
+	// Record the platform defaults applied on service
+	apiClient, isApiClient := handler.(*centreonServiceApiClient)
+	if isApiClient {
+		sg.Status.AppliedDefaults = apiClient.AppliedDefaults()
+	}
+
 	if diff.NeedCreate() || diff.NeedUpdate() {
 		sg.Status.ServiceName = sg.GetExternalName()
-		sg.Status.Host = sg.Spec.Host
+		sg.Status.Host = sg.GetHost()
+		sg.Status.PendingMove = nil
+	}
+
+	// Handle the status of service on each platform
+	if isApiClient {
+		errs := make([]error, 0)
+		if err = apiClient.DeleteRemovedMirrors(sg); err != nil {
+			errs = append(errs, err)
+		}
+		if len(sg.GetPlatforms()) > 1 {
+			centreoncrd.SetPlatformRefStatus(&sg.Status.Platforms, centreoncrd.PlatformRefStatus{
+				Name:         sg.GetPlatform(),
+				Host:         sg.Status.Host,
+				ExternalName: sg.Status.ServiceName,
+				IsSync:       true,
+			})
+		} else {
+			centreoncrd.RemovePlatformRefStatus(&sg.Status.Platforms, sg.GetPlatform())
+			if len(sg.Status.Platforms) == 0 {
+				sg.Status.Platforms = nil
+			}
+		}
+		errs = append(errs, apiClient.MirrorErrors()...)
+		if len(errs) > 0 {
+			return res, fmt.Errorf("%w: %s", errServiceMirrorNotSync, utilerrors.NewAggregate(errs).Error())
+		}
+
+		// Keep the hash of password macros applied on Centreon
+		if !sg.Spec.Policy.NoCreate && !sg.Spec.Policy.NoUpdate {
+			sg.Status.PasswordMacrosHash = hashPasswordMacros(apiClient.macros)
+		}
+	}
+
+	// Keep the expected resource to know on next reconcile if the diff come from changes made on Centreon
+	if hash, ok := data[expectedHashKey].(string); ok {
+		sg.Status.ExpectedHash = hash
 	}
 
-	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Reconcile periodically to detect the changes made on Centreon
+	if !res.Requeue && res.RequeueAfter == 0 {
+		res.RequeueAfter = resyncAfter(h.resyncInterval)
+	}
+
+	return res, nil
 }
 
 func (h *centreonServiceReconciler) Diff(ctx context.Context, o object.RemoteObject, read controller.RemoteRead[*CentreonService], data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], logger *logrus.Entry, ignoreDiff ...patch.CalculateOption) (diff controller.RemoteDiff[*CentreonService], res ctrl.Result, err error) {
@@ -100,30 +305,151 @@ func (h *centreonServiceReconciler) Diff(ctx context.Context, o object.RemoteObj
 	}
 
 	diff = controller.NewBasicRemoteDiff[*CentreonService]()
+	currentObject := read.GetCurrentObject()
+	expectedObject := read.GetExpectedObject()
+	if currentObject == nil {
+		currentObject = &CentreonService{}
+	}
+	objectToCreate := &CentreonService{}
 
-	// Check if need to create object on remote
-	if read.GetCurrentObject() == nil {
-		diff.SetObjectToCreate(read.GetExpectedObject())
-		diff.AddDiff(fmt.Sprintf("Need to create new object %s on remote target", o.GetName()))
+	cs := o.(*centreoncrd.CentreonService)
 
-		return diff, res, nil
+	// Keep the expected service to know on next reconcile if the diff come from changes made on Centreon
+	var macros resolvedMacros
+	if apiClient, ok := handler.(*centreonServiceApiClient); ok {
+		macros = apiClient.macros
 	}
-
-	differ, err := handler.Diff(read.GetCurrentObject(), read.GetExpectedObject(), originalObject, o.(*centreoncrd.CentreonService), ignoreDiff...)
+	hash, err := hashExpectedService(expectedObject, cs.GetPlatform(), macros)
 	if err != nil {
-		return diff, res, errors.Wrapf(err, "Error when diffing %s for remote target", o.GetName())
+		return diff, res, err
+	}
+	data[expectedHashKey] = hash
+
+	// Take ownership of the service that already exist on Centreon and that is not handled by the resource
+	// The service created by the resource is recognized with its owner, even if its identity was not recorded on status
+	if currentObject.hasUnmanaged() {
+		adoption, err := adopt(cs.Spec.Policy, currentObject.getByPlatform(cs.GetPlatform()))
+		if err != nil {
+			return diff, res, err
+		}
+		if adoption != nil {
+			cs.Status.Adoption = adoption
+			if currentObject.CentreonService != nil {
+				cs.Status.Host = currentObject.CentreonService.Host
+				cs.Status.ServiceName = currentObject.CentreonService.Name
+			}
+			for platformRef, mirror := range currentObject.Mirrors {
+				centreoncrd.SetPlatformRefStatus(&cs.Status.Platforms, centreoncrd.PlatformRefStatus{
+					Name:         platformRef,
+					Host:         mirror.CentreonService.Host,
+					ExternalName: mirror.CentreonService.Name,
+				})
+			}
+			logger.Infof("Take ownership of service that already exist on Centreon")
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "Adopted", "Service already exist on Centreon, the operator take ownership of it")
+		}
+	} else if !currentObject.isEmpty() {
+		// The service is already handled, even if it was not recorded on status
+		data[isManagedKey] = true
+	}
+	objectToUpdate := &CentreonService{}
+
+	// Check if need to create object on main platform
+	if currentObject.CentreonService == nil {
+		objectToCreate.CentreonService = expectedObject.CentreonService
+		diff.AddDiff(fmt.Sprintf("Need to create new object %s on remote target", o.GetName()))
+	} else {
+		csDiff, patchDiff, err := h.diffService(handler, currentObject, expectedObject, originalObject, o.(*centreoncrd.CentreonService), ignoreDiff...)
+		if err != nil {
+			return diff, res, err
+		}
+		if csDiff != nil {
+			diff.AddDiff(patchDiff)
+			objectToUpdate.CentreonService = expectedObject.CentreonService
+			objectToUpdate.CentreonServiceDiff = csDiff
+		}
 	}
 
-	if !differ.IsEmpty() {
-		csDiff := &centreonhandler.CentreonServiceDiff{}
-		if err = json.Unmarshal(differ.Patch, csDiff); err != nil {
-			return diff, res, errors.Wrap(err, "Error when unmarshall the CentreonService patch")
+	// Each other platform are diffed independently
+	for platformRef, expectedMirror := range expectedObject.Mirrors {
+		currentMirror := currentObject.Mirrors[platformRef]
+		if currentMirror == nil {
+			if objectToCreate.Mirrors == nil {
+				objectToCreate.Mirrors = map[string]*CentreonService{}
+			}
+			objectToCreate.Mirrors[platformRef] = expectedMirror
+			diff.AddDiff(fmt.Sprintf("Need to create new object %s on platform %s", o.GetName(), platformRef))
+			continue
+		}
+
+		csDiff, patchDiff, err := h.diffService(handler, currentMirror, expectedMirror, originalObject.Mirrors[platformRef], o.(*centreoncrd.CentreonService), ignoreDiff...)
+		if err != nil {
+			return diff, res, errors.Wrapf(err, "Error on platform %s", platformRef)
+		}
+		if csDiff != nil {
+			if objectToUpdate.Mirrors == nil {
+				objectToUpdate.Mirrors = map[string]*CentreonService{}
+			}
+			diff.AddDiff(fmt.Sprintf("On platform %s: %s", platformRef, patchDiff))
+			objectToUpdate.Mirrors[platformRef] = &CentreonService{
+				CentreonService:     expectedMirror.CentreonService,
+				CentreonServiceDiff: csDiff,
+			}
 		}
-		diff.AddDiff(string(differ.Patch))
-		cs := read.GetExpectedObject()
-		cs.CentreonServiceDiff = csDiff
-		diff.SetObjectToUpdate(cs)
+	}
+
+	// Only report the changes made on Centreon outside of the operator, the changes of resource are always applied
+	if cs.Spec.Policy.IsDriftReportOnly() && isManaged(data) && diff.IsDiff() && !isExpectedChanged(o, cs.Status.ExpectedHash, hash) {
+		cs.Status.Drift = reportDrift(h.Recorder(), o, cs.Status.Drift, h.name, diff.Diff(), logger)
+		return controller.NewBasicRemoteDiff[*CentreonService](), res, nil
+	}
+	cs.Status.Drift = nil
+	resetDrift(o, h.name)
+
+	if !objectToCreate.isEmpty() {
+		diff.SetObjectToCreate(objectToCreate)
+	}
+	if !objectToUpdate.isEmpty() {
+		diff.SetObjectToUpdate(objectToUpdate)
 	}
 
 	return diff, res, nil
 }
+
+// diffService permit to compute the diff of service on one platform
+// It return nil if there are no diff
+func (h *centreonServiceReconciler) diffService(handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], currentObject, expectedObject, originalObject *CentreonService, o *centreoncrd.CentreonService, ignoreDiff ...patch.CalculateOption) (csDiff *centreonhandler.CentreonServiceDiff, patchDiff string, err error) {
+	differ, err := handler.Diff(currentObject, expectedObject, originalObject, o, ignoreDiff...)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "Error when diffing %s for remote target", o.GetName())
+	}
+
+	if differ.IsEmpty() {
+		return nil, "", nil
+	}
+
+	csDiff = &centreonhandler.CentreonServiceDiff{}
+	if err = json.Unmarshal(differ.Patch, csDiff); err != nil {
+		return nil, "", errors.Wrap(err, "Error when unmarshall the CentreonService patch")
+	}
+
+	// The password macros values must not be leaked on events
+	redactedPatch, err := json.Marshal(redactServiceDiff(csDiff))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Error when marshall the CentreonService patch")
+	}
+
+	return csDiff, string(redactedPatch), nil
+}
+
+// hashExpectedService return the hash of expected service on each platform
+// The password macros values are redacted, their changes are known from the version of secrets where they are read
+func hashExpectedService(expectedObject *CentreonService, platform string, macros resolvedMacros) (hash string, err error) {
+	return hashExpected(struct {
+		Services       map[string]*centreonhandler.CentreonService
+		PasswordMacros string
+	}{
+		Services:       redactPasswordMacros(expectedObject).getByPlatform(platform),
+		PasswordMacros: hashPasswordMacros(macros),
+	})
+}
@@ -0,0 +1,343 @@
+package centreon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/platform"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const centreonServiceFinalizer string = "service.monitor.k8s.webcenter.fr/finalizer"
+
+// newTestCentreonServiceReconciler permit to get the CentreonService controller with fake client
+// Each handler is a platform, the first one is the default platform
+func newTestCentreonServiceReconciler(t *testing.T, handlers map[string]centreonhandler.CentreonHandler, objects ...client.Object) (r *CentreonServiceReconciler, c client.Client, recorder *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, centreoncrd.AddToScheme(scheme))
+
+	c = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&centreoncrd.CentreonService{}).
+		Build()
+	recorder = record.NewFakeRecorder(100)
+
+	platforms := map[string]*platform.ComputedPlatform{}
+	for name, handler := range handlers {
+		platforms[name] = &platform.ComputedPlatform{
+			Platform: &centreoncrd.Platform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: centreoncrd.PlatformSpec{
+					IsDefault:        name == "default",
+					PlatformType:     "centreon",
+					CentreonSettings: &centreoncrd.PlatformSpecCentreonSettings{},
+				},
+			},
+			Client: handler,
+		}
+	}
+
+	r = NewCentreonServiceReconciler(c, logrus.NewEntry(logrus.New()), recorder, platform.NewPlatformRegistry(platforms), "cluster1", 0).(*CentreonServiceReconciler)
+
+	return r, c, recorder
+}
+
+// reconcileCentreonService permit to run the reconcile loop until there are no requeue
+func reconcileCentreonService(t *testing.T, r *CentreonServiceReconciler, key types.NamespacedName) (res ctrl.Result, err error) {
+	for i := 0; i < 3; i++ {
+		if res, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil || !res.Requeue {
+			return res, err
+		}
+	}
+
+	return res, err
+}
+
+// getEvents return the events sent by the recorder
+func getEvents(recorder *record.FakeRecorder) (events []string) {
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestCentreonServiceReconcilerDeleteOwnerConflict(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+
+	now := metav1.Now()
+	cs := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "ping",
+			Namespace:         "default",
+			Finalizers:        []string{centreonServiceFinalizer},
+			DeletionTimestamp: &now,
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:     "central",
+			Name:     "ping",
+			Template: "template1",
+		},
+		Status: centreoncrd.CentreonServiceStatus{
+			Host:        "central",
+			ServiceName: "ping",
+		},
+	}
+	r, c, recorder := newTestCentreonServiceReconciler(t, map[string]centreonhandler.CentreonHandler{"default": mockCentreon}, cs)
+
+	// The service is managed by the same resource on another cluster
+	mockCentreon.EXPECT().GetService("central", "ping").AnyTimes().Return(&centreonhandler.CentreonService{Host: "central", Name: "ping"}, nil)
+	mockCentreon.EXPECT().GetServiceOwner("central", "ping").Return(&centreonhandler.Owner{ClusterID: "cluster2", Namespace: "default", Name: "ping", UID: "uid2"}, nil)
+	mockCentreon.EXPECT().DeleteService(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := reconcileCentreonService(t, r, client.ObjectKeyFromObject(cs))
+	assert.NoError(t, err)
+
+	// The resource is deleted and the service is kept on Centreon
+	err = c.Get(context.Background(), client.ObjectKeyFromObject(cs), &centreoncrd.CentreonService{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	events := getEvents(recorder)
+	assert.True(t, len(events) > 0 && strings.Contains(strings.Join(events, "\n"), "OwnerConflict"))
+}
+
+// fakeCentreonServices permit to mock the services on one platform
+type fakeCentreonServices struct {
+	services map[string]*centreonhandler.CentreonService
+	updates  []*centreonhandler.CentreonServiceDiff
+}
+
+// newFakeCentreonServices return the handler that read and write the services on fake platform
+func newFakeCentreonServices(mockCtrl *gomock.Controller) (fake *fakeCentreonServices, mockCentreon *mocks.MockCentreonHandler) {
+	fake = &fakeCentreonServices{
+		services: map[string]*centreonhandler.CentreonService{},
+	}
+	handler := centreonhandler.NewCentreonHandler(nil, logrus.NewEntry(logrus.New()))
+	mockCentreon = mocks.NewMockCentreonHandler(mockCtrl)
+
+	mockCentreon.EXPECT().GetService(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(host, name string) (*centreonhandler.CentreonService, error) {
+		return fake.services[host+"/"+name], nil
+	})
+	mockCentreon.EXPECT().GetServiceOwner(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(host, name string) (*centreonhandler.Owner, error) {
+		if cs := fake.services[host+"/"+name]; cs != nil {
+			return cs.Owner, nil
+		}
+		return nil, nil
+	})
+	mockCentreon.EXPECT().DiffService(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(handler.DiffService)
+	mockCentreon.EXPECT().CreateService(gomock.Any()).AnyTimes().DoAndReturn(func(cs *centreonhandler.CentreonService) error {
+		created := *cs
+		fake.services[cs.Host+"/"+cs.Name] = &created
+		return nil
+	})
+	mockCentreon.EXPECT().UpdateService(gomock.Any()).AnyTimes().DoAndReturn(func(csDiff *centreonhandler.CentreonServiceDiff) error {
+		fake.updates = append(fake.updates, csDiff)
+		if value, ok := csDiff.ParamsToSet["normal_check_interval"]; ok {
+			fake.services[csDiff.Host+"/"+csDiff.Name].NormalCheckInterval = value
+		}
+		return nil
+	})
+	mockCentreon.EXPECT().DeleteService(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(host, name string) error {
+		delete(fake.services, host+"/"+name)
+		return nil
+	})
+
+	return fake, mockCentreon
+}
+
+func TestCentreonServiceReconcilerMirrors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	prd, mockPrd := newFakeCentreonServices(mockCtrl)
+	dr, mockDr := newFakeCentreonServices(mockCtrl)
+
+	cs := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ping",
+			Namespace: "default",
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			PlatformRefs:        []string{"default", "dr"},
+			Host:                "central",
+			Name:                "ping",
+			Template:            "template1",
+			NormalCheckInterval: "5",
+			Activated:           true,
+		},
+	}
+	r, c, _ := newTestCentreonServiceReconciler(t, map[string]centreonhandler.CentreonHandler{"default": mockPrd, "dr": mockDr}, cs)
+	key := client.ObjectKeyFromObject(cs)
+
+	// When create service on all platforms
+	_, err := reconcileCentreonService(t, r, key)
+	assert.NoError(t, err)
+	assert.Contains(t, prd.services, "central/ping")
+	assert.Contains(t, dr.services, "central/ping")
+	cs = &centreoncrd.CentreonService{}
+	assert.NoError(t, c.Get(context.Background(), key, cs))
+	assert.Len(t, cs.Status.Platforms, 2)
+	for _, status := range cs.Status.Platforms {
+		assert.True(t, status.IsSync, status.Name)
+		assert.Equal(t, "ping", status.ExternalName, status.Name)
+	}
+
+	// When update service on all platforms
+	cs.Spec.NormalCheckInterval = "10"
+	assert.NoError(t, c.Update(context.Background(), cs))
+	_, err = reconcileCentreonService(t, r, key)
+	assert.NoError(t, err)
+	assert.Len(t, prd.updates, 1)
+	assert.Len(t, dr.updates, 1)
+	assert.Equal(t, "10", prd.services["central/ping"].NormalCheckInterval)
+	assert.Equal(t, "10", dr.services["central/ping"].NormalCheckInterval)
+
+	// When delete service on all platforms
+	cs = &centreoncrd.CentreonService{}
+	assert.NoError(t, c.Get(context.Background(), key, cs))
+	assert.NoError(t, c.Delete(context.Background(), cs))
+	_, err = reconcileCentreonService(t, r, key)
+	assert.NoError(t, err)
+	assert.Empty(t, prd.services)
+	assert.Empty(t, dr.services)
+	err = c.Get(context.Background(), key, &centreoncrd.CentreonService{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestCentreonServiceReconcilerDeleteUnloadedMirror(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	prd, mockPrd := newFakeCentreonServices(mockCtrl)
+
+	now := metav1.Now()
+	cs := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "ping",
+			Namespace:         "default",
+			Finalizers:        []string{centreonServiceFinalizer},
+			DeletionTimestamp: &now,
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			PlatformRefs: []string{"default", "dr"},
+			Host:         "central",
+			Name:         "ping",
+			Template:     "template1",
+		},
+		Status: centreoncrd.CentreonServiceStatus{
+			Host:        "central",
+			ServiceName: "ping",
+			Platforms: []centreoncrd.PlatformRefStatus{
+				{Name: "default", Host: "central", ExternalName: "ping", IsSync: true},
+				{Name: "dr", Host: "central", ExternalName: "ping", IsSync: true},
+			},
+		},
+	}
+	prd.services["central/ping"] = &centreonhandler.CentreonService{Host: "central", Name: "ping"}
+
+	// The platform dr can't be loaded
+	r, c, _ := newTestCentreonServiceReconciler(t, map[string]centreonhandler.CentreonHandler{"default": mockPrd}, cs)
+
+	// The resource is kept until the service is deleted on all platforms
+	_, err := reconcileCentreonService(t, r, client.ObjectKeyFromObject(cs))
+	assert.Error(t, err)
+	assert.Empty(t, prd.services)
+	current := &centreoncrd.CentreonService{}
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cs), current))
+	assert.Contains(t, current.Finalizers, centreonServiceFinalizer)
+}
+
+func TestCentreonServiceReconcilerNeedAdoptionOnExpectedIdentity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	prd, mockPrd := newFakeCentreonServices(mockCtrl)
+
+	cs := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ping",
+			Namespace:  "default",
+			Finalizers: []string{centreonServiceFinalizer},
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:      "central",
+			Name:      "ping",
+			Template:  "template1",
+			Activated: true,
+		},
+		Status: centreoncrd.CentreonServiceStatus{
+			Host:        "central",
+			ServiceName: "ping-old",
+		},
+	}
+
+	// The handled service was removed from Centreon, and another service exist on the expected identity
+	prd.services["central/ping"] = &centreonhandler.CentreonService{Host: "central", Name: "ping", Activated: "0"}
+	r, c, _ := newTestCentreonServiceReconciler(t, map[string]centreonhandler.CentreonHandler{"default": mockPrd}, cs)
+
+	_, err := reconcileCentreonService(t, r, client.ObjectKeyFromObject(cs))
+	assert.ErrorIs(t, err, errNeedAdoption)
+	assert.Empty(t, prd.updates)
+	assert.Equal(t, "0", prd.services["central/ping"].Activated)
+	current := &centreoncrd.CentreonService{}
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cs), current))
+	assert.Equal(t, "ping-old", current.Status.ServiceName)
+}
+
+func TestCentreonServiceReconcilerOwnedWithoutStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	prd, mockPrd := newFakeCentreonServices(mockCtrl)
+
+	cs := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ping",
+			Namespace:  "default",
+			Finalizers: []string{centreonServiceFinalizer},
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:      "central",
+			Name:      "ping",
+			Template:  "template1",
+			Activated: true,
+		},
+	}
+
+	// The service was created by the resource, but the status was not written
+	prd.services["central/ping"] = &centreonhandler.CentreonService{
+		Host:      "central",
+		Name:      "ping",
+		Activated: "1",
+		Owner:     &centreonhandler.Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping"},
+	}
+	r, c, _ := newTestCentreonServiceReconciler(t, map[string]centreonhandler.CentreonHandler{"default": mockPrd}, cs)
+
+	_, err := reconcileCentreonService(t, r, client.ObjectKeyFromObject(cs))
+	assert.NoError(t, err)
+	current := &centreoncrd.CentreonService{}
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cs), current))
+	assert.Nil(t, current.Status.Adoption)
+	assert.Equal(t, "central", current.Status.Host)
+	assert.Equal(t, "ping", current.Status.ServiceName)
+}
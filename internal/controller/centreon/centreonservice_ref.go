@@ -0,0 +1,76 @@
+package centreon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"emperror.dev/errors"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// resolveServiceRefs permit to get the services on Centreon platform from the CentreonService references
+// It use the identity from status, so the referenced service must be already created on the platform
+func resolveServiceRefs(ctx context.Context, c client.Client, namespace string, platform string, refs []centreoncrd.CentreonServiceRef) (services []string, err error) {
+	services = make([]string, 0, len(refs))
+	for _, ref := range refs {
+		cs := &centreoncrd.CentreonService{}
+		if err = c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, cs); err != nil {
+			return nil, errors.Wrapf(err, "Error when get CentreonService %s", ref.Name)
+		}
+		if !slices.Contains(cs.GetPlatforms(), platform) {
+			return nil, errors.Errorf("CentreonService %s is not created on platform %s", ref.Name, platform)
+		}
+		host, name := getServiceIdentity(cs, platform)
+		if host == "" || name == "" {
+			return nil, errors.Errorf("CentreonService %s is not yet created on platform %s", ref.Name, platform)
+		}
+		services = append(services, centreonhandler.ServiceKey(host, name))
+	}
+
+	return services, nil
+}
+
+// getServiceIdentity return the host and the service name on platform from status
+// The identity on the main platform is on status, the others are on the platforms status
+func getServiceIdentity(cs *centreoncrd.CentreonService, platform string) (host, name string) {
+	if platform == cs.GetPlatform() {
+		return cs.Status.Host, cs.Status.ServiceName
+	}
+	for _, status := range cs.Status.Platforms {
+		if status.Name == platform {
+			return status.Host, status.ExternalName
+		}
+	}
+
+	return "", ""
+}
+
+// watchCentreonServiceRefs permit to reconcile the resources that reference the CentreonService
+// It use the index `spec.serviceRefs`
+func watchCentreonServiceRefs(c client.Client, list client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		reconcileRequests := make([]reconcile.Request, 0)
+		objectList := helpers.CloneObject(list)
+
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.serviceRefs=%s", a.GetName()))
+
+		// Get all resources that use the current service
+		if err := c.List(ctx, objectList, &client.ListOptions{Namespace: a.GetNamespace(), FieldSelector: fs}); err != nil {
+			panic(err)
+		}
+
+		for _, o := range helpers.GetItems(objectList) {
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()}})
+		}
+
+		return reconcileRequests
+	}
+}
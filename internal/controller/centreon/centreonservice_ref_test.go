@@ -0,0 +1,84 @@
+package centreon
+
+import (
+	"context"
+	"testing"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveServiceRefs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, centreoncrd.AddToScheme(scheme))
+
+	created := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "s1",
+			Namespace: "default",
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host: "host1",
+			Name: "service2",
+		},
+	}
+	created.Status.Host = "host1"
+	created.Status.ServiceName = "service1"
+	mirrored := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "s4",
+			Namespace: "default",
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			PlatformRefs: []string{"p1", "p2", "p3"},
+			Host:         "host1",
+			Name:         "service4",
+		},
+	}
+	mirrored.Status.Host = "host1"
+	mirrored.Status.ServiceName = "service4"
+	mirrored.Status.Platforms = []centreoncrd.PlatformRefStatus{{Name: "p2", Host: "host2", ExternalName: "service4"}}
+	notCreated := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "s2",
+			Namespace: "default",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(created, mirrored, notCreated).Build()
+
+	// When services are created on Centreon, it use the identity from status
+	services, err := resolveServiceRefs(context.Background(), c, "default", "default", []centreoncrd.CentreonServiceRef{{Name: "s1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"host1,service1"}, services)
+
+	// When service is not yet created on Centreon
+	_, err = resolveServiceRefs(context.Background(), c, "default", "default", []centreoncrd.CentreonServiceRef{{Name: "s1"}, {Name: "s2"}})
+	assert.Error(t, err)
+
+	// When service not exist
+	_, err = resolveServiceRefs(context.Background(), c, "default", "default", []centreoncrd.CentreonServiceRef{{Name: "s3"}})
+	assert.Error(t, err)
+
+	// When service is not created on the platform
+	_, err = resolveServiceRefs(context.Background(), c, "default", "p1", []centreoncrd.CentreonServiceRef{{Name: "s1"}})
+	assert.Error(t, err)
+
+	// When service is mirrored, it use the identity on the platform
+	services, err = resolveServiceRefs(context.Background(), c, "default", "p1", []centreoncrd.CentreonServiceRef{{Name: "s4"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"host1,service4"}, services)
+	services, err = resolveServiceRefs(context.Background(), c, "default", "p2", []centreoncrd.CentreonServiceRef{{Name: "s4"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"host2,service4"}, services)
+
+	// When service is not yet created on mirror platform
+	_, err = resolveServiceRefs(context.Background(), c, "default", "p3", []centreoncrd.CentreonServiceRef{{Name: "s4"}})
+	assert.Error(t, err)
+
+	// When service is on other namespace
+	_, err = resolveServiceRefs(context.Background(), c, "other", "default", []centreoncrd.CentreonServiceRef{{Name: "s1"}})
+	assert.Error(t, err)
+}
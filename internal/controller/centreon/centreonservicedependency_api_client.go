@@ -0,0 +1,130 @@
+package centreon
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/disaster37/generic-objectmatcher/patch"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	json "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type centreonServiceDependencyApiClient struct {
+	*controller.BasicRemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler]
+	logger *logrus.Entry
+
+	// parents and children are the services on Centreon resolved from CentreonService references
+	parents  []string
+	children []string
+}
+
+func newCentreonServiceDependencyApiClient(client centreonhandler.CentreonHandler, parents, children []string, logger *logrus.Entry) controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler] {
+	return &centreonServiceDependencyApiClient{
+		BasicRemoteExternalReconciler: controller.NewBasicRemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler](client),
+		logger:                        logger,
+		parents:                       parents,
+		children:                      children,
+	}
+}
+
+func (h *centreonServiceDependencyApiClient) Build(o *centreoncrd.CentreonServiceDependency) (csd *CentreonServiceDependency, err error) {
+	return &CentreonServiceDependency{
+		CentreonServiceDependency: &centreonhandler.CentreonServiceDependency{
+			Name:                        o.GetExternalName(),
+			Description:                 o.GetDescription(),
+			InheritsParent:              helpers.BoolToString(&o.Spec.InheritsParent),
+			ExecutionFailureCriteria:    joinOptions(o.Spec.ExecutionFailureCriteria),
+			NotificationFailureCriteria: joinOptions(o.Spec.NotificationFailureCriteria),
+			ParentServices:              h.parents,
+			ChildServices:               h.children,
+		},
+	}, nil
+}
+
+func (h *centreonServiceDependencyApiClient) Get(o *centreoncrd.CentreonServiceDependency) (object *CentreonServiceDependency, err error) {
+	csd, err := h.Client().GetServiceDependency(getDependencyName(o))
+	if err != nil {
+		return nil, err
+	}
+	if csd == nil {
+		return nil, nil
+	}
+
+	return &CentreonServiceDependency{
+		CentreonServiceDependency: csd,
+	}, nil
+}
+
+func (h *centreonServiceDependencyApiClient) Create(object *CentreonServiceDependency, o *centreoncrd.CentreonServiceDependency) (err error) {
+	// Check policy
+	if o.Spec.Policy.NoCreate {
+		h.logger.Info("Skip create service dependency (policy NoCreate)")
+		return nil
+	}
+
+	return h.Client().CreateServiceDependency(object.CentreonServiceDependency)
+}
+
+func (h *centreonServiceDependencyApiClient) Update(object *CentreonServiceDependency, o *centreoncrd.CentreonServiceDependency) (err error) {
+	// Check policy
+	if o.Spec.Policy.NoUpdate {
+		h.logger.Info("Skip update service dependency (policy NoUpdate)")
+		return nil
+	}
+
+	return h.Client().UpdateServiceDependency(object.CentreonServiceDependencyDiff)
+}
+
+func (h *centreonServiceDependencyApiClient) Delete(o *centreoncrd.CentreonServiceDependency) (err error) {
+	// Check policy
+	if o.Spec.Policy.NoDelete {
+		h.logger.Info("Skip delete service dependency (policy NoDelete)")
+		return nil
+	}
+
+	return h.Client().DeleteServiceDependency(getDependencyName(o))
+}
+
+func (h *centreonServiceDependencyApiClient) Diff(currentOject *CentreonServiceDependency, expectedObject *CentreonServiceDependency, originalObject *CentreonServiceDependency, o *centreoncrd.CentreonServiceDependency, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	patchResult = &patch.PatchResult{}
+
+	csdDiff, err := h.Client().DiffServiceDependency(currentOject.CentreonServiceDependency, expectedObject.CentreonServiceDependency, o.Spec.Policy.ExcludeFieldsOnDiff)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when diff CentreonServiceDependency")
+	}
+
+	if csdDiff.IsDiff {
+		patchDiff, err := json.ConfigCompatibleWithStandardLibrary.Marshal(csdDiff)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert patched object to byte sequence")
+		}
+
+		patchResult.Patch = patchDiff
+	}
+
+	return patchResult, nil
+}
+
+// getDependencyName return the name of dependency on Centreon
+// It use the status when dependency already exist, because the name can be changed
+func getDependencyName(o *centreoncrd.CentreonServiceDependency) string {
+	if o.Status.DependencyName != "" {
+		return o.Status.DependencyName
+	}
+
+	return o.GetExternalName()
+}
+
+// joinOptions return the options as expected by Centreon, like `c,w`
+// The options are sorted to not detect diff when only the order change
+func joinOptions(options []string) string {
+	options = slices.Clone(options)
+	slices.Sort(options)
+
+	return strings.Join(slices.Compact(options), ",")
+}
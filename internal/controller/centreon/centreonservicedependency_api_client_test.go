@@ -0,0 +1,57 @@
+package centreon
+
+import (
+	"testing"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCentreonServiceDependencyBuild(t *testing.T) {
+	client := &centreonServiceDependencyApiClient{
+		parents:  []string{"host1,service1"},
+		children: []string{"host2,service1", "host2,service2"},
+	}
+
+	o := &centreoncrd.CentreonServiceDependency{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dep1",
+		},
+		Spec: centreoncrd.CentreonServiceDependencySpec{
+			InheritsParent:              true,
+			ExecutionFailureCriteria:    []string{"w", "c", "w"},
+			NotificationFailureCriteria: []string{"n"},
+		},
+	}
+
+	expectedCSD := &centreonhandler.CentreonServiceDependency{
+		Name:                        "dep1",
+		Description:                 "dep1",
+		InheritsParent:              "1",
+		ExecutionFailureCriteria:    "c,w",
+		NotificationFailureCriteria: "n",
+		ParentServices:              []string{"host1,service1"},
+		ChildServices:               []string{"host2,service1", "host2,service2"},
+	}
+
+	csd, err := client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCSD, csd.CentreonServiceDependency)
+	assert.Equal(t, []string{"w", "c", "w"}, o.Spec.ExecutionFailureCriteria)
+}
+
+func TestGetDependencyName(t *testing.T) {
+	o := &centreoncrd.CentreonServiceDependency{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dep1",
+		},
+	}
+	assert.Equal(t, "dep1", getDependencyName(o))
+
+	// When dependency is renamed
+	o.Spec.Name = "dep2"
+	o.Status.DependencyName = "dep1"
+	assert.Equal(t, "dep1", getDependencyName(o))
+}
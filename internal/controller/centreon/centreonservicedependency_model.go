@@ -0,0 +1,10 @@
+package centreon
+
+import "github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+
+// CentreonServiceDependency wrap the original model because we haven't unique model on each step.
+// Sometime, we need to have centreonhandler.CentreonServiceDependency, sometime we need to have centreonhandler.CentreonServiceDependencyDiff
+type CentreonServiceDependency struct {
+	*centreonhandler.CentreonServiceDependency
+	*centreonhandler.CentreonServiceDependencyDiff
+}
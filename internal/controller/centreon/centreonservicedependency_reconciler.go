@@ -0,0 +1,196 @@
+package centreon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/common"
+	"github.com/disaster37/monitoring-operator/internal/controller/platform"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/object"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type centreonServiceDependencyReconciler struct {
+	controller.RemoteReconcilerAction[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler]
+	name           string
+	platforms      *platform.PlatformRegistry
+	resyncInterval time.Duration
+}
+
+func newCentreonServiceDependencyReconciler(name string, client client.Client, recorder record.EventRecorder, platforms *platform.PlatformRegistry, resyncInterval time.Duration) controller.RemoteReconcilerAction[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler] {
+	return &centreonServiceDependencyReconciler{
+		RemoteReconcilerAction: controller.NewRemoteReconcilerAction[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler](
+			client,
+			recorder,
+		),
+		name:           name,
+		platforms:      platforms,
+		resyncInterval: resyncInterval,
+	}
+}
+
+func (h *centreonServiceDependencyReconciler) GetRemoteHandler(ctx context.Context, req ctrl.Request, o object.RemoteObject, logger *logrus.Entry) (handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler], res ctrl.Result, err error) {
+	csd := o.(*centreoncrd.CentreonServiceDependency)
+
+	meta, _, err := platform.GetClient(csd.GetPlatform(), csd.Namespace, h.platforms)
+	if err != nil {
+		return nil, res, err
+	}
+
+	// Read the services from CentreonService resources
+	// They are not needed to delete the dependency
+	var parents, children []string
+	if csd.DeletionTimestamp.IsZero() {
+		if parents, err = resolveServiceRefs(ctx, h.Client(), csd.Namespace, csd.GetPlatform(), csd.Spec.Services); err != nil {
+			return nil, res, errors.Wrap(err, "Error when resolve services")
+		}
+		if children, err = resolveServiceRefs(ctx, h.Client(), csd.Namespace, csd.GetPlatform(), csd.Spec.DependentServices); err != nil {
+			return nil, res, errors.Wrap(err, "Error when resolve dependent services")
+		}
+	}
+
+	handler = newCentreonServiceDependencyApiClient(meta.(centreonhandler.CentreonHandler), parents, children, logger)
+
+	return handler, res, nil
+}
+
+func (h *centreonServiceDependencyReconciler) Configure(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler], logger *logrus.Entry) (res ctrl.Result, err error) {
+	// Set prometheus Metrics
+	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(1)
+
+	// Set plaformRef status
+	csd := o.(*centreoncrd.CentreonServiceDependency)
+	csd.Status.PlatformRef = csd.GetPlatform()
+
+	// Keep if the dependency is already handled, before read it on platform
+	data[isManagedKey] = csd.Status.DependencyName != ""
+
+	return h.RemoteReconcilerAction.Configure(ctx, o, data, handler, logger)
+}
+
+func (h *centreonServiceDependencyReconciler) Delete(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler], logger *logrus.Entry) (err error) {
+	// Set prometheus Metrics
+	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
+	common.ControllerDrift.DeleteLabelValues(h.name, o.GetNamespace(), o.GetName())
+
+	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
+}
+
+func (h *centreonServiceDependencyReconciler) OnError(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler], currentErr error, logger *logrus.Entry) (res ctrl.Result, err error) {
+	common.TotalErrors.Inc()
+	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Inc()
+
+	return h.RemoteReconcilerAction.OnError(ctx, o, data, handler, currentErr, logger)
+}
+
+func (h *centreonServiceDependencyReconciler) OnSuccess(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler], diff controller.RemoteDiff[*CentreonServiceDependency], logger *logrus.Entry) (res ctrl.Result, err error) {
+	csd := o.(*centreoncrd.CentreonServiceDependency)
+
+	// Reset the current cluster errors
+	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
+
+	if diff.NeedCreate() || diff.NeedUpdate() {
+		csd.Status.DependencyName = csd.GetExternalName()
+	}
+
+	// Keep the expected resource to know on next reconcile if the diff come from changes made on Centreon
+	if hash, ok := data[expectedHashKey].(string); ok {
+		csd.Status.ExpectedHash = hash
+	}
+
+	if res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Reconcile periodically to detect the changes made on Centreon
+	if !res.Requeue && res.RequeueAfter == 0 {
+		res.RequeueAfter = resyncAfter(h.resyncInterval)
+	}
+
+	return res, nil
+}
+
+func (h *centreonServiceDependencyReconciler) Diff(ctx context.Context, o object.RemoteObject, read controller.RemoteRead[*CentreonServiceDependency], data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceDependency, *CentreonServiceDependency, centreonhandler.CentreonHandler], logger *logrus.Entry, ignoreDiff ...patch.CalculateOption) (diff controller.RemoteDiff[*CentreonServiceDependency], res ctrl.Result, err error) {
+	// Get the original object from status to use 3-way diff
+	originalObject := new(CentreonServiceDependency)
+	if o.GetStatus().GetLastAppliedConfiguration() != "" {
+		if err = helper.UnZipBase64Decode(o.GetStatus().GetLastAppliedConfiguration(), originalObject); err != nil {
+			return diff, res, errors.Wrap(err, "Error when create object from 'lastAppliedConfiguration'")
+		}
+	}
+
+	diff = controller.NewBasicRemoteDiff[*CentreonServiceDependency]()
+	currentObject := read.GetCurrentObject()
+	expectedObject := read.GetExpectedObject()
+	csd := o.(*centreoncrd.CentreonServiceDependency)
+
+	// Keep the expected dependency to know on next reconcile if the diff come from changes made on Centreon
+	hash, err := hashExpected(expectedObject.CentreonServiceDependency)
+	if err != nil {
+		return diff, res, err
+	}
+	data[expectedHashKey] = hash
+
+	// Check if need to create object
+	if currentObject == nil || currentObject.CentreonServiceDependency == nil {
+		diff.AddDiff(fmt.Sprintf("Need to create new object %s on remote target", o.GetName()))
+		diff.SetObjectToCreate(expectedObject)
+		return diff, res, nil
+	}
+
+	// Take ownership of the dependency that already exist on Centreon
+	if !isManaged(data) && csd.Status.Adoption == nil {
+		adoption, err := adopt(csd.Spec.Policy, currentObject.CentreonServiceDependency)
+		if err != nil {
+			return diff, res, err
+		}
+		if adoption != nil {
+			csd.Status.Adoption = adoption
+			csd.Status.DependencyName = currentObject.CentreonServiceDependency.Name
+			logger.Infof("Take ownership of service dependency that already exist on Centreon")
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "Adopted", "Service dependency already exist on Centreon, the operator take ownership of it")
+		}
+	}
+
+	differ, err := handler.Diff(currentObject, expectedObject, originalObject, csd, ignoreDiff...)
+	if err != nil {
+		return diff, res, errors.Wrapf(err, "Error when diffing %s for remote target", o.GetName())
+	}
+	if differ.IsEmpty() {
+		csd.Status.Drift = nil
+		resetDrift(o, h.name)
+		return diff, res, nil
+	}
+	diff.AddDiff(string(differ.Patch))
+
+	// Only report the changes made on Centreon outside of the operator, the changes of resource are always applied
+	if csd.Spec.Policy.IsDriftReportOnly() && isManaged(data) && !isExpectedChanged(o, csd.Status.ExpectedHash, hash) {
+		csd.Status.Drift = reportDrift(h.Recorder(), o, csd.Status.Drift, h.name, diff.Diff(), logger)
+		return controller.NewBasicRemoteDiff[*CentreonServiceDependency](), res, nil
+	}
+	csd.Status.Drift = nil
+	resetDrift(o, h.name)
+
+	csdDiff := &centreonhandler.CentreonServiceDependencyDiff{}
+	if err = json.Unmarshal(differ.Patch, csdDiff); err != nil {
+		return diff, res, errors.Wrap(err, "Error when unmarshall the CentreonServiceDependency patch")
+	}
+	diff.SetObjectToUpdate(&CentreonServiceDependency{
+		CentreonServiceDependency:     expectedObject.CentreonServiceDependency,
+		CentreonServiceDependencyDiff: csdDiff,
+	})
+
+	return diff, res, nil
+}
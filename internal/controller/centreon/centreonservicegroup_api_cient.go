@@ -9,17 +9,23 @@ import (
 	json "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 type centreonServiceGroupApiClient struct {
 	*controller.BasicRemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler]
-	logger *logrus.Entry
+	logger  *logrus.Entry
+	mirrors platformMirrors[centreonServiceGroupApiClient]
 }
 
-func newCentreonServiceGroupApiClient(client centreonhandler.CentreonHandler, logger *logrus.Entry) controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler] {
+// centreonServiceGroupMirror permit to handle the service group on one of the other target platforms
+type centreonServiceGroupMirror = platformMirror[centreonServiceGroupApiClient]
+
+func newCentreonServiceGroupApiClient(client centreonhandler.CentreonHandler, logger *logrus.Entry, mirrors ...*centreonServiceGroupMirror) controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler] {
 	return &centreonServiceGroupApiClient{
 		BasicRemoteExternalReconciler: controller.NewBasicRemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler](client),
 		logger:                        logger,
+		mirrors:                       mirrors,
 	}
 }
 
@@ -28,11 +34,20 @@ func (h *centreonServiceGroupApiClient) Build(o *centreoncrd.CentreonServiceGrou
 		CentreonServiceGroup: &centreonhandler.CentreonServiceGroup{
 			Name:        o.GetExternalName(),
 			Activated:   helpers.BoolToString(&o.Spec.Activated),
-			Comment:     "Managed by monitoring-operator",
+			Comment:     centreoncrd.DefaultComment,
 			Description: o.Spec.Description,
 		},
 	}
 
+	for _, m := range h.mirrors.active() {
+		if csg.Mirrors == nil {
+			csg.Mirrors = map[string]*CentreonServiceGroup{}
+		}
+		csg.Mirrors[m.platform] = &CentreonServiceGroup{
+			CentreonServiceGroup: csg.CentreonServiceGroup,
+		}
+	}
+
 	return csg, nil
 }
 
@@ -51,13 +66,36 @@ func (h *centreonServiceGroupApiClient) Get(o *centreoncrd.CentreonServiceGroup)
 	if err != nil {
 		return nil, err
 	}
+	unmanaged := csg != nil && !isManagedServiceGroup(csg, o.Status.ServiceGroupName)
 
-	if csg == nil {
+	// The status was not written after the service group was created, so it is set with the service group found
+	if csg != nil && !unmanaged {
+		o.Status.ServiceGroupName = csg.Name
+	}
+
+	// Read the service group on other platforms
+	// A platform on error not block the others
+	mirrors := readMirrors(h.mirrors, &o.Status.Platforms, "service group", func(m *centreonServiceGroupMirror) (*CentreonServiceGroup, error) {
+		mcsg, err := m.client.Client().GetServiceGroup(getServiceGroupMirrorIdentity(m, o))
+		if err != nil || mcsg == nil {
+			return nil, err
+		}
+		return &CentreonServiceGroup{
+			CentreonServiceGroup: mcsg,
+			unmanaged:            !isManagedServiceGroup(mcsg, getServiceGroupMirrorRecordedIdentity(m, o)),
+		}, nil
+	}, identifyServiceGroup)
+
+	if csg == nil && len(mirrors) == 0 {
 		return nil, nil
 	}
 
 	object = &CentreonServiceGroup{
 		CentreonServiceGroup: csg,
+		unmanaged:            unmanaged,
+	}
+	if len(mirrors) > 0 {
+		object.Mirrors = mirrors
 	}
 
 	return object, nil
@@ -71,7 +109,15 @@ func (h *centreonServiceGroupApiClient) Create(object *CentreonServiceGroup, o *
 	}
 
 	// Create service on Centreon
-	return h.Client().CreateServiceGroup(object.CentreonServiceGroup)
+	if object.CentreonServiceGroup != nil {
+		err = h.Client().CreateServiceGroup(object.CentreonServiceGroup)
+	}
+
+	applyMirrors(h.mirrors, &o.Status.Platforms, "create service group", object.Mirrors, func(m *centreonServiceGroupMirror, mcsg *CentreonServiceGroup) error {
+		return m.client.Client().CreateServiceGroup(mcsg.CentreonServiceGroup)
+	}, identifyServiceGroup)
+
+	return err
 }
 
 func (h *centreonServiceGroupApiClient) Update(object *CentreonServiceGroup, o *centreoncrd.CentreonServiceGroup) (err error) {
@@ -82,7 +128,15 @@ func (h *centreonServiceGroupApiClient) Update(object *CentreonServiceGroup, o *
 	}
 
 	// Update service on Centreon
-	return h.Client().UpdateServiceGroup(object.CentreonServiceGroupDiff)
+	if object.CentreonServiceGroupDiff != nil {
+		err = h.Client().UpdateServiceGroup(object.CentreonServiceGroupDiff)
+	}
+
+	applyMirrors(h.mirrors, &o.Status.Platforms, "update service group", object.Mirrors, func(m *centreonServiceGroupMirror, mcsg *CentreonServiceGroup) error {
+		return m.client.Client().UpdateServiceGroup(mcsg.CentreonServiceGroupDiff)
+	}, identifyServiceGroup)
+
+	return err
 }
 
 func (h *centreonServiceGroupApiClient) Delete(o *centreoncrd.CentreonServiceGroup) (err error) {
@@ -92,7 +146,67 @@ func (h *centreonServiceGroupApiClient) Delete(o *centreoncrd.CentreonServiceGro
 		return nil
 	}
 
-	return h.Client().DeleteServiceGroup(o.GetExternalName())
+	errs := make([]error, 0)
+	if err = h.Client().DeleteServiceGroup(o.GetExternalName()); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Delete service group on other platforms
+	// The service group is kept until it is deleted on all platforms
+	errs = append(errs, deleteMirrors(h.mirrors, "service group", func(m *centreonServiceGroupMirror) error {
+		return m.client.Client().DeleteServiceGroup(getServiceGroupMirrorIdentity(m, o))
+	})...)
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// DeleteRemovedMirrors permit to delete service group on platforms that are not anymore a target
+func (h *centreonServiceGroupApiClient) DeleteRemovedMirrors(o *centreoncrd.CentreonServiceGroup) (err error) {
+	return deleteRemovedMirrors(h.mirrors, &o.Status.Platforms, "service group", o.Spec.Policy.NoDelete, h.logger, func(m *centreonServiceGroupMirror) error {
+		return m.client.Client().DeleteServiceGroup(getServiceGroupMirrorIdentity(m, o))
+	})
+}
+
+// MirrorErrors return the errors when handle service group on the other platforms
+func (h *centreonServiceGroupApiClient) MirrorErrors() (errs []error) {
+	return h.mirrors.errors()
+}
+
+// getServiceGroupMirrorIdentity return the name of service group on platform
+// It use the status when service group already exist on platform
+func getServiceGroupMirrorIdentity(m *centreonServiceGroupMirror, o *centreoncrd.CentreonServiceGroup) (serviceGroupName string) {
+	if serviceGroupName = getServiceGroupMirrorRecordedIdentity(m, o); serviceGroupName != "" {
+		return serviceGroupName
+	}
+
+	return o.GetExternalName()
+}
+
+// getServiceGroupMirrorRecordedIdentity return the name of service group already handled by the resource on platform
+// It return empty string when the service group is not yet handled on platform
+func getServiceGroupMirrorRecordedIdentity(m *centreonServiceGroupMirror, o *centreoncrd.CentreonServiceGroup) (serviceGroupName string) {
+	if status := centreoncrd.GetPlatformRefStatus(o.Status.Platforms, m.platform); status != nil {
+		return status.ExternalName
+	}
+
+	return ""
+}
+
+// isManagedServiceGroup return true if the service group found on Centreon is handled by the resource
+// The service group has no owner, so it is handled if it is on its recorded identity, or if it has the comment set by the operator, like when the status was not written after it was created
+// Else, it is another service group that need to be adopted
+func isManagedServiceGroup(sg *centreonhandler.CentreonServiceGroup, recorded string) bool {
+	return (recorded != "" && sg.Name == recorded) || sg.Comment == centreoncrd.DefaultComment
+}
+
+// identifyServiceGroup return the identity of service group to set on platform status
+// The service group not handled by the resource has no identity, so it is not recorded before adoption
+func identifyServiceGroup(csg *CentreonServiceGroup) (host, name string) {
+	if csg.unmanaged {
+		return "", ""
+	}
+
+	return "", csg.CentreonServiceGroup.Name
 }
 
 func (h *centreonServiceGroupApiClient) Diff(currentOject *CentreonServiceGroup, expectedObject *CentreonServiceGroup, originalObject *CentreonServiceGroup, o *centreoncrd.CentreonServiceGroup, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
@@ -5,6 +5,9 @@ import (
 
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,3 +33,56 @@ func TestCentreonServiceGroupBuild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCSG, csg.CentreonServiceGroup)
 }
+
+func TestCentreonServiceGroupGetUnmanaged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	mockMirror := mocks.NewMockCentreonHandler(mockCtrl)
+	logger := logrus.NewEntry(logrus.New())
+	mirror := &centreonServiceGroupMirror{
+		platform: "p2",
+		client:   newCentreonServiceGroupApiClient(mockMirror, logger).(*centreonServiceGroupApiClient),
+	}
+	client := newCentreonServiceGroupApiClient(mockCentreon, logger, mirror)
+
+	o := &centreoncrd.CentreonServiceGroup{
+		Spec: centreoncrd.CentreonServiceGroupSpec{
+			Name: "sg1",
+		},
+	}
+
+	// When the service group was created by the operator but the status was not written
+	mockCentreon.EXPECT().GetServiceGroup("sg1").Return(&centreonhandler.CentreonServiceGroup{Name: "sg1", Comment: centreoncrd.DefaultComment}, nil)
+	mockMirror.EXPECT().GetServiceGroup("sg1").Return(nil, nil)
+	csg, err := client.Get(o)
+	assert.NoError(t, err)
+	assert.False(t, csg.hasUnmanaged())
+	assert.Equal(t, "sg1", o.Status.ServiceGroupName)
+
+	// When the service group already exist on the new platform and it is not created by the operator
+	mockCentreon.EXPECT().GetServiceGroup("sg1").Return(&centreonhandler.CentreonServiceGroup{Name: "sg1", Comment: centreoncrd.DefaultComment}, nil)
+	mockMirror.EXPECT().GetServiceGroup("sg1").Return(&centreonhandler.CentreonServiceGroup{Name: "sg1", Comment: "manual"}, nil)
+	csg, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.True(t, csg.hasUnmanaged())
+	assert.Equal(t, "", centreoncrd.GetPlatformRefStatus(o.Status.Platforms, "p2").ExternalName)
+
+	// When the service group is already handled on the platform
+	o.Status.Platforms = []centreoncrd.PlatformRefStatus{{Name: "p2", ExternalName: "sg1"}}
+	mockCentreon.EXPECT().GetServiceGroup("sg1").Return(&centreonhandler.CentreonServiceGroup{Name: "sg1", Comment: "manual"}, nil)
+	mockMirror.EXPECT().GetServiceGroup("sg1").Return(&centreonhandler.CentreonServiceGroup{Name: "sg1", Comment: "manual"}, nil)
+	csg, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.False(t, csg.hasUnmanaged())
+
+	// When the service group already exist and it is not created by the operator
+	o.Status.ServiceGroupName = ""
+	o.Status.Platforms = nil
+	mockCentreon.EXPECT().GetServiceGroup("sg1").Return(&centreonhandler.CentreonServiceGroup{Name: "sg1", Comment: "manual"}, nil)
+	mockMirror.EXPECT().GetServiceGroup("sg1").Return(nil, nil)
+	csg, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.True(t, csg.hasUnmanaged())
+	assert.Equal(t, "", o.Status.ServiceGroupName)
+}
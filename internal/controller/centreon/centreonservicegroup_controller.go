@@ -18,6 +18,7 @@ package centreon
 
 import (
 	"context"
+	"time"
 
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/internal/controller/platform"
@@ -29,7 +30,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -41,10 +44,11 @@ type CentreonServiceGroupReconciler struct {
 	controller.Controller
 	controller.RemoteReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler]
 	controller.RemoteReconcilerAction[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler]
-	name string
+	name      string
+	platforms *platform.PlatformRegistry
 }
 
-func NewCentreonServiceGroupReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder, platforms map[string]*platform.ComputedPlatform) controller.Controller {
+func NewCentreonServiceGroupReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder, platforms *platform.PlatformRegistry, resyncInterval time.Duration) controller.Controller {
 	return &CentreonServiceGroupReconciler{
 		Controller: controller.NewBasicController(),
 		RemoteReconciler: controller.NewBasicRemoteReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler](
@@ -59,8 +63,10 @@ func NewCentreonServiceGroupReconciler(client client.Client, logger *logrus.Entr
 			client,
 			recorder,
 			platforms,
+			resyncInterval,
 		),
-		name: centreonServiceGroupName,
+		name:      centreonServiceGroupName,
+		platforms: platforms,
 	}
 }
 
@@ -99,5 +105,6 @@ func (r *CentreonServiceGroupReconciler) SetupWithManager(mgr ctrl.Manager) erro
 		WithOptions(k8scontroller.Options{
 			RateLimiter: helper.DefaultControllerRateLimiter[reconcile.Request](),
 		}).
+		WatchesRawSource(source.Channel(r.platforms.Subscribe(), handler.EnqueueRequestsFromMapFunc(platform.WatchPlatform(r.Client(), &centreoncrd.CentreonServiceGroupList{})))).
 		Complete(r)
 }
@@ -4,7 +4,47 @@ import "github.com/disaster37/monitoring-operator/pkg/centreonhandler"
 
 // CentreonServiceGroup wrap the original model because we haven't unique model on each step.
 // Sometime, we need to have centreonhandler.CentreonServiceGroup, sometime we need to have centreonhandler.CentreonServiceGroupDiff
+// Mirrors is the same service group on the other target platforms, by platform name
 type CentreonServiceGroup struct {
 	*centreonhandler.CentreonServiceGroup
 	*centreonhandler.CentreonServiceGroupDiff
+	Mirrors map[string]*CentreonServiceGroup `json:"mirrors,omitempty"`
+
+	// unmanaged is true when the service group exist on Centreon but it is not handled by the resource
+	// It need to be adopted before the resource can handle it
+	unmanaged bool
+}
+
+// isEmpty return true if there are nothing to handle on any platform
+func (h *CentreonServiceGroup) isEmpty() bool {
+	return h.CentreonServiceGroup == nil && len(h.Mirrors) == 0
+}
+
+// hasUnmanaged return true if the service group exist on one of the platforms but it is not handled by the resource
+func (h *CentreonServiceGroup) hasUnmanaged() bool {
+	if h.CentreonServiceGroup != nil && h.unmanaged {
+		return true
+	}
+	for _, mirror := range h.Mirrors {
+		if mirror.hasUnmanaged() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getByPlatform return the service group on each platform, by platform name
+func (h *CentreonServiceGroup) getByPlatform(platform string) map[string]*centreonhandler.CentreonServiceGroup {
+	serviceGroups := make(map[string]*centreonhandler.CentreonServiceGroup, len(h.Mirrors)+1)
+	if h.CentreonServiceGroup != nil {
+		serviceGroups[platform] = h.CentreonServiceGroup
+	}
+	for platformRef, mirror := range h.Mirrors {
+		if mirror.CentreonServiceGroup != nil {
+			serviceGroups[platformRef] = mirror.CentreonServiceGroup
+		}
+	}
+
+	return serviceGroups
 }
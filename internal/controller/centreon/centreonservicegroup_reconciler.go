@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/disaster37/generic-objectmatcher/patch"
@@ -15,41 +17,79 @@ import (
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/disaster37/operator-sdk-extra/pkg/object"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// errServiceGroupMirrorNotSync is returned when the service group can't be handled on some of the other target platforms
+var errServiceGroupMirrorNotSync = errors.New("Service group is not up to date on some platforms")
+
 type centreonServiceGroupReconciler struct {
 	controller.RemoteReconcilerAction[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler]
-	name      string
-	platforms map[string]*platform.ComputedPlatform
+	name           string
+	platforms      *platform.PlatformRegistry
+	resyncInterval time.Duration
 }
 
-func newCentreonServiceGroupReconciler(name string, client client.Client, recorder record.EventRecorder, platforms map[string]*platform.ComputedPlatform) controller.RemoteReconcilerAction[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler] {
+func newCentreonServiceGroupReconciler(name string, client client.Client, recorder record.EventRecorder, platforms *platform.PlatformRegistry, resyncInterval time.Duration) controller.RemoteReconcilerAction[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler] {
 	return &centreonServiceGroupReconciler{
 		RemoteReconcilerAction: controller.NewRemoteReconcilerAction[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler](
 			client,
 			recorder,
 		),
-		name:      name,
-		platforms: platforms,
+		name:           name,
+		platforms:      platforms,
+		resyncInterval: resyncInterval,
 	}
 }
 
 func (h *centreonServiceGroupReconciler) GetRemoteHandler(ctx context.Context, req ctrl.Request, o object.RemoteObject, logger *logrus.Entry) (handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler], res ctrl.Result, err error) {
 	cs := o.(*centreoncrd.CentreonServiceGroup)
+	platforms := cs.GetPlatforms()
 
-	meta, _, err := platform.GetClient(cs.GetPlatform(), h.platforms)
+	meta, _, err := platform.GetClient(platforms[0], cs.Namespace, h.platforms)
 	if err != nil {
 		return nil, res, err
 	}
 
-	handler = newCentreonServiceGroupApiClient(meta.(centreonhandler.CentreonHandler), logger)
+	// The service group is mirrored on the other target platforms
+	// It also need to be deleted from platforms that are not anymore a target
+	mirrors := make([]*centreonServiceGroupMirror, 0, len(platforms)-1)
+	for _, platformRef := range platforms[1:] {
+		mirrors = append(mirrors, h.newMirror(platformRef, cs.Namespace, false, logger))
+	}
+	for _, status := range cs.Status.Platforms {
+		if !slices.Contains(platforms, status.Name) {
+			mirrors = append(mirrors, h.newMirror(status.Name, cs.Namespace, true, logger))
+		}
+	}
+
+	handler = newCentreonServiceGroupApiClient(meta.(centreonhandler.CentreonHandler), logger, mirrors...)
 
 	return handler, res, nil
 }
 
+// newMirror permit to get the api client to handle the service group on another platform
+// The error is kept on mirror to not block the others platforms
+func (h *centreonServiceGroupReconciler) newMirror(platformRef string, namespace string, removed bool, logger *logrus.Entry) *centreonServiceGroupMirror {
+	m := &centreonServiceGroupMirror{
+		platform: platformRef,
+		removed:  removed,
+	}
+
+	meta, _, err := platform.GetClient(platformRef, namespace, h.platforms)
+	if err != nil {
+		m.err = errors.Wrapf(err, "Error when get platform %s", platformRef)
+		return m
+	}
+	m.client = newCentreonServiceGroupApiClient(meta.(centreonhandler.CentreonHandler), logger.WithField("platform", platformRef)).(*centreonServiceGroupApiClient)
+
+	return m
+}
+
 func (h *centreonServiceGroupReconciler) Configure(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler], logger *logrus.Entry) (res ctrl.Result, err error) {
 	// Set prometheus Metrics
 	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(1)
@@ -58,12 +98,16 @@ func (h *centreonServiceGroupReconciler) Configure(ctx context.Context, o object
 	csg := o.(*centreoncrd.CentreonServiceGroup)
 	csg.Status.PlatformRef = csg.GetPlatform()
 
+	// Keep if the service group is already handled, before read it on platforms
+	data[isManagedKey] = csg.Status.ServiceGroupName != "" || hasPlatformIdentity(csg.Status.Platforms)
+
 	return h.RemoteReconcilerAction.Configure(ctx, o, data, handler, logger)
 }
 
 func (h *centreonServiceGroupReconciler) Delete(ctx context.Context, o object.RemoteObject, data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler], logger *logrus.Entry) (err error) {
 	// Set prometheus Metrics
 	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
+	common.ControllerDrift.DeleteLabelValues(h.name, o.GetNamespace(), o.GetName())
 
 	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
 }
@@ -72,6 +116,17 @@ func (h *centreonServiceGroupReconciler) OnError(ctx context.Context, o object.R
 	common.TotalErrors.Inc()
 	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Inc()
 
+	// Report the error on main platform when service group is mirrored
+	csg := o.(*centreoncrd.CentreonServiceGroup)
+	if len(csg.GetPlatforms()) > 1 && !errors.Is(currentErr, errServiceGroupMirrorNotSync) {
+		status := centreoncrd.PlatformRefStatus{
+			Name:             csg.GetPlatform(),
+			ExternalName:     csg.Status.ServiceGroupName,
+			LastErrorMessage: currentErr.Error(),
+		}
+		centreoncrd.SetPlatformRefStatus(&csg.Status.Platforms, status)
+	}
+
 	return h.RemoteReconcilerAction.OnError(ctx, o, data, handler, currentErr, logger)
 }
 
@@ -85,7 +140,45 @@ func (h *centreonServiceGroupReconciler) OnSuccess(ctx context.Context, o object
 		sg.Status.ServiceGroupName = sg.GetExternalName()
 	}
 
-	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	// Handle the status of service group on each platform
+	if apiClient, ok := handler.(*centreonServiceGroupApiClient); ok {
+		errs := make([]error, 0)
+		if err = apiClient.DeleteRemovedMirrors(sg); err != nil {
+			errs = append(errs, err)
+		}
+		if len(sg.GetPlatforms()) > 1 {
+			centreoncrd.SetPlatformRefStatus(&sg.Status.Platforms, centreoncrd.PlatformRefStatus{
+				Name:         sg.GetPlatform(),
+				ExternalName: sg.Status.ServiceGroupName,
+				IsSync:       true,
+			})
+		} else {
+			centreoncrd.RemovePlatformRefStatus(&sg.Status.Platforms, sg.GetPlatform())
+			if len(sg.Status.Platforms) == 0 {
+				sg.Status.Platforms = nil
+			}
+		}
+		errs = append(errs, apiClient.MirrorErrors()...)
+		if len(errs) > 0 {
+			return res, fmt.Errorf("%w: %s", errServiceGroupMirrorNotSync, utilerrors.NewAggregate(errs).Error())
+		}
+	}
+
+	// Keep the expected resource to know on next reconcile if the diff come from changes made on Centreon
+	if hash, ok := data[expectedHashKey].(string); ok {
+		sg.Status.ExpectedHash = hash
+	}
+
+	if res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Reconcile periodically to detect the changes made on Centreon
+	if !res.Requeue && res.RequeueAfter == 0 {
+		res.RequeueAfter = resyncAfter(h.resyncInterval)
+	}
+
+	return res, nil
 }
 
 func (h *centreonServiceGroupReconciler) Diff(ctx context.Context, o object.RemoteObject, read controller.RemoteRead[*CentreonServiceGroup], data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler], logger *logrus.Entry, ignoreDiff ...patch.CalculateOption) (diff controller.RemoteDiff[*CentreonServiceGroup], res ctrl.Result, err error) {
@@ -99,30 +192,127 @@ func (h *centreonServiceGroupReconciler) Diff(ctx context.Context, o object.Remo
 	}
 
 	diff = controller.NewBasicRemoteDiff[*CentreonServiceGroup]()
+	currentObject := read.GetCurrentObject()
+	expectedObject := read.GetExpectedObject()
+	if currentObject == nil {
+		currentObject = &CentreonServiceGroup{}
+	}
+	objectToCreate := &CentreonServiceGroup{}
 
-	// Check if need to create object on remote
-	if read.GetCurrentObject() == nil {
-		diff.SetObjectToCreate(read.GetExpectedObject())
-		diff.AddDiff(fmt.Sprintf("Need to create new object %s on remote target", o.GetName()))
+	csg := o.(*centreoncrd.CentreonServiceGroup)
 
-		return diff, res, nil
+	// Keep the expected service group to know on next reconcile if the diff come from changes made on Centreon
+	hash, err := hashExpected(expectedObject.getByPlatform(csg.GetPlatform()))
+	if err != nil {
+		return diff, res, err
 	}
+	data[expectedHashKey] = hash
 
-	differ, err := handler.Diff(read.GetCurrentObject(), read.GetExpectedObject(), originalObject, o.(*centreoncrd.CentreonServiceGroup), ignoreDiff...)
-	if err != nil {
-		return diff, res, errors.Wrapf(err, "Error when diffing %s for remote target", o.GetName())
+	// Take ownership of the service group that already exist on Centreon and that is not handled by the resource
+	// The service group created by the resource is recognized with its comment, even if its identity was not recorded on status
+	if currentObject.hasUnmanaged() {
+		adoption, err := adopt(csg.Spec.Policy, currentObject.getByPlatform(csg.GetPlatform()))
+		if err != nil {
+			return diff, res, err
+		}
+		if adoption != nil {
+			csg.Status.Adoption = adoption
+			if currentObject.CentreonServiceGroup != nil {
+				csg.Status.ServiceGroupName = currentObject.CentreonServiceGroup.Name
+			}
+			for platformRef, mirror := range currentObject.Mirrors {
+				centreoncrd.SetPlatformRefStatus(&csg.Status.Platforms, centreoncrd.PlatformRefStatus{
+					Name:         platformRef,
+					ExternalName: mirror.CentreonServiceGroup.Name,
+				})
+			}
+			logger.Infof("Take ownership of service group that already exist on Centreon")
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "Adopted", "Service group already exist on Centreon, the operator take ownership of it")
+		}
+	} else if !currentObject.isEmpty() {
+		// The service group is already handled, even if it was not recorded on status
+		data[isManagedKey] = true
+	}
+	objectToUpdate := &CentreonServiceGroup{}
+
+	// Check if need to create object on main platform
+	if currentObject.CentreonServiceGroup == nil {
+		objectToCreate.CentreonServiceGroup = expectedObject.CentreonServiceGroup
+		diff.AddDiff(fmt.Sprintf("Need to create new object %s on remote target", o.GetName()))
+	} else {
+		csgDiff, patchDiff, err := h.diffServiceGroup(handler, currentObject, expectedObject, originalObject, o.(*centreoncrd.CentreonServiceGroup), ignoreDiff...)
+		if err != nil {
+			return diff, res, err
+		}
+		if csgDiff != nil {
+			diff.AddDiff(patchDiff)
+			objectToUpdate.CentreonServiceGroup = expectedObject.CentreonServiceGroup
+			objectToUpdate.CentreonServiceGroupDiff = csgDiff
+		}
 	}
 
-	if !differ.IsEmpty() {
-		csgDiff := &centreonhandler.CentreonServiceGroupDiff{}
-		if err = json.Unmarshal(differ.Patch, csgDiff); err != nil {
-			return diff, res, errors.Wrap(err, "Error when unmarshall the CentreonServiceGroup patch")
+	// Each other platform are diffed independently
+	for platformRef, expectedMirror := range expectedObject.Mirrors {
+		currentMirror := currentObject.Mirrors[platformRef]
+		if currentMirror == nil {
+			if objectToCreate.Mirrors == nil {
+				objectToCreate.Mirrors = map[string]*CentreonServiceGroup{}
+			}
+			objectToCreate.Mirrors[platformRef] = expectedMirror
+			diff.AddDiff(fmt.Sprintf("Need to create new object %s on platform %s", o.GetName(), platformRef))
+			continue
+		}
+
+		csgDiff, patchDiff, err := h.diffServiceGroup(handler, currentMirror, expectedMirror, originalObject.Mirrors[platformRef], o.(*centreoncrd.CentreonServiceGroup), ignoreDiff...)
+		if err != nil {
+			return diff, res, errors.Wrapf(err, "Error on platform %s", platformRef)
+		}
+		if csgDiff != nil {
+			if objectToUpdate.Mirrors == nil {
+				objectToUpdate.Mirrors = map[string]*CentreonServiceGroup{}
+			}
+			diff.AddDiff(fmt.Sprintf("On platform %s: %s", platformRef, patchDiff))
+			objectToUpdate.Mirrors[platformRef] = &CentreonServiceGroup{
+				CentreonServiceGroup:     expectedMirror.CentreonServiceGroup,
+				CentreonServiceGroupDiff: csgDiff,
+			}
 		}
-		diff.AddDiff(string(differ.Patch))
-		csg := read.GetExpectedObject()
-		csg.CentreonServiceGroupDiff = csgDiff
-		diff.SetObjectToUpdate(csg)
+	}
+
+	// Only report the changes made on Centreon outside of the operator, the changes of resource are always applied
+	if csg.Spec.Policy.IsDriftReportOnly() && isManaged(data) && diff.IsDiff() && !isExpectedChanged(o, csg.Status.ExpectedHash, hash) {
+		csg.Status.Drift = reportDrift(h.Recorder(), o, csg.Status.Drift, h.name, diff.Diff(), logger)
+		return controller.NewBasicRemoteDiff[*CentreonServiceGroup](), res, nil
+	}
+	csg.Status.Drift = nil
+	resetDrift(o, h.name)
+
+	if !objectToCreate.isEmpty() {
+		diff.SetObjectToCreate(objectToCreate)
+	}
+	if !objectToUpdate.isEmpty() {
+		diff.SetObjectToUpdate(objectToUpdate)
 	}
 
 	return diff, res, nil
 }
+
+// diffServiceGroup permit to compute the diff of service group on one platform
+// It return nil if there are no diff
+func (h *centreonServiceGroupReconciler) diffServiceGroup(handler controller.RemoteExternalReconciler[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler], currentObject, expectedObject, originalObject *CentreonServiceGroup, o *centreoncrd.CentreonServiceGroup, ignoreDiff ...patch.CalculateOption) (csgDiff *centreonhandler.CentreonServiceGroupDiff, patchDiff string, err error) {
+	differ, err := handler.Diff(currentObject, expectedObject, originalObject, o, ignoreDiff...)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "Error when diffing %s for remote target", o.GetName())
+	}
+
+	if differ.IsEmpty() {
+		return nil, "", nil
+	}
+
+	csgDiff = &centreonhandler.CentreonServiceGroupDiff{}
+	if err = json.Unmarshal(differ.Patch, csgDiff); err != nil {
+		return nil, "", errors.Wrap(err, "Error when unmarshall the CentreonServiceGroup patch")
+	}
+
+	return csgDiff, string(differ.Patch), nil
+}
@@ -0,0 +1,76 @@
+package centreon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/monitoring-operator/api/shared"
+	"github.com/disaster37/monitoring-operator/internal/controller/common"
+	"github.com/disaster37/operator-sdk-extra/pkg/object"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// expectedHashKey is the data key to keep the hash of expected resource until it reconciled successfully
+	expectedHashKey string = "expectedHash"
+
+	// resyncJitter is the max factor added to resync interval, to not reconcile all resources at the same time
+	resyncJitter float64 = 0.1
+)
+
+// resyncAfter return the duration before the next periodic reconcile
+// It return 0 if the periodic resync is disabled
+func resyncAfter(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+
+	return wait.Jitter(interval, resyncJitter)
+}
+
+// hashExpected return the hash of expected resource on Centreon
+// The hash is written on status, so the expected resource must not contain secret values
+func hashExpected(expected any) (hash string, err error) {
+	b, err := json.Marshal(expected)
+	if err != nil {
+		return "", errors.Wrap(err, "Error when marshall the expected resource")
+	}
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isExpectedChanged return true if the expected resource change since the last reconcile, like when the spec, the macros or the platform defaults are updated
+func isExpectedChanged(o object.RemoteObject, expectedHash string, hash string) bool {
+	return o.GetGeneration() != o.GetStatus().GetObservedGeneration() || expectedHash != hash
+}
+
+// reportDrift permit to record the changes made on Centreon outside of the operator, instead of revert them
+// The event is only sent when the drift is new or when it change
+func reportDrift(recorder record.EventRecorder, o object.RemoteObject, drift *shared.DriftStatus, controllerName string, diff string, logger *logrus.Entry) *shared.DriftStatus {
+	if drift == nil || drift.Diff != diff {
+		recorder.Eventf(o, corev1.EventTypeWarning, "DriftDetected", "Resource is changed on Centreon outside of the operator: %s", diff)
+		logger.Warnf("Resource is changed on Centreon outside of the operator: %s", diff)
+	}
+	if drift == nil {
+		drift = &shared.DriftStatus{
+			DetectedAt: metav1.Now(),
+		}
+	}
+	drift.Diff = diff
+	common.ControllerDrift.WithLabelValues(controllerName, o.GetNamespace(), o.GetName()).Set(1)
+
+	return drift
+}
+
+// resetDrift permit to reset the drift metric when the resource is in sync with Centreon
+func resetDrift(o object.RemoteObject, controllerName string) {
+	common.ControllerDrift.WithLabelValues(controllerName, o.GetNamespace(), o.GetName()).Set(0)
+}
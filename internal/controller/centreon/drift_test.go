@@ -0,0 +1,241 @@
+package centreon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/disaster37/monitoring-operator/api/shared"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResyncAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), resyncAfter(0))
+
+	after := resyncAfter(10 * time.Minute)
+	assert.GreaterOrEqual(t, after, 10*time.Minute)
+	assert.LessOrEqual(t, after, 11*time.Minute)
+}
+
+func TestHashExpectedService(t *testing.T) {
+	expected := func(password string) *CentreonService {
+		return &CentreonService{CentreonService: &centreonhandler.CentreonService{
+			Host:   "host1",
+			Name:   "s1",
+			Macros: []*models.Macro{{Name: "TOKEN", Value: password, IsPassword: "1"}},
+		}}
+	}
+	macros := resolvedMacros{passwordSources: map[string]string{"TOKEN": "secret1/uid1/1/token"}}
+
+	// The hash not depend of the password macros values
+	hash, err := hashExpectedService(expected("secret1"), "default", macros)
+	assert.NoError(t, err)
+	hash2, err := hashExpectedService(expected("secret2"), "default", macros)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, hash2)
+
+	// The hash change when the secret change
+	macros.passwordSources["TOKEN"] = "secret1/uid1/2/token"
+	cs := expected("secret2")
+	hash2, err = hashExpectedService(cs, "default", macros)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, hash2)
+
+	// The expected service is not modified
+	assert.Equal(t, "secret2", cs.Macros[0].Value)
+}
+
+func TestIsExpectedChanged(t *testing.T) {
+	hash, err := hashExpected(&centreonhandler.CentreonService{Host: "host1", Name: "s1"})
+	assert.NoError(t, err)
+	hash2, err := hashExpected(&centreonhandler.CentreonService{Host: "host1", Name: "s2"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, hash2)
+
+	o := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 2,
+		},
+	}
+	o.Status.ObservedGeneration = 2
+
+	assert.False(t, isExpectedChanged(o, hash, hash))
+	assert.True(t, isExpectedChanged(o, hash, hash2))
+
+	// When spec change
+	o.Generation = 3
+	assert.True(t, isExpectedChanged(o, hash, hash))
+}
+
+func TestReportDrift(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	logger := logrus.NewEntry(logrus.New())
+	o := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "s1",
+			Namespace: "default",
+		},
+	}
+
+	// New drift
+	drift := reportDrift(recorder, o, nil, "test", "diff1", logger)
+	assert.NotNil(t, drift)
+	assert.Equal(t, "diff1", drift.Diff)
+	assert.False(t, drift.DetectedAt.IsZero())
+	assert.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// Same drift
+	detectedAt := drift.DetectedAt
+	drift = reportDrift(recorder, o, drift, "test", "diff1", logger)
+	assert.Equal(t, detectedAt, drift.DetectedAt)
+	assert.Len(t, recorder.Events, 0)
+
+	// Drift change
+	drift = reportDrift(recorder, o, drift, "test", "diff2", logger)
+	assert.Equal(t, detectedAt, drift.DetectedAt)
+	assert.Equal(t, "diff2", drift.Diff)
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestCentreonServiceDiffReportOnly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	logger := logrus.NewEntry(logrus.New())
+	recorder := record.NewFakeRecorder(10)
+	reconciler := newCentreonServiceReconciler("test", fake.NewClientBuilder().Build(), recorder, nil, "", 0).(*centreonServiceReconciler)
+	handler := newCentreonServiceApiClient(mockCentreon, nil, resolvedMacros{}, nil, logger)
+
+	o := &centreoncrd.CentreonService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "s1",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			Host:     "host1",
+			Name:     "s1",
+			Template: "template1",
+			Policy: shared.Policy{
+				Drift: shared.DriftModeReportOnly,
+			},
+		},
+	}
+	o.Status.ServiceName = "s1"
+	o.Status.Host = "host1"
+	o.Status.ObservedGeneration = 1
+
+	expectedObject, err := handler.Build(o)
+	assert.NoError(t, err)
+	hash, err := hashExpectedService(expectedObject, o.GetPlatform(), resolvedMacros{})
+	assert.NoError(t, err)
+	read := controller.NewBasicRemoteRead[*CentreonService]()
+	read.SetCurrentObject(&CentreonService{CentreonService: &centreonhandler.CentreonService{Host: "host1", Name: "s1", Template: "template2"}})
+	read.SetExpectedObject(expectedObject)
+	mockCentreon.EXPECT().DiffService(gomock.Any(), gomock.Any(), gomock.Any()).Return(&centreonhandler.CentreonServiceDiff{
+		Host:        "host1",
+		Name:        "s1",
+		IsDiff:      true,
+		ParamsToSet: map[string]string{"template": "template1"},
+	}, nil).AnyTimes()
+
+	// When the service is changed on Centreon, the drift is only reported
+	o.Status.ExpectedHash = hash
+	data := map[string]any{isManagedKey: true}
+	diff, _, err := reconciler.Diff(context.Background(), o, read, data, handler, logger)
+	assert.NoError(t, err)
+	assert.False(t, diff.NeedUpdate())
+	assert.False(t, diff.NeedCreate())
+	assert.NotNil(t, o.Status.Drift)
+	assert.Contains(t, o.Status.Drift.Diff, "template1")
+	assert.Equal(t, hash, data[expectedHashKey])
+
+	// When the resource is changed, the diff is applied
+	o.Generation = 2
+	diff, _, err = reconciler.Diff(context.Background(), o, read, data, handler, logger)
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Nil(t, o.Status.Drift)
+
+	// When drift is enforced, the diff is applied
+	o.Generation = 1
+	o.Spec.Policy.Drift = shared.DriftModeEnforce
+	diff, _, err = reconciler.Diff(context.Background(), o, read, data, handler, logger)
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Nil(t, o.Status.Drift)
+}
+
+func TestCentreonServiceDependencyDiffReportOnly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	logger := logrus.NewEntry(logrus.New())
+	recorder := record.NewFakeRecorder(10)
+	reconciler := newCentreonServiceDependencyReconciler("test", fake.NewClientBuilder().Build(), recorder, nil, 0).(*centreonServiceDependencyReconciler)
+	handler := newCentreonServiceDependencyApiClient(mockCentreon, []string{"host1,service1"}, []string{"host2,service1"}, logger)
+
+	o := &centreoncrd.CentreonServiceDependency{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "dep1",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: centreoncrd.CentreonServiceDependencySpec{
+			Services:          []centreoncrd.CentreonServiceRef{{Name: "s1"}},
+			DependentServices: []centreoncrd.CentreonServiceRef{{Name: "s2"}},
+			Policy: shared.Policy{
+				Drift: shared.DriftModeReportOnly,
+			},
+		},
+	}
+	o.Status.DependencyName = "dep1"
+	o.Status.ObservedGeneration = 1
+
+	expectedObject, err := handler.Build(o)
+	assert.NoError(t, err)
+	hash, err := hashExpected(expectedObject.CentreonServiceDependency)
+	assert.NoError(t, err)
+	read := controller.NewBasicRemoteRead[*CentreonServiceDependency]()
+	read.SetCurrentObject(&CentreonServiceDependency{CentreonServiceDependency: &centreonhandler.CentreonServiceDependency{Name: "dep1", ParentServices: []string{"host1,service2"}}})
+	read.SetExpectedObject(expectedObject)
+	mockCentreon.EXPECT().DiffServiceDependency(gomock.Any(), gomock.Any(), gomock.Any()).Return(&centreonhandler.CentreonServiceDependencyDiff{
+		Name:                   "dep1",
+		IsDiff:                 true,
+		ParentServicesToSet:    []string{"host1,service1"},
+		ParentServicesToDelete: []string{"host1,service2"},
+	}, nil).AnyTimes()
+
+	// When the dependency is changed on Centreon, the drift is only reported
+	o.Status.ExpectedHash = hash
+	data := map[string]any{isManagedKey: true}
+	diff, _, err := reconciler.Diff(context.Background(), o, read, data, handler, logger)
+	assert.NoError(t, err)
+	assert.False(t, diff.NeedUpdate())
+	assert.NotNil(t, o.Status.Drift)
+	assert.Contains(t, o.Status.Drift.Diff, "host1,service2")
+
+	// When drift is enforced, the diff is applied
+	o.Spec.Policy.Drift = shared.DriftModeEnforce
+	diff, _, err = reconciler.Diff(context.Background(), o, read, data, handler, logger)
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, []string{"host1,service1"}, diff.GetObjectToUpdate().CentreonServiceDependencyDiff.ParentServicesToSet)
+	assert.Nil(t, o.Status.Drift)
+
+	// When the dependency is not managed, it need to be adopted
+	data = map[string]any{isManagedKey: false}
+	_, _, err = reconciler.Diff(context.Background(), o, read, data, handler, logger)
+	assert.ErrorIs(t, err, errNeedAdoption)
+}
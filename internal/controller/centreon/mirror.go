@@ -0,0 +1,192 @@
+package centreon
+
+import (
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// platformMirror permit to handle the resource on one of the other target platforms
+// C is the api client of resource
+type platformMirror[C any] struct {
+	platform string
+
+	// client is nil when the platform can't be loaded
+	client *C
+
+	// err is set when the resource can't be handled on platform during the current reconcile
+	err error
+
+	// removed is true when the platform is not anymore a target of resource
+	removed bool
+}
+
+// platformMirrors is the other target platforms of resource
+type platformMirrors[C any] []*platformMirror[C]
+
+// active return the other target platforms where resource can be handled
+func (ms platformMirrors[C]) active() []*platformMirror[C] {
+	mirrors := make([]*platformMirror[C], 0, len(ms))
+	for _, m := range ms {
+		if !m.removed && m.err == nil {
+			mirrors = append(mirrors, m)
+		}
+	}
+
+	return mirrors
+}
+
+// get return the active mirror of platform
+func (ms platformMirrors[C]) get(platform string) *platformMirror[C] {
+	for _, m := range ms.active() {
+		if m.platform == platform {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// errors return the errors when handle resource on the other target platforms
+func (ms platformMirrors[C]) errors() (errs []error) {
+	errs = make([]error, 0)
+	for _, m := range ms {
+		if !m.removed && m.err != nil {
+			errs = append(errs, m.err)
+		}
+	}
+
+	return errs
+}
+
+// readMirrors permit to read the resource on each target platform and to set its status
+// It return the resources found by platform. A platform on error not block the others
+// The identify function return the identity to record on status, or empty name when the resource is not handled on platform
+func readMirrors[C any, T any](ms platformMirrors[C], statuses *[]centreoncrd.PlatformRefStatus, kind string, read func(m *platformMirror[C]) (*T, error), identify func(*T) (host, name string)) (objects map[string]*T) {
+	objects = map[string]*T{}
+	for _, m := range ms {
+		if m.removed {
+			continue
+		}
+		if m.err != nil {
+			m.setStatus(statuses, m.err)
+			continue
+		}
+
+		object, err := read(m)
+		if err != nil {
+			m.err = errors.Wrapf(err, "Error when get %s on platform %s", kind, m.platform)
+			m.setStatus(statuses, m.err)
+			continue
+		}
+		if object == nil {
+			m.setStatus(statuses, nil)
+			continue
+		}
+		objects[m.platform] = object
+		// The identity is not recorded when the resource is not yet handled on platform
+		if host, name := identify(object); name != "" {
+			m.setSyncStatus(statuses, host, name)
+		} else {
+			m.setStatus(statuses, nil)
+		}
+	}
+
+	return objects
+}
+
+// applyMirrors permit to create or update the resource on each target platform and to set its status
+// The action is the operation name used on errors, like `create service`
+func applyMirrors[C any, T any](ms platformMirrors[C], statuses *[]centreoncrd.PlatformRefStatus, action string, objects map[string]T, apply func(m *platformMirror[C], object T) error, identify func(T) (host, name string)) {
+	for platform, object := range objects {
+		m := ms.get(platform)
+		if m == nil {
+			continue
+		}
+		if err := apply(m, object); err != nil {
+			m.err = errors.Wrapf(err, "Error when %s on platform %s", action, platform)
+			m.setStatus(statuses, m.err)
+			continue
+		}
+		host, name := identify(object)
+		m.setSyncStatus(statuses, host, name)
+	}
+}
+
+// deleteMirrors permit to delete the resource on each platform, even when it is not anymore a target
+// A platform that can't be loaded is on error, so the resource is kept until it is deleted everywhere
+func deleteMirrors[C any](ms platformMirrors[C], kind string, remove func(m *platformMirror[C]) error) (errs []error) {
+	errs = make([]error, 0)
+	for _, m := range ms {
+		if err := m.delete(kind, remove); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// deleteRemovedMirrors permit to delete the resource on platforms that are not anymore a target
+// The platform is removed from status when the resource is deleted on it
+func deleteRemovedMirrors[C any](ms platformMirrors[C], statuses *[]centreoncrd.PlatformRefStatus, kind string, noDelete bool, logger *logrus.Entry, remove func(m *platformMirror[C]) error) (err error) {
+	errs := make([]error, 0)
+	for _, m := range ms {
+		if !m.removed {
+			continue
+		}
+		if noDelete {
+			logger.Infof("Skip delete %s on platform %s (policy NoDelete)", kind, m.platform)
+		} else if err = m.delete(kind, remove); err != nil {
+			errs = append(errs, err)
+			m.setStatus(statuses, err)
+			continue
+		}
+		centreoncrd.RemovePlatformRefStatus(statuses, m.platform)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// delete permit to delete the resource on platform
+func (m *platformMirror[C]) delete(kind string, remove func(m *platformMirror[C]) error) (err error) {
+	if m.client == nil {
+		if m.err != nil {
+			return errors.Wrapf(m.err, "Error when delete %s on platform %s", kind, m.platform)
+		}
+		return errors.Errorf("Error when delete %s on platform %s: platform is not loaded", kind, m.platform)
+	}
+
+	if err = remove(m); err != nil {
+		return errors.Wrapf(err, "Error when delete %s on platform %s", kind, m.platform)
+	}
+
+	return nil
+}
+
+// setStatus permit to set the resource status on platform
+// It keep the current identity of resource on platform
+func (m *platformMirror[C]) setStatus(statuses *[]centreoncrd.PlatformRefStatus, err error) {
+	status := centreoncrd.PlatformRefStatus{
+		Name: m.platform,
+	}
+	if current := centreoncrd.GetPlatformRefStatus(*statuses, m.platform); current != nil {
+		status.Host = current.Host
+		status.ExternalName = current.ExternalName
+	}
+	if err != nil {
+		status.LastErrorMessage = err.Error()
+	}
+
+	centreoncrd.SetPlatformRefStatus(statuses, status)
+}
+
+// setSyncStatus permit to set the resource status on platform when it is up to date
+func (m *platformMirror[C]) setSyncStatus(statuses *[]centreoncrd.PlatformRefStatus, host, name string) {
+	centreoncrd.SetPlatformRefStatus(statuses, centreoncrd.PlatformRefStatus{
+		Name:         m.platform,
+		Host:         host,
+		ExternalName: name,
+		IsSync:       true,
+	})
+}
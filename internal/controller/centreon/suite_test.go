@@ -46,7 +46,7 @@ type CentreonControllerTestSuite struct {
 	mockCentreonHandler *mocks.MockCentreonHandler
 	mockCtrl            *gomock.Controller
 	cfg                 *rest.Config
-	platforms           map[string]*platform.ComputedPlatform
+	platforms           *platform.PlatformRegistry
 }
 
 func TestCentreonControllerSuite(t *testing.T) {
@@ -123,6 +123,8 @@ func (t *CentreonControllerTestSuite) SetupSuite() {
 		centreoncrd.SetupPlatformIndexer,
 		centreoncrd.SetupCentreonServiceIndexer,
 		centreoncrd.SetupCentreonServiceGroupIndexer,
+		centreoncrd.SetupCentreonServiceDependencyIndexer,
+		centreoncrd.SetupCentreonEscalationIndexer,
 		centreoncrd.SetupCertificateIndexer,
 		centreoncrd.SetupIngressIndexer,
 		centreoncrd.SetupNamespaceIndexer,
@@ -160,18 +162,20 @@ func (t *CentreonControllerTestSuite) SetupSuite() {
 			Client: t.mockCentreonHandler,
 		},
 	}
-	t.platforms = platforms
+	t.platforms = platform.NewPlatformRegistry(platforms)
 
 	centreonServiceReconsiler := NewCentreonServiceReconciler(
 		k8sClient,
 		logrus.NewEntry(logrus.StandardLogger()),
 		k8sManager.GetEventRecorderFor("centreonservice-controller"),
 		t.platforms,
+		"",
+		0,
 	)
 	centreonServiceReconsiler.(*CentreonServiceReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler](
 		centreonServiceReconsiler.(*CentreonServiceReconciler).RemoteReconcilerAction,
 		func(ctx context.Context, req reconcile.Request, o object.RemoteObject, logger *logrus.Entry) (handler controller.RemoteExternalReconciler[*centreoncrd.CentreonService, *CentreonService, centreonhandler.CentreonHandler], res reconcile.Result, err error) {
-			return newCentreonServiceApiClient(t.mockCentreonHandler, logger), res, nil
+			return newCentreonServiceApiClient(t.mockCentreonHandler, nil, resolvedMacros{}, nil, logger), res, nil
 		},
 	)
 	if err = centreonServiceReconsiler.SetupWithManager(k8sManager); err != nil {
@@ -183,6 +187,7 @@ func (t *CentreonControllerTestSuite) SetupSuite() {
 		logrus.NewEntry(logrus.StandardLogger()),
 		k8sManager.GetEventRecorderFor("centreonservicegroup-controller"),
 		t.platforms,
+		0,
 	)
 	centreonServiceGroupReconsiler.(*CentreonServiceGroupReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*centreoncrd.CentreonServiceGroup, *CentreonServiceGroup, centreonhandler.CentreonHandler](
 		centreonServiceGroupReconsiler.(*CentreonServiceGroupReconciler).RemoteReconcilerAction,
@@ -2,8 +2,7 @@ package certificate
 
 import (
 	"context"
-	"crypto/x509"
-	"encoding/pem"
+	"time"
 
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/internal/controller/template"
@@ -23,6 +22,9 @@ import (
 
 const (
 	name string = "certificate"
+
+	// requeueAfterKey is the data key to keep the duration before reconcile the certificate again
+	requeueAfterKey string = "requeueAfter"
 )
 
 // CertificateReconciler reconciles a Secret (Certificate type)
@@ -97,29 +99,35 @@ func (r *CertificateReconciler) Read(ctx context.Context, o client.Object, data
 	placeholders := map[string]any{}
 
 	// Read certificates
-	var (
-		blocks []byte
-		rest   []byte
-		block  *pem.Block
-	)
-	rest = s.Data["tls.crt"]
-	for {
-		block, rest = pem.Decode(rest)
-		if block == nil {
-			break
-		}
-		blocks = append(blocks, block.Bytes...)
-		if len(rest) == 0 {
-			break
-		}
+	certs, err := decodeCertificates(s.Data["tls.crt"])
+	if err != nil {
+		logger.Errorf("Error when read TLS certificate: %s", err.Error())
+		r.SentinelReconcilerAction.Recorder().Eventf(s, corev1.EventTypeWarning, "ParseCertificateFailed", "Error when read TLS certificate: %s", err.Error())
 	}
 
-	if len(blocks) > 0 {
-		certs, err := x509.ParseCertificates(blocks)
-		if err != nil {
-			logger.Errorf("Error when read TLS certificate: %s", err.Error())
-		}
+	if len(certs) > 0 {
+		now := time.Now()
 		placeholders["certificates"] = certs
+
+		chain := make([]map[string]any, 0, len(certs))
+		for i, cert := range certs {
+			chain = append(chain, getCertificatePlaceholders(cert, i, now))
+		}
+		placeholders["chain"] = chain
+		placeholders["leaf"] = chain[0]
+		placeholders["daysRemaining"] = chain[0]["daysRemaining"]
+
+		// The days remaining and expired fields change with time, not only when the secret change
+		data[requeueAfterKey] = requeueAfter(certs, now)
+
+		// Check the chain against the CA provided on secret
+		if err := verifyChain(certs, s.Data["ca.crt"], now); err != nil {
+			placeholders["chainVerified"] = false
+			placeholders["chainError"] = err.Error()
+		} else {
+			placeholders["chainVerified"] = true
+			placeholders["chainError"] = ""
+		}
 	}
 
 	data["placeholders"] = placeholders
@@ -127,6 +135,19 @@ func (r *CertificateReconciler) Read(ctx context.Context, o client.Object, data
 	return r.SentinelReconcilerAction.Read(ctx, o, data, logger)
 }
 
+func (r *CertificateReconciler) OnSuccess(ctx context.Context, o client.Object, data map[string]any, diff controller.SentinelDiff, logger *logrus.Entry) (res ctrl.Result, err error) {
+	if res, err = r.SentinelReconcilerAction.OnSuccess(ctx, o, data, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Reconcile again to refresh the placeholders that depend of the current date
+	if after, ok := data[requeueAfterKey].(time.Duration); ok && !res.Requeue && (res.RequeueAfter == 0 || after < res.RequeueAfter) {
+		res.RequeueAfter = after
+	}
+
+	return res, nil
+}
+
 func viewCertificate() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
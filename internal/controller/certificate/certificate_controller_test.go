@@ -358,6 +358,35 @@ spec:
 			}
 			logrus.Infof("Create template template-certificate2")
 
+			template = &centreoncrd.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template-certificate3",
+					Namespace: "default",
+				},
+				Spec: centreoncrd.TemplateSpec{
+					Template: `
+apiVersion: monitor.k8s.webcenter.fr/v1
+kind: CentreonService
+spec:
+  host: "localhost"
+  name: "ping3"
+  template: "template3"
+  macros:
+    cn: "{{ .leaf.subjectCN }}"
+    issuer: "{{ .leaf.issuerCN }}"
+    sans: "{{ join \",\" .leaf.dnsNames }}"
+    key: "{{ .leaf.keyAlgorithm }}-{{ .leaf.keySize }}"
+    expired: "{{ lt .daysRemaining 0 }}"
+    chainVerified: "{{ .chainVerified }}"
+    certificates: "{{ len .chain }}"
+  activate: true`,
+				},
+			}
+			if err := c.Create(context.Background(), template); err != nil {
+				return err
+			}
+			logrus.Infof("Create template template-certificate3")
+
 			return nil
 		},
 		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
@@ -372,7 +401,7 @@ spec:
 						"env": "dev",
 					},
 					Annotations: map[string]string{
-						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-certificate2\"}]",
+						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-certificate2\"}, {\"namespace\":\"default\", \"name\": \"template-certificate3\"}]",
 					},
 				},
 				Type: corev1.SecretTypeTLS,
@@ -460,6 +489,36 @@ t92lHyOldNtNHZtcJSRqLQNZo7MixgHDJ/jLAio=
 			assert.Equal(t, expectedCSSpec, cs.Spec)
 			assert.NotEmpty(t, cs.OwnerReferences)
 
+			// Get service generated by template-certificate3
+			isTimeout, err = test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: "template-certificate3"}, cs); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return errors.New("Not yet created")
+					}
+					t.Fatalf("Error when get Centreon service template-certificate3: %s", err.Error())
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Centreon service template-certificate3: %s", err.Error())
+			}
+			expectedCSSpec = centreoncrd.CentreonServiceSpec{
+				Host:     "localhost",
+				Name:     "ping3",
+				Template: "template3",
+				Macros: map[string]string{
+					"cn":            "rancher-prd",
+					"issuer":        "PKI SIHM OPE",
+					"sans":          "rancher-prd,rancher-prd.hm.dm.ad,*.rancher-prd.hm.dm.ad",
+					"key":           "RSA-2048",
+					"expired":       "true",
+					"chainVerified": "false",
+					"certificates":  "1",
+				},
+				Activated: true,
+			}
+			assert.Equal(t, expectedCSSpec, cs.Spec)
+
 			return nil
 		},
 	}
@@ -0,0 +1,156 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"math"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+const (
+	certificateTypeLeaf         string = "leaf"
+	certificateTypeIntermediate string = "intermediate"
+	certificateTypeRoot         string = "root"
+
+	// maxRequeueAfter is the max duration before reconcile the certificate again, to refresh the days remaining
+	maxRequeueAfter = 24 * time.Hour
+)
+
+// decodeCertificates permit to decode PEM blocks and parse the certificates
+func decodeCertificates(data []byte) (certs []*x509.Certificate, err error) {
+	var (
+		blocks []byte
+		block  *pem.Block
+	)
+	rest := data
+	for {
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block.Bytes...)
+		if len(rest) == 0 {
+			break
+		}
+	}
+
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	return x509.ParseCertificates(blocks)
+}
+
+// getCertificatePlaceholders permit to compute the usefull fields of certificate
+// The first certificate is the leaf, like expected on kubernetes TLS secret
+func getCertificatePlaceholders(cert *x509.Certificate, index int, now time.Time) map[string]any {
+	certType := certificateTypeIntermediate
+	if index == 0 && !cert.IsCA {
+		certType = certificateTypeLeaf
+	} else if isSelfSigned(cert) {
+		certType = certificateTypeRoot
+	}
+
+	ipAddresses := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+
+	return map[string]any{
+		"type":          certType,
+		"isLeaf":        certType == certificateTypeLeaf,
+		"isCA":          cert.IsCA,
+		"subjectCN":     cert.Subject.CommonName,
+		"subject":       cert.Subject.String(),
+		"issuerCN":      cert.Issuer.CommonName,
+		"issuer":        cert.Issuer.String(),
+		"serialNumber":  cert.SerialNumber.String(),
+		"dnsNames":      append([]string{}, cert.DNSNames...),
+		"ipAddresses":   ipAddresses,
+		"notBefore":     cert.NotBefore.UTC().Format(time.RFC3339),
+		"notAfter":      cert.NotAfter.UTC().Format(time.RFC3339),
+		"daysRemaining": daysRemaining(cert.NotAfter, now),
+		"expired":       now.After(cert.NotAfter),
+		"keyAlgorithm":  cert.PublicKeyAlgorithm.String(),
+		"keySize":       keySize(cert),
+	}
+}
+
+// verifyChain permit to check that the leaf certificate is trusted by the CA bundle
+// The other certificates provided on tls.crt are used as intermediates
+func verifyChain(certs []*x509.Certificate, caData []byte, now time.Time) error {
+	if len(certs) == 0 {
+		return errors.New("No certificate to verify")
+	}
+	if len(bytes.TrimSpace(caData)) == 0 {
+		return errors.New("No CA found on ca.crt")
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caData) {
+		return errors.New("No valid certificate found on ca.crt")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return errors.Wrap(err, "Error when verify certificate chain")
+	}
+
+	return nil
+}
+
+// daysRemaining permit to compute the number of full days before the date
+// It's negative when the date is already expired
+func daysRemaining(notAfter time.Time, now time.Time) int {
+	return int(math.Floor(notAfter.Sub(now).Hours() / 24))
+}
+
+// requeueAfter permit to compute the duration before reconcile the certificate again
+// It's the min between one day, to refresh the days remaining, and the time until the first certificate expire
+func requeueAfter(certs []*x509.Certificate, now time.Time) time.Duration {
+	after := maxRequeueAfter
+	for _, cert := range certs {
+		if until := cert.NotAfter.Sub(now); until > 0 && until < after {
+			// Add one second to be sure the certificate is expired when it reconciled
+			after = until + time.Second
+		}
+	}
+
+	return after
+}
+
+// keySize permit to get the size in bits of the public key
+func keySize(cert *x509.Certificate) int {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return len(key) * 8
+	default:
+		return 0
+	}
+}
+
+// isSelfSigned permit to know if the certificate is signed by itself
+func isSelfSigned(cert *x509.Certificate) bool {
+	if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false
+	}
+	return cert.CheckSignatureFrom(cert) == nil
+}
@@ -0,0 +1,269 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCertificate permit to generate certificate signed by parent, or self signed when parent is nil
+func newTestCertificate(t *testing.T, cn string, isCA bool, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer, notAfter time.Time) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if parent == nil {
+		parent = template
+		parentKey = key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func toPEM(certs ...*x509.Certificate) []byte {
+	data := make([]byte, 0)
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+
+	return data
+}
+
+func newTestKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestDecodeCertificates(t *testing.T) {
+	key := newTestKey(t)
+	notAfter := time.Now().Add(24 * time.Hour)
+	root := newTestCertificate(t, "root", true, key, nil, nil, notAfter)
+	leaf := newTestCertificate(t, "leaf", false, key, root, key, notAfter)
+
+	tests := []struct {
+		Name        string
+		Data        []byte
+		ExpectedCNs []string
+		IsError     bool
+	}{
+		{
+			Name:        "One certificate",
+			Data:        toPEM(leaf),
+			ExpectedCNs: []string{"leaf"},
+		},
+		{
+			Name:        "Certificate chain",
+			Data:        toPEM(leaf, root),
+			ExpectedCNs: []string{"leaf", "root"},
+		},
+		{
+			Name: "No PEM block",
+			Data: []byte("not a certificate"),
+		},
+		{
+			Name: "Empty data",
+			Data: nil,
+		},
+		{
+			Name:    "Malformed certificate on PEM block",
+			Data:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("malformed")}),
+			IsError: true,
+		},
+	}
+
+	for _, test := range tests {
+		certs, err := decodeCertificates(test.Data)
+		if test.IsError {
+			assert.Error(t, err, test.Name)
+			continue
+		}
+		assert.NoError(t, err, test.Name)
+		cns := make([]string, 0, len(certs))
+		for _, cert := range certs {
+			cns = append(cns, cert.Subject.CommonName)
+		}
+		if test.ExpectedCNs == nil {
+			assert.Empty(t, cns, test.Name)
+		} else {
+			assert.Equal(t, test.ExpectedCNs, cns, test.Name)
+		}
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	now := time.Now()
+	rootKey := newTestKey(t)
+	intermediateKey := newTestKey(t)
+	otherKey := newTestKey(t)
+	root := newTestCertificate(t, "root", true, rootKey, nil, nil, now.Add(48*time.Hour))
+	intermediate := newTestCertificate(t, "intermediate", true, intermediateKey, root, rootKey, now.Add(48*time.Hour))
+	leaf := newTestCertificate(t, "leaf", false, newTestKey(t), intermediate, intermediateKey, now.Add(24*time.Hour))
+	expiredLeaf := newTestCertificate(t, "leaf", false, newTestKey(t), intermediate, intermediateKey, now.Add(-time.Hour))
+	otherRoot := newTestCertificate(t, "other", true, otherKey, nil, nil, now.Add(48*time.Hour))
+
+	tests := []struct {
+		Name    string
+		Certs   []*x509.Certificate
+		CA      []byte
+		IsError bool
+	}{
+		{
+			Name:  "Valid chain",
+			Certs: []*x509.Certificate{leaf, intermediate},
+			CA:    toPEM(root),
+		},
+		{
+			Name:    "Expired certificate",
+			Certs:   []*x509.Certificate{expiredLeaf, intermediate},
+			CA:      toPEM(root),
+			IsError: true,
+		},
+		{
+			Name:    "Broken chain without intermediate",
+			Certs:   []*x509.Certificate{leaf},
+			CA:      toPEM(root),
+			IsError: true,
+		},
+		{
+			Name:    "Signed by other CA",
+			Certs:   []*x509.Certificate{leaf, intermediate},
+			CA:      toPEM(otherRoot),
+			IsError: true,
+		},
+		{
+			Name:    "No CA",
+			Certs:   []*x509.Certificate{leaf, intermediate},
+			IsError: true,
+		},
+		{
+			Name:    "Malformed CA",
+			Certs:   []*x509.Certificate{leaf, intermediate},
+			CA:      []byte("malformed"),
+			IsError: true,
+		},
+		{
+			Name:    "No certificate",
+			CA:      toPEM(root),
+			IsError: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := verifyChain(test.Certs, test.CA, now)
+		if test.IsError {
+			assert.Error(t, err, test.Name)
+		} else {
+			assert.NoError(t, err, test.Name)
+		}
+	}
+}
+
+func TestKeySize(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		Name         string
+		Key          crypto.Signer
+		ExpectedSize int
+	}{
+		{
+			Name:         "RSA 2048",
+			Key:          rsaKey,
+			ExpectedSize: 2048,
+		},
+		{
+			Name:         "ECDSA P-256",
+			Key:          newTestKey(t),
+			ExpectedSize: 256,
+		},
+		{
+			Name:         "ECDSA P-384",
+			Key:          p384Key,
+			ExpectedSize: 384,
+		},
+		{
+			Name:         "Ed25519",
+			Key:          ed25519Key,
+			ExpectedSize: 256,
+		},
+	}
+
+	for _, test := range tests {
+		cert := newTestCertificate(t, "test", false, test.Key, nil, nil, time.Now().Add(time.Hour))
+		assert.Equal(t, test.ExpectedSize, keySize(cert), test.Name)
+	}
+
+	// When key is unknown
+	assert.Equal(t, 0, keySize(&x509.Certificate{}))
+}
+
+func TestRequeueAfter(t *testing.T) {
+	now := time.Now()
+	key := newTestKey(t)
+
+	tests := []struct {
+		Name     string
+		NotAfter []time.Time
+		Expected time.Duration
+	}{
+		{
+			Name:     "Certificate expire after one day",
+			NotAfter: []time.Time{now.Add(72 * time.Hour)},
+			Expected: maxRequeueAfter,
+		},
+		{
+			Name:     "Certificate expire before one day",
+			NotAfter: []time.Time{now.Add(72 * time.Hour), now.Add(2 * time.Hour)},
+			Expected: 2*time.Hour + time.Second,
+		},
+		{
+			Name:     "Certificate already expired",
+			NotAfter: []time.Time{now.Add(-time.Hour)},
+			Expected: maxRequeueAfter,
+		},
+	}
+
+	for _, test := range tests {
+		certs := make([]*x509.Certificate, 0, len(test.NotAfter))
+		for _, notAfter := range test.NotAfter {
+			certs = append(certs, newTestCertificate(t, "test", false, key, nil, nil, notAfter))
+		}
+		// The certificate dates are truncated to the second
+		assert.InDelta(t, test.Expected, requeueAfter(certs, now), float64(time.Second), test.Name)
+	}
+}
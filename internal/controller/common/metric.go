@@ -20,9 +20,37 @@ var (
 		Name: "monitoring_operator_instances_controller",
 		Help: "Number of instance per controllers",
 	}, []string{"controller", "namespace", "name"})
+	ControllerDrift = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "monitoring_operator_drift_controller",
+		Help: "Is the resource changed on Centreon outside of the operator and not reverted (1) or not (0)",
+	}, []string{"controller", "namespace", "name"})
+	PlatformUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "monitoring_operator_platform_up",
+		Help: "Is the platform API reachable and authenticated (1) or not (0)",
+	}, []string{"namespace", "name"})
+	PlatformLatency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "monitoring_operator_platform_latency_seconds",
+		Help: "Latency of the platform API during the last health check",
+	}, []string{"namespace", "name"})
+	PlatformActiveEndpoint = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "monitoring_operator_platform_active_endpoint",
+		Help: "Is the endpoint the one currently used by platform (1) or not (0)",
+	}, []string{"namespace", "name", "url"})
+	PlatformThrottledRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "monitoring_operator_platform_throttled_requests_total",
+		Help: "Number of requests on platform API throttled by client side limits or retried with backoff",
+	}, []string{"namespace", "name", "reason"})
+	PlatformOrphanServices = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "monitoring_operator_platform_orphan_services",
+		Help: "Number of services created by the operator on platform that are not handled anymore by any resource",
+	}, []string{"namespace", "name"})
+	PlatformOrphanServicesDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "monitoring_operator_platform_orphan_services_deleted_total",
+		Help: "Number of orphan services deleted on platform",
+	}, []string{"namespace", "name"})
 )
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(TotalErrors, ControllerErrors, ControllerInstances)
+	metrics.Registry.MustRegister(TotalErrors, ControllerErrors, ControllerInstances, ControllerDrift, PlatformUp, PlatformLatency, PlatformActiveEndpoint, PlatformThrottledRequests, PlatformOrphanServices, PlatformOrphanServicesDeleted)
 }
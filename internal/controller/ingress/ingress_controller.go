@@ -94,30 +94,105 @@ func (r *IngressReconciler) Read(ctx context.Context, o client.Object, data map[
 	rules := make([]map[string]any, 0, len(i.Spec.Rules))
 	for _, rule := range i.Spec.Rules {
 		r := map[string]any{
-			"host":   rule.Host,
-			"scheme": "http",
+			"host":          rule.Host,
+			"scheme":        "http",
+			"tlsSecretName": "",
 		}
 
 		// Check if scheme is https
+		// The secret name is empty when the scheme is http, or when the default certificate is used
 		for _, tls := range i.Spec.TLS {
 			for _, host := range tls.Hosts {
 				if host == rule.Host {
 					r["scheme"] = "https"
+					r["tlsSecretName"] = tls.SecretName
 				}
 			}
 		}
 
-		// Add path
-		paths := make([]string, 0, len(rule.HTTP.Paths))
-		for _, path := range rule.HTTP.Paths {
-			paths = append(paths, path.Path)
+		// Add path and backends
+		paths := make([]string, 0)
+		backends := make([]map[string]any, 0)
+		if rule.HTTP != nil {
+			for _, path := range rule.HTTP.Paths {
+				paths = append(paths, path.Path)
+
+				backend := getBackendPlaceholders(path.Backend)
+				backend["path"] = path.Path
+				backend["pathType"] = ""
+				if path.PathType != nil {
+					backend["pathType"] = string(*path.PathType)
+				}
+				backends = append(backends, backend)
+			}
 		}
 		r["paths"] = paths
+		r["backends"] = backends
 		rules = append(rules, r)
 	}
 	placeholders["rules"] = rules
 
+	// Ingress class
+	if i.Spec.IngressClassName != nil {
+		placeholders["ingressClassName"] = *i.Spec.IngressClassName
+	} else {
+		placeholders["ingressClassName"] = ""
+	}
+
+	// TLS
+	tls := make([]map[string]any, 0, len(i.Spec.TLS))
+	for _, t := range i.Spec.TLS {
+		tls = append(tls, map[string]any{
+			"hosts":      t.Hosts,
+			"secretName": t.SecretName,
+		})
+	}
+	placeholders["tls"] = tls
+
+	// Default backend
+	if i.Spec.DefaultBackend != nil {
+		placeholders["defaultBackend"] = getBackendPlaceholders(*i.Spec.DefaultBackend)
+	}
+
+	// Load balancer status
+	loadBalancers := make([]map[string]any, 0, len(i.Status.LoadBalancer.Ingress))
+	for _, lb := range i.Status.LoadBalancer.Ingress {
+		ports := make([]int32, 0, len(lb.Ports))
+		for _, port := range lb.Ports {
+			ports = append(ports, port.Port)
+		}
+		loadBalancers = append(loadBalancers, map[string]any{
+			"ip":       lb.IP,
+			"hostname": lb.Hostname,
+			"ports":    ports,
+		})
+	}
+	placeholders["loadBalancers"] = loadBalancers
+
 	data["placeholders"] = placeholders
 
 	return r.SentinelReconcilerAction.Read(ctx, o, data, logger)
 }
+
+// getBackendPlaceholders permit to convert ingress backend on placeholders
+func getBackendPlaceholders(b networkv1.IngressBackend) map[string]any {
+	backend := map[string]any{
+		"serviceName":     "",
+		"servicePort":     int32(0),
+		"servicePortName": "",
+	}
+
+	if b.Service != nil {
+		backend["serviceName"] = b.Service.Name
+		backend["servicePort"] = b.Service.Port.Number
+		backend["servicePortName"] = b.Service.Port.Name
+	}
+	if b.Resource != nil {
+		backend["resource"] = map[string]any{
+			"kind": b.Resource.Kind,
+			"name": b.Resource.Name,
+		}
+	}
+
+	return backend
+}
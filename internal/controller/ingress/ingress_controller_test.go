@@ -16,6 +16,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -470,6 +471,35 @@ spec:
 			}
 			logrus.Infof("Create template template-ingress4")
 
+			template = &monitorapi.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template-ingress5",
+					Namespace: "default",
+				},
+				Spec: monitorapi.TemplateSpec{
+					Template: `
+{{ $rule := index .rules 1 }}
+{{ $backend := index $rule.backends 0 }}
+apiVersion: monitor.k8s.webcenter.fr/v1
+kind: CentreonService
+spec:
+  host: "localhost"
+  name: "ping5"
+  template: "template5"
+  macros:
+    ingressClass: "{{ .ingressClassName }}"
+    url: "{{ $rule.scheme }}://{{ $rule.host }}{{ $backend.path }}"
+    pathType: "{{ $backend.pathType }}"
+    backend: "{{ $backend.serviceName }}:{{ $backend.servicePort }}"
+    tlsSecret: "{{ $rule.tlsSecretName }}"
+  activate: true`,
+				},
+			}
+			if err := c.Create(context.Background(), template); err != nil {
+				return err
+			}
+			logrus.Infof("Create template template-ingress5")
+
 			return nil
 		},
 		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
@@ -486,10 +516,11 @@ spec:
 						"env": "dev",
 					},
 					Annotations: map[string]string{
-						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-ingress3\"}, {\"namespace\":\"default\", \"name\": \"template-ingress4\"}]",
+						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-ingress3\"}, {\"namespace\":\"default\", \"name\": \"template-ingress4\"}, {\"namespace\":\"default\", \"name\": \"template-ingress5\"}]",
 					},
 				},
 				Spec: networkv1.IngressSpec{
+					IngressClassName: ptr.To("nginx"),
 					Rules: []networkv1.IngressRule{
 						{
 							Host: "front.local.local",
@@ -542,7 +573,8 @@ spec:
 					},
 					TLS: []networkv1.IngressTLS{
 						{
-							Hosts: []string{"back.local.local"},
+							Hosts:      []string{"back.local.local"},
+							SecretName: "back-tls",
 						},
 					},
 				},
@@ -621,6 +653,34 @@ spec:
 			assert.Equal(t, fmt.Sprintf("%s.%s", key.Namespace, key.Name), cs.Labels["monitor.k8s.webcenter.fr/parent"])
 			assert.Equal(t, expectedCSSpec, cs.Spec)
 			assert.NotEmpty(t, cs.OwnerReferences)
+
+			// Get service generated by template-ingress5
+			isTimeout, err = test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: "template-ingress5"}, cs); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return errors.New("Not yet created")
+					}
+					t.Fatalf("Error when get Centreon service template-ingress5: %s", err.Error())
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Centreon service template-ingress5: %s", err.Error())
+			}
+			expectedCSSpec = monitorapi.CentreonServiceSpec{
+				Host:     "localhost",
+				Name:     "ping5",
+				Template: "template5",
+				Macros: map[string]string{
+					"ingressClass": "nginx",
+					"url":          "https://back.local.local/",
+					"pathType":     "Prefix",
+					"backend":      "test:80",
+					"tlsSecret":    "back-tls",
+				},
+				Activated: true,
+			}
+			assert.Equal(t, expectedCSSpec, cs.Spec)
 			return nil
 		},
 	}
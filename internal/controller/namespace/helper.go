@@ -0,0 +1,71 @@
+package namespace
+
+import (
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// getResourceQuotasPlaceholders permit to convert resource quotas on placeholders
+func getResourceQuotasPlaceholders(quotas []corev1.ResourceQuota) []map[string]any {
+	placeholders := make([]map[string]any, 0, len(quotas))
+	for _, quota := range quotas {
+		placeholders = append(placeholders, map[string]any{
+			"name": quota.Name,
+			"hard": helpers.ResourceListToPlaceholders(quota.Spec.Hard),
+			"used": helpers.ResourceListToPlaceholders(quota.Status.Used),
+		})
+	}
+
+	return placeholders
+}
+
+// getQuotaPlaceholders permit to merge all resource quotas on a single one
+// When a resource is defined on multiple quotas, it keep the most restrictive
+func getQuotaPlaceholders(quotas []corev1.ResourceQuota) map[string]any {
+	hard := corev1.ResourceList{}
+	used := corev1.ResourceList{}
+
+	for _, quota := range quotas {
+		for name, quantity := range quota.Spec.Hard {
+			if current, ok := hard[name]; ok && current.Cmp(quantity) <= 0 {
+				continue
+			}
+			hard[name] = quantity
+			if usedQuantity, ok := quota.Status.Used[name]; ok {
+				used[name] = usedQuantity
+			} else {
+				used[name] = resource.Quantity{}
+			}
+		}
+	}
+
+	return map[string]any{
+		"hard": helpers.ResourceListToPlaceholders(hard),
+		"used": helpers.ResourceListToPlaceholders(used),
+	}
+}
+
+// getLimitRangesPlaceholders permit to convert limit ranges on placeholders
+func getLimitRangesPlaceholders(limitRanges []corev1.LimitRange) []map[string]any {
+	placeholders := make([]map[string]any, 0, len(limitRanges))
+	for _, limitRange := range limitRanges {
+		limits := make([]map[string]any, 0, len(limitRange.Spec.Limits))
+		for _, limit := range limitRange.Spec.Limits {
+			limits = append(limits, map[string]any{
+				"type":                 string(limit.Type),
+				"max":                  helpers.ResourceListToPlaceholders(limit.Max),
+				"min":                  helpers.ResourceListToPlaceholders(limit.Min),
+				"default":              helpers.ResourceListToPlaceholders(limit.Default),
+				"defaultRequest":       helpers.ResourceListToPlaceholders(limit.DefaultRequest),
+				"maxLimitRequestRatio": helpers.ResourceListToPlaceholders(limit.MaxLimitRequestRatio),
+			})
+		}
+		placeholders = append(placeholders, map[string]any{
+			"name":   limitRange.Name,
+			"limits": limits,
+		})
+	}
+
+	return placeholders
+}
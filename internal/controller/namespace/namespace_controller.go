@@ -3,24 +3,43 @@ package namespace
 import (
 	"context"
 
+	"emperror.dev/errors"
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/internal/controller/template"
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
-	name string = "namespace"
+	name                string = "namespace"
+	templatesAnnotation string = centreoncrd.MonitoringAnnotationKey + "/templates"
 )
 
+// workloadKinds is the list of workloads to count on namespace
+var workloadKinds = map[string]schema.GroupVersionKind{
+	"deployments":  appsv1.SchemeGroupVersion.WithKind("DeploymentList"),
+	"statefulSets": appsv1.SchemeGroupVersion.WithKind("StatefulSetList"),
+	"daemonSets":   appsv1.SchemeGroupVersion.WithKind("DaemonSetList"),
+	"cronJobs":     batchv1.SchemeGroupVersion.WithKind("CronJobList"),
+}
+
 // NamespaceReconciler reconciles a namespace
 type NamespaceReconciler struct {
 	controller.Controller
@@ -48,6 +67,9 @@ func NewNamespaceReconciler(client client.Client, logger *logrus.Entry, recorder
 //+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservicegroups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+//+kubebuilder:rbac:groups="",resources=resourcequotas;limitranges;pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="apps",resources=deployments;statefulsets;daemonsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="batch",resources=cronjobs,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -72,17 +94,123 @@ func (r *NamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// The template filter is set per watch because the namespace resources (quota, pods, ...) haven't the template annotation
 func (r *NamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		// Uncomment the following line adding a pointer to an instance of the controlled resource as an argument
 		Named(r.name).
-		For(&corev1.Namespace{}).
-		Owns(&centreoncrd.CentreonService{}).
-		Owns(&centreoncrd.CentreonServiceGroup{}).
+		For(&corev1.Namespace{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Owns(&centreoncrd.CentreonService{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Owns(&centreoncrd.CentreonServiceGroup{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
 		WithOptions(k8scontroller.Options{
 			RateLimiter: helper.DefaultControllerRateLimiter[reconcile.Request](),
 		}).
-		WithEventFilter(template.ViewResourceWithMonitoringTemplate()).
-		Watches(&centreoncrd.Template{}, handler.EnqueueRequestsFromMapFunc(template.WatchTemplate(r.Client(), &corev1.NamespaceList{}))).
+		Watches(&centreoncrd.Template{}, handler.EnqueueRequestsFromMapFunc(template.WatchTemplate(r.Client(), &corev1.NamespaceList{})), builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Watches(&corev1.ResourceQuota{}, handler.EnqueueRequestsFromMapFunc(watchNamespaceResource(r.Client()))).
+		Watches(&corev1.LimitRange{}, handler.EnqueueRequestsFromMapFunc(watchNamespaceResource(r.Client()))).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(watchNamespaceResource(r.Client())), builder.WithPredicates(viewCreateOrDeletePredicate())).
+		WatchesMetadata(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(watchNamespaceResource(r.Client())), builder.WithPredicates(viewCreateOrDeletePredicate())).
+		WatchesMetadata(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(watchNamespaceResource(r.Client())), builder.WithPredicates(viewCreateOrDeletePredicate())).
+		WatchesMetadata(&appsv1.DaemonSet{}, handler.EnqueueRequestsFromMapFunc(watchNamespaceResource(r.Client())), builder.WithPredicates(viewCreateOrDeletePredicate())).
+		WatchesMetadata(&batchv1.CronJob{}, handler.EnqueueRequestsFromMapFunc(watchNamespaceResource(r.Client())), builder.WithPredicates(viewCreateOrDeletePredicate())).
 		Complete(r)
 }
+
+func (r *NamespaceReconciler) Read(ctx context.Context, o client.Object, data map[string]any, logger *logrus.Entry) (read controller.SentinelRead, res ctrl.Result, err error) {
+	n := o.(*corev1.Namespace)
+
+	// Read resource quotas
+	quotaList := &corev1.ResourceQuotaList{}
+	if err = r.Client().List(ctx, quotaList, client.InNamespace(n.Name)); err != nil {
+		return nil, res, errors.Wrapf(err, "Error when read resource quotas on namespace %s", n.Name)
+	}
+
+	// Read limit ranges
+	limitRangeList := &corev1.LimitRangeList{}
+	if err = r.Client().List(ctx, limitRangeList, client.InNamespace(n.Name)); err != nil {
+		return nil, res, errors.Wrapf(err, "Error when read limit ranges on namespace %s", n.Name)
+	}
+
+	// Count workloads and pods
+	workloads := map[string]any{}
+	for key, gvk := range workloadKinds {
+		count, err := r.count(ctx, n.Name, gvk)
+		if err != nil {
+			return nil, res, err
+		}
+		workloads[key] = count
+	}
+	// The pods are read from the same cache as the persistent volume claim controller, that need the whole pods
+	podList := &corev1.PodList{}
+	if err = r.Client().List(ctx, podList, client.InNamespace(n.Name)); err != nil {
+		return nil, res, errors.Wrapf(err, "Error when read pods on namespace %s", n.Name)
+	}
+	pods := len(podList.Items)
+
+	ownerReferences := make([]map[string]any, 0, len(n.OwnerReferences))
+	for _, ownerReference := range n.OwnerReferences {
+		ownerReferences = append(ownerReferences, map[string]any{
+			"apiVersion": ownerReference.APIVersion,
+			"kind":       ownerReference.Kind,
+			"name":       ownerReference.Name,
+		})
+	}
+
+	placeholders := map[string]any{
+		"resourceQuotas":  getResourceQuotasPlaceholders(quotaList.Items),
+		"quota":           getQuotaPlaceholders(quotaList.Items),
+		"limitRanges":     getLimitRangesPlaceholders(limitRangeList.Items),
+		"workloads":       workloads,
+		"pods":            pods,
+		"ownerReferences": ownerReferences,
+	}
+
+	data["placeholders"] = placeholders
+
+	return r.SentinelReconcilerAction.Read(ctx, o, data, logger)
+}
+
+// count permit to count the number of objects on namespace
+// It only use the metadata of objects
+func (r *NamespaceReconciler) count(ctx context.Context, namespace string, gvk schema.GroupVersionKind) (int, error) {
+	objectList := &metav1.PartialObjectMetadataList{}
+	objectList.SetGroupVersionKind(gvk)
+	if err := r.Client().List(ctx, objectList, client.InNamespace(namespace)); err != nil {
+		return 0, errors.Wrapf(err, "Error when read %s on namespace %s", gvk.Kind, namespace)
+	}
+
+	return len(objectList.Items), nil
+}
+
+// watchNamespaceResource permit to reconcile the namespace when one of resources inside it change
+func watchNamespaceResource(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		n := &corev1.Namespace{}
+		if err := c.Get(ctx, types.NamespacedName{Name: a.GetNamespace()}, n); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			panic(err)
+		}
+
+		// Only namespace with monitoring templates
+		if n.GetAnnotations()[templatesAnnotation] == "" {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: n.Name}}}
+	}
+}
+
+// viewCreateOrDeletePredicate permit to only handle creation and deletion events
+// It's enough to count objects
+func viewCreateOrDeletePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
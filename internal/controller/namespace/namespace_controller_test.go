@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -391,6 +392,31 @@ spec:
 			}
 			logrus.Infof("Create template template-namespace4")
 
+			template = &monitorapi.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template-namespace5",
+					Namespace: "default",
+				},
+				Spec: monitorapi.TemplateSpec{
+					Template: `
+apiVersion: monitor.k8s.webcenter.fr/v1
+kind: CentreonService
+spec:
+  host: "localhost"
+  name: "ping5"
+  template: "template5"
+  macros:
+    maxPods: "{{ with .quota.hard.pods }}{{ .int }}{{ end }}"
+    quotas: "{{ len .resourceQuotas }}"
+    deployments: "{{ .workloads.deployments }}"
+  activate: true`,
+				},
+			}
+			if err := c.Create(context.Background(), template); err != nil {
+				return err
+			}
+			logrus.Infof("Create template template-namespace5")
+
 			return nil
 		},
 		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
@@ -406,7 +432,7 @@ spec:
 						"env": "dev",
 					},
 					Annotations: map[string]string{
-						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-namespace3\"}, {\"namespace\":\"default\", \"name\": \"template-namespace4\"}]",
+						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-namespace3\"}, {\"namespace\":\"default\", \"name\": \"template-namespace4\"}, {\"namespace\":\"default\", \"name\": \"template-namespace5\"}]",
 					},
 				},
 			}
@@ -415,6 +441,22 @@ spec:
 				return err
 			}
 
+			// Add quota on namespace, it must reconcile the namespace
+			quota := &core.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "quota",
+					Namespace: key.Name,
+				},
+				Spec: core.ResourceQuotaSpec{
+					Hard: core.ResourceList{
+						core.ResourcePods: resource.MustParse("10"),
+					},
+				},
+			}
+			if err = c.Create(context.Background(), quota); err != nil {
+				return err
+			}
+
 			return nil
 		},
 		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
@@ -485,6 +527,35 @@ spec:
 			assert.Equal(t, fmt.Sprintf("%s.%s", key.Name, key.Name), cs.Labels["monitor.k8s.webcenter.fr/parent"])
 			assert.Equal(t, expectedCSSpec, cs.Spec)
 			assert.NotEmpty(t, cs.OwnerReferences)
+
+			// Get service generated by template-namespace5, after quota is taken into account
+			isTimeout, err = test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Name, Name: "template-namespace5"}, cs); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return errors.New("Not yet created")
+					}
+					t.Fatalf("Error when get Centreon service template-namespace5: %s", err.Error())
+				}
+				if cs.Spec.Macros["maxPods"] != "10" {
+					return errors.New("Not yet updated from quota")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Centreon service template-namespace5: %s", err.Error())
+			}
+			expectedCSSpec = monitorapi.CentreonServiceSpec{
+				Host:     "localhost",
+				Name:     "ping5",
+				Template: "template5",
+				Macros: map[string]string{
+					"maxPods":     "10",
+					"quotas":      "1",
+					"deployments": "0",
+				},
+				Activated: true,
+			}
+			assert.Equal(t, expectedCSSpec, cs.Spec)
 			return nil
 		},
 	}
@@ -2,12 +2,16 @@ package node
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/internal/controller/template"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -18,7 +22,9 @@ import (
 )
 
 const (
-	name string = "node"
+	name                string = "node"
+	nodeRoleLabelPrefix string = "node-role.kubernetes.io/"
+	nodeRoleLabel       string = "kubernetes.io/role"
 )
 
 // NodeReconciler reconciles a node
@@ -90,13 +96,81 @@ func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 func (r *NodeReconciler) Read(ctx context.Context, o client.Object, data map[string]any, logger *logrus.Entry) (read controller.SentinelRead, res ctrl.Result, err error) {
 	n := o.(*corev1.Node)
 
+	roles := getRoles(n)
+
 	placeholders := map[string]any{
-		"nodeInfo":      n.Status.NodeInfo,
-		"addresses":     n.Status.Addresses,
-		"unschedulable": n.Spec.Unschedulable,
+		"nodeInfo":       n.Status.NodeInfo,
+		"addresses":      n.Status.Addresses,
+		"unschedulable":  n.Spec.Unschedulable,
+		"roles":          roles,
+		"isControlPlane": funk.ContainsString(roles, "control-plane") || funk.ContainsString(roles, "master"),
+		"zone":           getTopologyLabel(n, corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone),
+		"region":         getTopologyLabel(n, corev1.LabelTopologyRegion, corev1.LabelFailureDomainBetaRegion),
+		"capacity":       helpers.ResourceListToPlaceholders(n.Status.Capacity),
+		"allocatable":    helpers.ResourceListToPlaceholders(n.Status.Allocatable),
+		"conditions":     getConditionsPlaceholders(n.Status.Conditions),
+		"taints":         getTaintsPlaceholders(n.Spec.Taints),
 	}
 
 	data["placeholders"] = placeholders
 
 	return r.SentinelReconcilerAction.Read(ctx, o, data, logger)
 }
+
+// getRoles permit to compute node roles from labels `node-role.kubernetes.io/<role>` and `kubernetes.io/role`
+func getRoles(n *corev1.Node) []string {
+	roles := make([]string, 0)
+	for key, value := range n.GetLabels() {
+		switch {
+		case strings.HasPrefix(key, nodeRoleLabelPrefix):
+			if role := strings.TrimPrefix(key, nodeRoleLabelPrefix); role != "" {
+				roles = append(roles, role)
+			}
+		case key == nodeRoleLabel && value != "":
+			roles = append(roles, value)
+		}
+	}
+	roles = funk.UniqString(roles)
+	sort.Strings(roles)
+
+	return roles
+}
+
+// getTopologyLabel return the value of the first label found
+func getTopologyLabel(n *corev1.Node, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := n.GetLabels()[key]; ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// getConditionsPlaceholders permit to convert node conditions on placeholders indexed by condition type
+func getConditionsPlaceholders(conditions []corev1.NodeCondition) map[string]any {
+	placeholders := make(map[string]any, len(conditions))
+	for _, condition := range conditions {
+		placeholders[string(condition.Type)] = map[string]any{
+			"status":  string(condition.Status),
+			"reason":  condition.Reason,
+			"message": condition.Message,
+		}
+	}
+
+	return placeholders
+}
+
+// getTaintsPlaceholders permit to convert node taints on placeholders
+func getTaintsPlaceholders(taints []corev1.Taint) []map[string]any {
+	placeholders := make([]map[string]any, 0, len(taints))
+	for _, taint := range taints {
+		placeholders = append(placeholders, map[string]any{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": string(taint.Effect),
+		})
+	}
+
+	return placeholders
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -313,6 +314,35 @@ spec:
 			}
 			logrus.Infof("Create template template-node3")
 
+			template = &monitorapi.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template-node4",
+					Namespace: "default",
+				},
+				Spec: monitorapi.TemplateSpec{
+					Template: `
+apiVersion: monitor.k8s.webcenter.fr/v1
+kind: CentreonService
+spec:
+  host: "localhost"
+  name: "ping4"
+  template: "template4"
+  macros:
+    roles: "{{ join "," .roles }}"
+    isControlPlane: "{{ .isControlPlane }}"
+    zone: "{{ .zone }}"
+    memory: "{{ .capacity.memory.int }}"
+    cpu: "{{ .allocatable.cpu.milli }}"
+    ready: "{{ .conditions.Ready.status }}"
+    taint: "{{ (index .taints 0).key }}"
+  activate: true`,
+				},
+			}
+			if err := c.Create(context.Background(), template); err != nil {
+				return err
+			}
+			logrus.Infof("Create template template-node4")
+
 			return nil
 		},
 		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
@@ -323,11 +353,39 @@ spec:
 				ObjectMeta: metav1.ObjectMeta{
 					Name: key.Name,
 					Labels: map[string]string{
-						"app": "appTest",
-						"env": "dev",
+						"app":                            "appTest",
+						"env":                            "dev",
+						"node-role.kubernetes.io/worker": "",
+						"node-role.kubernetes.io/infra":  "",
+						"topology.kubernetes.io/zone":    "zone-a",
 					},
 					Annotations: map[string]string{
-						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-node3\"}]",
+						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-node3\"}, {\"namespace\":\"default\", \"name\": \"template-node4\"}]",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{
+							Key:    "dedicated",
+							Value:  "infra",
+							Effect: corev1.TaintEffectNoSchedule,
+						},
+					},
+				},
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+						corev1.ResourceCPU:    resource.MustParse("2"),
+					},
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+						corev1.ResourceCPU:    resource.MustParse("1500m"),
+					},
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionTrue,
+						},
 					},
 				},
 			}
@@ -372,6 +430,36 @@ spec:
 			assert.Equal(t, fmt.Sprintf("%s.%s", key.Namespace, key.Name), cs.Labels["monitor.k8s.webcenter.fr/parent"])
 			assert.Equal(t, expectedCSSpec, cs.Spec)
 			assert.NotEmpty(t, cs.OwnerReferences)
+
+			// Get service generated by template-node4
+			isTimeout, err = test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: "template-node4"}, cs); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return errors.New("Not yet created")
+					}
+					t.Fatalf("Error when get Centreon service template-node4: %s", err.Error())
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Centreon service template-node4: %s", err.Error())
+			}
+			expectedCSSpec = monitorapi.CentreonServiceSpec{
+				Host:     "localhost",
+				Name:     "ping4",
+				Template: "template4",
+				Macros: map[string]string{
+					"roles":          "infra,worker",
+					"isControlPlane": "false",
+					"zone":           "zone-a",
+					"memory":         "1073741824",
+					"cpu":            "1500",
+					"ready":          "True",
+					"taint":          "dedicated",
+				},
+				Activated: true,
+			}
+			assert.Equal(t, expectedCSSpec, cs.Spec)
 			return nil
 		},
 	}
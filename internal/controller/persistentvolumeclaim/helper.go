@@ -0,0 +1,70 @@
+package persistentvolumeclaim
+
+import (
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getAccessModes permit to convert access modes on list of string
+func getAccessModes(accessModes []corev1.PersistentVolumeAccessMode) []string {
+	res := make([]string, 0, len(accessModes))
+	for _, accessMode := range accessModes {
+		res = append(res, string(accessMode))
+	}
+
+	return res
+}
+
+// getVolumePlaceholders permit to convert the bound persistent volume on placeholders
+// It return empty map if the claim is not yet bound
+func getVolumePlaceholders(pv *corev1.PersistentVolume) map[string]any {
+	if pv == nil {
+		return map[string]any{}
+	}
+
+	volume := map[string]any{
+		"name":          pv.Name,
+		"phase":         string(pv.Status.Phase),
+		"reclaimPolicy": string(pv.Spec.PersistentVolumeReclaimPolicy),
+		"capacity":      helpers.ResourceListToPlaceholders(pv.Spec.Capacity),
+		"driver":        "",
+		"volumeHandle":  "",
+	}
+	if pv.Spec.CSI != nil {
+		volume["driver"] = pv.Spec.CSI.Driver
+		volume["volumeHandle"] = pv.Spec.CSI.VolumeHandle
+	}
+
+	return volume
+}
+
+// getPodsPlaceholders permit to get the pods that consume the claim
+func getPodsPlaceholders(claimName string, pods []corev1.Pod) []map[string]any {
+	res := make([]map[string]any, 0)
+	for _, pod := range pods {
+		for _, podClaimName := range getClaimNames(&pod) {
+			if podClaimName == claimName {
+				res = append(res, map[string]any{
+					"name":     pod.Name,
+					"nodeName": pod.Spec.NodeName,
+					"phase":    string(pod.Status.Phase),
+				})
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// getClaimNames permit to get the persistent volume claims used by pod
+func getClaimNames(pod *corev1.Pod) []string {
+	res := make([]string, 0)
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName != "" {
+			res = append(res, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+
+	return res
+}
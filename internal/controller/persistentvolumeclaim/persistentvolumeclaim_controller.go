@@ -0,0 +1,281 @@
+package persistentvolumeclaim
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/template"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	name                string = "persistentvolumeclaim"
+	templatesAnnotation string = centreoncrd.MonitoringAnnotationKey + "/templates"
+)
+
+// PersistentVolumeClaimReconciler reconciles a persistent volume claim
+type PersistentVolumeClaimReconciler struct {
+	controller.Controller
+	controller.SentinelReconciler
+	controller.SentinelReconcilerAction
+	name string
+}
+
+func NewPersistentVolumeClaimReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder) (sentienelReconciler controller.Controller) {
+	return &PersistentVolumeClaimReconciler{
+		Controller: controller.NewBasicController(),
+		SentinelReconciler: controller.NewBasicSentinelReconciler(
+			client,
+			name,
+			logger,
+			recorder,
+		),
+		SentinelReconcilerAction: template.NewTemplateReconciler(client, recorder),
+		name:                     name,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=persistentvolumes;pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservicegroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// TODO(user): Modify the Reconcile function to compare the state specified by
+// the Cerebro object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.13.0/pkg/reconcile
+func (r *PersistentVolumeClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	data := map[string]any{}
+
+	return r.SentinelReconciler.Reconcile(
+		ctx,
+		req,
+		pvc,
+		data,
+		r,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The template filter is set per watch because the pods haven't the template annotation
+func (r *PersistentVolumeClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Uncomment the following line adding a pointer to an instance of the controlled resource as an argument
+		Named(r.name).
+		For(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Owns(&centreoncrd.CentreonService{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Owns(&centreoncrd.CentreonServiceGroup{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		WithOptions(k8scontroller.Options{
+			RateLimiter: helper.DefaultControllerRateLimiter[reconcile.Request](),
+		}).
+		Watches(&centreoncrd.Template{}, handler.EnqueueRequestsFromMapFunc(template.WatchTemplate(r.Client(), &corev1.PersistentVolumeClaimList{})), builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(watchPod(r.Client())), builder.WithPredicates(viewPodPredicate())).
+		Watches(&corev1.PersistentVolume{}, handler.EnqueueRequestsFromMapFunc(watchPersistentVolume(r.Client())), builder.WithPredicates(viewPersistentVolumePredicate())).
+		Complete(r)
+}
+
+func (r *PersistentVolumeClaimReconciler) Read(ctx context.Context, o client.Object, data map[string]any, logger *logrus.Entry) (read controller.SentinelRead, res ctrl.Result, err error) {
+	pvc := o.(*corev1.PersistentVolumeClaim)
+
+	// Read the bound persistent volume
+	var pv *corev1.PersistentVolume
+	if pvc.Spec.VolumeName != "" {
+		pv = &corev1.PersistentVolume{}
+		if err = r.Client().Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, res, errors.Wrapf(err, "Error when read persistent volume %s", pvc.Spec.VolumeName)
+			}
+			logger.Debugf("Persistent volume %s not found", pvc.Spec.VolumeName)
+			pv = nil
+		}
+	}
+
+	// Read the pods that consume the claim
+	// It use the index `spec.volumes.persistentVolumeClaim`
+	podList := &corev1.PodList{}
+	if err = r.Client().List(ctx, podList, client.InNamespace(pvc.Namespace), client.MatchingFields{"spec.volumes.persistentVolumeClaim": pvc.Name}); err != nil {
+		return nil, res, errors.Wrapf(err, "Error when read pods on namespace %s", pvc.Namespace)
+	}
+
+	storageClassName := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClassName = *pvc.Spec.StorageClassName
+	}
+	volumeMode := ""
+	if pvc.Spec.VolumeMode != nil {
+		volumeMode = string(*pvc.Spec.VolumeMode)
+	}
+
+	placeholders := map[string]any{
+		"storageClassName": storageClassName,
+		"volumeMode":       volumeMode,
+		"accessModes":      getAccessModes(pvc.Spec.AccessModes),
+		"phase":            string(pvc.Status.Phase),
+		"requests":         helpers.ResourceListToPlaceholders(pvc.Spec.Resources.Requests),
+		"limits":           helpers.ResourceListToPlaceholders(pvc.Spec.Resources.Limits),
+		"capacity":         helpers.ResourceListToPlaceholders(pvc.Status.Capacity),
+		"volumeName":       pvc.Spec.VolumeName,
+		"volume":           getVolumePlaceholders(pv),
+		"pods":             getPodsPlaceholders(pvc.Name, podList.Items),
+	}
+
+	data["placeholders"] = placeholders
+
+	return r.SentinelReconcilerAction.Read(ctx, o, data, logger)
+}
+
+// watchPod permit to reconcile the persistent volume claims used by pod
+func watchPod(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		pod, ok := a.(*corev1.Pod)
+		if !ok {
+			return nil
+		}
+
+		reconcileRequests := make([]reconcile.Request, 0)
+		for _, claimName := range getClaimNames(pod) {
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: claimName}, pvc); err != nil {
+				if k8serrors.IsNotFound(err) {
+					continue
+				}
+				panic(err)
+			}
+
+			// Only claim with monitoring templates
+			if pvc.GetAnnotations()[templatesAnnotation] == "" {
+				continue
+			}
+
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}})
+		}
+
+		return reconcileRequests
+	}
+}
+
+// viewPodPredicate permit to only handle pods that use persistent volume claim
+// On update, it only handle the change of node or phase
+func viewPodPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return hasClaim(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			if !hasClaim(newPod) {
+				return false
+			}
+			return oldPod.Spec.NodeName != newPod.Spec.NodeName || oldPod.Status.Phase != newPod.Status.Phase
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return hasClaim(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+func hasClaim(o client.Object) bool {
+	pod, ok := o.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	return len(getClaimNames(pod)) > 0
+}
+
+// watchPersistentVolume permit to reconcile the persistent volume claim bound to the volume
+func watchPersistentVolume(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		pv, ok := a.(*corev1.PersistentVolume)
+		if !ok || pv.Spec.ClaimRef == nil {
+			return nil
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: pv.Spec.ClaimRef.Namespace, Name: pv.Spec.ClaimRef.Name}, pvc); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			panic(err)
+		}
+
+		// Only claim with monitoring templates
+		if pvc.GetAnnotations()[templatesAnnotation] == "" {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}}}
+	}
+}
+
+// viewPersistentVolumePredicate permit to only handle persistent volumes bound to a claim
+// On update, it only handle the change of spec or phase
+func viewPersistentVolumePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return hasClaimRef(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPV, ok := e.ObjectOld.(*corev1.PersistentVolume)
+			if !ok {
+				return false
+			}
+			newPV, ok := e.ObjectNew.(*corev1.PersistentVolume)
+			if !ok {
+				return false
+			}
+			if !hasClaimRef(oldPV) && !hasClaimRef(newPV) {
+				return false
+			}
+			return oldPV.Status.Phase != newPV.Status.Phase || !equality.Semantic.DeepEqual(oldPV.Spec, newPV.Spec)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return hasClaimRef(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+func hasClaimRef(o client.Object) bool {
+	pv, ok := o.(*corev1.PersistentVolume)
+	if !ok {
+		return false
+	}
+	return pv.Spec.ClaimRef != nil
+}
@@ -0,0 +1,394 @@
+package persistentvolumeclaim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	monitorapi "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func (t *PersistentVolumeClaimControllerTestSuite) TestPersistentVolumeClaimCentreonController() {
+	key := types.NamespacedName{
+		Name:      "t-pvc-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, pvc, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreatePersistentVolumeClaimStep(),
+		doUpdatePersistentVolumeClaimStep(),
+		doDeletePersistentVolumeClaimStep(),
+	}
+
+	testCase.Run()
+}
+
+func doCreatePersistentVolumeClaimStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Pre: func(c client.Client, data map[string]any) error {
+			template := &monitorapi.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template-pvc1",
+					Namespace: "default",
+				},
+				Spec: monitorapi.TemplateSpec{
+					Template: `
+{{ $pod := index .pods 0 }}
+apiVersion: monitor.k8s.webcenter.fr/v1
+kind: CentreonService
+spec:
+  host: "localhost"
+  name: "volume-{{ .name }}"
+  template: "template1"
+  macros:
+    name: "{{ .name }}"
+    namespace: "{{ .namespace }}"
+    storageClass: "{{ .storageClassName }}"
+    accessModes: "{{ join "," .accessModes }}"
+    size: "{{ .requests.storage.value }}"
+    sizeBytes: "{{ .requests.storage.int }}"
+    volumeMode: "{{ .volumeMode }}"
+    pod: "{{ $pod.name }}"
+  activate: true
+  groups:
+    - "volumes-{{ .namespace }}"`,
+				},
+			}
+			if err := c.Create(context.Background(), template); err != nil {
+				return err
+			}
+			logrus.Infof("Create template template-pvc1")
+
+			return nil
+		},
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new PersistentVolumeClaim %s/%s ===", key.Namespace, key.Name)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "test",
+							Image: "test",
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: key.Name,
+								},
+							},
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), pod); err != nil {
+				return err
+			}
+
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels: map[string]string{
+						"app": "appTest",
+						"env": "dev",
+					},
+					Annotations: map[string]string{
+						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-pvc1\"}]",
+					},
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: ptr.To("standard"),
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), pvc); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			cs := &monitorapi.CentreonService{}
+
+			// Get service generated by template-pvc1
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: "template-pvc1"}, cs); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return errors.New("Not yet created")
+					}
+					t.Fatalf("Error when get Centreon service template-pvc1: %s", err.Error())
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Centreon service template-pvc1: %s", err.Error())
+			}
+			expectedCSSpec := monitorapi.CentreonServiceSpec{
+				Host:     "localhost",
+				Name:     fmt.Sprintf("volume-%s", key.Name),
+				Template: "template1",
+				Macros: map[string]string{
+					"name":         key.Name,
+					"namespace":    key.Namespace,
+					"storageClass": "standard",
+					"accessModes":  "ReadWriteOnce",
+					"size":         "1Gi",
+					"sizeBytes":    "1073741824",
+					"volumeMode":   "Filesystem",
+					"pod":          key.Name,
+				},
+				Activated: true,
+				Groups:    []string{"volumes-default"},
+			}
+			assert.Equal(t, "appTest", cs.Labels["app"])
+			assert.Equal(t, "template-pvc1", cs.Name)
+			assert.Equal(t, "default.template-pvc1", cs.Labels["monitor.k8s.webcenter.fr/template"])
+			assert.Equal(t, fmt.Sprintf("%s.%s", key.Namespace, key.Name), cs.Labels["monitor.k8s.webcenter.fr/parent"])
+			assert.Equal(t, expectedCSSpec, cs.Spec)
+			assert.NotEmpty(t, cs.OwnerReferences)
+
+			return nil
+		},
+	}
+}
+
+func doUpdatePersistentVolumeClaimStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Pre: func(c client.Client, data map[string]any) error {
+			logrus.Info("Update template template-pvc1")
+			template := &monitorapi.Template{}
+			if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "template-pvc1"}, template); err != nil {
+				return err
+			}
+
+			template.Spec.Template = `
+apiVersion: monitor.k8s.webcenter.fr/v1
+kind: CentreonService
+spec:
+  host: "localhost"
+  name: "volume-{{ .name }}"
+  template: "template2"
+  macros:
+    name: "{{ .name }}"
+    size: "{{ .requests.storage.value }}"
+    phase: "{{ .phase }}"
+  activate: true
+  groups:
+    - "volumes-{{ .namespace }}"`
+			if err := c.Update(context.Background(), template); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update PersistentVolumeClaim %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("PersistentVolumeClaim is null")
+			}
+			pvc := o.(*corev1.PersistentVolumeClaim)
+
+			pvc.Annotations["test"] = "update"
+
+			// Get version of current CentreonService object
+			cs := &monitorapi.CentreonService{}
+			if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: "template-pvc1"}, cs); err != nil {
+				return err
+			}
+
+			data["version"] = cs.ResourceVersion
+
+			if err = c.Update(context.Background(), pvc); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			cs := &monitorapi.CentreonService{}
+
+			version := data["version"].(string)
+
+			// Get service generated by template-pvc1
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: "template-pvc1"}, cs); err != nil {
+					t.Fatalf("Error when get Centreon service: %s", err.Error())
+				}
+				if cs.ResourceVersion == version {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Centreon service template-pvc1: %s", err.Error())
+			}
+			expectedCSSpec := monitorapi.CentreonServiceSpec{
+				Host:     "localhost",
+				Name:     fmt.Sprintf("volume-%s", key.Name),
+				Template: "template2",
+				Macros: map[string]string{
+					"name":  key.Name,
+					"size":  "1Gi",
+					"phase": "Pending",
+				},
+				Activated: true,
+				Groups:    []string{"volumes-default"},
+			}
+			assert.Equal(t, expectedCSSpec, cs.Spec)
+			assert.NotEmpty(t, cs.OwnerReferences)
+
+			return nil
+		},
+	}
+}
+
+func doDeletePersistentVolumeClaimStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete PersistentVolumeClaim %s/%s ===", key.Namespace, key.Name)
+			if o == nil {
+				return errors.New("PersistentVolumeClaim is null")
+			}
+			pvc := o.(*corev1.PersistentVolumeClaim)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), pvc, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			pvc := &corev1.PersistentVolumeClaim{}
+			isDeleted := false
+
+			// We can't test in envtest that the children is deleted
+			// https://stackoverflow.com/questions/64821970/operator-controller-could-not-delete-correlated-resources
+
+			// Object can be deleted or marked as deleted
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, pvc); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				if !pvc.DeletionTimestamp.IsZero() {
+					isDeleted = true
+					return nil
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+
+			if err != nil || isTimeout {
+				t.Fatalf("PersistentVolumeClaim not deleted: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
+
+func TestWatchPersistentVolume(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default", Annotations: map[string]string{templatesAnnotation: `[{"namespace":"default","name":"t1"}]`}},
+			},
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-template", Namespace: "default"},
+			},
+		).
+		Build()
+
+	// When the volume is bound to claim with templates
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv1"},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Namespace: "default", Name: "data"},
+		},
+	}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "data"}}}, watchPersistentVolume(c)(context.Background(), pv))
+
+	// When the claim has no templates
+	pv.Spec.ClaimRef.Name = "no-template"
+	assert.Empty(t, watchPersistentVolume(c)(context.Background(), pv))
+
+	// When the claim not exist
+	pv.Spec.ClaimRef.Name = "not-found"
+	assert.Empty(t, watchPersistentVolume(c)(context.Background(), pv))
+
+	// When the volume is not bound
+	pv.Spec.ClaimRef = nil
+	assert.Empty(t, watchPersistentVolume(c)(context.Background(), pv))
+}
+
+func TestViewPersistentVolumePredicate(t *testing.T) {
+	p := viewPersistentVolumePredicate()
+	oldPV := &corev1.PersistentVolume{
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Namespace: "default", Name: "data"},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumePending},
+	}
+
+	// When only metadata change
+	newPV := oldPV.DeepCopy()
+	newPV.Labels = map[string]string{"app": "test"}
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: oldPV, ObjectNew: newPV}))
+
+	// When phase change
+	newPV.Status.Phase = corev1.VolumeBound
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: oldPV, ObjectNew: newPV}))
+
+	// When the volume is not bound
+	assert.False(t, p.Create(event.CreateEvent{Object: &corev1.PersistentVolume{}}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: oldPV}))
+}
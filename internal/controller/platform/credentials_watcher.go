@@ -0,0 +1,166 @@
+package platform
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"emperror.dev/errors"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// credentialsWatcher permit to watch the credentials files of platforms
+// It notify the platforms when the files change, like when Vault agent or CSI secrets store rotate them
+type credentialsWatcher struct {
+	mu        sync.Mutex
+	watcher   *fsnotify.Watcher
+	platforms map[types.NamespacedName]string
+	events    chan event.GenericEvent
+	logger    *logrus.Entry
+}
+
+func newCredentialsWatcher(logger *logrus.Entry) *credentialsWatcher {
+	return &credentialsWatcher{
+		platforms: map[types.NamespacedName]string{},
+		events:    make(chan event.GenericEvent, subscriberBufferSize),
+		logger:    logger,
+	}
+}
+
+// Watch permit to watch the credentials path of platform
+// It can be called before the watcher is started
+func (w *credentialsWatcher) Watch(path string, platform types.NamespacedName) error {
+	path = filepath.Clean(path)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current, isExist := w.platforms[platform]
+	if isExist && current == path {
+		return nil
+	}
+	w.platforms[platform] = path
+
+	if w.watcher == nil {
+		return nil
+	}
+	if isExist {
+		w.removeIfUnused(current)
+	}
+	if err := w.watcher.Add(path); err != nil {
+		return errors.Wrapf(err, "Error when watch %s", path)
+	}
+
+	return nil
+}
+
+// Unwatch permit to stop to watch the credentials path of platform
+func (w *credentialsWatcher) Unwatch(platform types.NamespacedName) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	path, isExist := w.platforms[platform]
+	if !isExist {
+		return
+	}
+	delete(w.platforms, platform)
+
+	if w.watcher != nil {
+		w.removeIfUnused(path)
+	}
+}
+
+// Events return the channel that receive platform to reconcile
+func (w *credentialsWatcher) Events() <-chan event.GenericEvent {
+	return w.events
+}
+
+// Start implement manager.Runnable
+// It watch the files until the context is done
+func (w *credentialsWatcher) Start(ctx context.Context) (err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "Error when create credentials watcher")
+	}
+	defer watcher.Close()
+
+	w.mu.Lock()
+	w.watcher = watcher
+	for _, path := range w.platforms {
+		if err = watcher.Add(path); err != nil {
+			w.logger.Warnf("Error when watch %s: %s", path, err.Error())
+		}
+	}
+	w.mu.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.mu.Lock()
+			w.watcher = nil
+			w.mu.Unlock()
+			return nil
+		case e, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			w.notify(e.Name)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Errorf("Error when watch credentials: %s", err.Error())
+		}
+	}
+}
+
+// notify send event for each platform that use the file
+func (w *credentialsWatcher) notify(file string) {
+	dir := filepath.Dir(file)
+
+	w.mu.Lock()
+	platforms := make([]types.NamespacedName, 0)
+	for platform, path := range w.platforms {
+		if path == dir || path == file {
+			platforms = append(platforms, platform)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, platform := range platforms {
+		w.logger.Debugf("Credentials change on %s for platform %s", file, platform.String())
+		e := event.GenericEvent{
+			Object: &centreoncrd.Platform{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: platform.Namespace,
+					Name:      platform.Name,
+				},
+			},
+		}
+		select {
+		case w.events <- e:
+		default:
+			go func() {
+				w.events <- e
+			}()
+		}
+	}
+}
+
+// removeIfUnused stop to watch path if no more platform use it
+// The lock must be held by the caller
+func (w *credentialsWatcher) removeIfUnused(path string) {
+	for _, p := range w.platforms {
+		if p == path {
+			return
+		}
+	}
+	if err := w.watcher.Remove(path); err != nil {
+		w.logger.Debugf("Error when unwatch %s: %s", path, err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+package platform
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCredentialsWatcher(t *testing.T) {
+	dir := t.TempDir()
+	w := newCredentialsWatcher(logrus.NewEntry(logrus.New()))
+	key := types.NamespacedName{Namespace: "default", Name: "p1"}
+
+	// Watch before start
+	assert.NoError(t, w.Watch(dir, key))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = w.Start(ctx)
+	}()
+
+	// Wait watcher is started
+	assert.Eventually(t, func() bool {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return w.watcher != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// When credentials change
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("pass"), 0600))
+	e := waitEvent(t, w.Events())
+	assert.Equal(t, "p1", e.Object.GetName())
+	assert.Equal(t, "default", e.Object.GetNamespace())
+
+	// Drain events generated by the same change
+	for len(w.Events()) > 0 {
+		<-w.Events()
+	}
+
+	// When platform is not more watched
+	w.Unwatch(key)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("pass2"), 0600))
+	assertNoEvent(t, w.Events())
+}
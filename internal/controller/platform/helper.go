@@ -3,6 +3,8 @@ package platform
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"os"
@@ -12,6 +14,7 @@ import (
 	"github.com/disaster37/go-centreon-rest/v21/models"
 	"github.com/disaster37/logredact"
 	monitorapi "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/common"
 	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
 	"github.com/disaster37/monitoring-operator/pkg/helpers"
 	"github.com/sirupsen/logrus"
@@ -22,20 +25,26 @@ import (
 )
 
 // GetClient premit to get client to connect on monitoring platform
-func GetClient(platformRef string, platforms map[string]*ComputedPlatform) (meta any, platform *monitorapi.Platform, err error) {
-	if platformRef == "" {
-		if p, ok := platforms["default"]; ok {
-			return p.Client, p.Platform, nil
-		}
+// It use the default platform of namespace when platformRef is empty and check that the namespace is allowed to use it
+func GetClient(platformRef string, namespace string, platforms *PlatformRegistry) (meta any, platform *monitorapi.Platform, err error) {
+	var (
+		p  *ComputedPlatform
+		ok bool
+	)
 
-		return nil, nil, errors.New("No default platform")
+	if platformRef == "" || platformRef == defaultPlatformKey {
+		if p, ok = platforms.GetDefault(namespace); !ok {
+			return nil, nil, errors.New("No default platform")
+		}
+	} else if p, ok = platforms.Get(platformRef); !ok {
+		return nil, nil, errors.Errorf("Platform %s not found", platformRef)
 	}
 
-	if p, ok := platforms[platformRef]; ok {
-		return p.Client, p.Platform, nil
+	if !p.Platform.IsAllowedNamespace(namespace) {
+		return nil, nil, errors.Errorf("Platform %s can't be used on namespace %s", p.Platform.Name, namespace)
 	}
 
-	return nil, nil, errors.Errorf("Platform %s not found", platformRef)
+	return p.ActiveClient(), p.Platform, nil
 }
 
 // ComputedPlatformList permit to get the list of coomputed platform object
@@ -59,33 +68,32 @@ func ComputedPlatformList(ctx context.Context, c client.Client, logger *logrus.E
 
 		switch p.Spec.PlatformType {
 		case "centreon":
-			// Get centreon secret
-			s := &corev1.Secret{}
-			k := types.NamespacedName{
-				Namespace: p.Namespace,
-				Name:      p.Spec.CentreonSettings.Secret,
+			credentials, err := getCentreonCredentials(ctx, c, &p)
+			if err != nil {
+				logger.Warnf("Error when get credentials of platform %s, skip it: %s", p.Name, err.Error())
+				continue
 			}
-			if err = c.Get(ctx, k, s); err != nil {
-				if k8serrors.IsNotFound(err) {
-					logger.Warnf("Secret %s not yet exist, skip it", p.Spec.CentreonSettings.Secret)
-					continue
-				}
+			tlsSettings, err := getCentreonTLSSettings(ctx, c, &p)
+			if err != nil {
+				logger.Warnf("Error when get TLS settings of platform %s, skip it: %s", p.Name, err.Error())
+				continue
 			}
-			cp, err := getComputedCentreonPlatform(&p, s, logger)
+			cp, err := getComputedCentreonPlatform(&p, credentials, tlsSettings, logger)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error when compute platform %s", p.Name)
 			}
-			// Test authentification
+			// Probe the platform. Platform is registered even if not ready, the health is reported on its status
 			if os.Getenv("TEST") != "true" {
-				if err = cp.Client.(centreonhandler.CentreonHandler).Auth(); err != nil {
-					logger.Errorf("Error when authentificate on platform %s, we skip it", p.Name)
-					continue
+				health := checkPlatformHealth(cp)
+				cp.SetHealth(health)
+				if !health.IsReady() {
+					logger.Errorf("Platform %s is not ready: %s", p.Name, health.Err.Error())
 				}
 			}
 
 			platforms[p.Name] = cp
 			if p.Spec.IsDefault {
-				platforms["default"] = cp
+				platforms[defaultPlatformKey] = cp
 			}
 
 		default:
@@ -96,30 +104,155 @@ func ComputedPlatformList(ctx context.Context, c client.Client, logger *logrus.E
 	return platforms, nil
 }
 
-func getComputedCentreonPlatform(p *monitorapi.Platform, s *corev1.Secret, log *logrus.Entry) (cp *ComputedPlatform, err error) {
-	var (
-		token    string
-		username string
-		password string
-	)
+// getCentreonCredentials permit to read the credentials to access on Centreon API
+// It read them from credentials path if provided, else from secret
+func getCentreonCredentials(ctx context.Context, c client.Client, p *monitorapi.Platform) (credentials *centreonhandler.Credentials, err error) {
+	if p.Spec.CentreonSettings.CredentialsPath != "" {
+		return centreonhandler.ReadCredentialsFromPath(p.Spec.CentreonSettings.CredentialsPath)
+	}
+
+	s := &corev1.Secret{}
+	k := types.NamespacedName{
+		Namespace: p.Namespace,
+		Name:      p.Spec.CentreonSettings.Secret,
+	}
+	if err = c.Get(ctx, k, s); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errors.Errorf("Secret %s not yet exist", p.Spec.CentreonSettings.Secret)
+		}
+		return nil, errors.Wrapf(err, "Error when get secret %s", p.Spec.CentreonSettings.Secret)
+	}
+
+	credentials, err = centreonhandler.NewCredentials(s.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error when read credentials on secret %s", s.Name)
+	}
+
+	return credentials, nil
+}
+
+// centreonTLSSettings is the TLS settings read from secrets and configmaps to access on Centreon API
+type centreonTLSSettings struct {
+	CA                []byte `json:"ca,omitempty"`
+	ClientCertificate []byte `json:"clientCertificate,omitempty"`
+	ClientKey         []byte `json:"clientKey,omitempty"`
+}
+
+// getCentreonTLSSettings permit to read the CA bundle and the client certificate to access on Centreon API
+func getCentreonTLSSettings(ctx context.Context, c client.Client, p *monitorapi.Platform) (tlsSettings *centreonTLSSettings, err error) {
+	tlsSettings = &centreonTLSSettings{}
+	settings := p.Spec.CentreonSettings
+
+	if settings.CASecretRef != nil {
+		s := &corev1.Secret{}
+		if err = c.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: settings.CASecretRef.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, "Error when get CA secret %s", settings.CASecretRef.Name)
+		}
+		if len(s.Data[settings.CASecretRef.Key]) == 0 {
+			return nil, errors.Errorf("Key %s not found on CA secret %s", settings.CASecretRef.Key, s.Name)
+		}
+		tlsSettings.CA = append(tlsSettings.CA, s.Data[settings.CASecretRef.Key]...)
+	}
+
+	if settings.CAConfigMapRef != nil {
+		cm := &corev1.ConfigMap{}
+		if err = c.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: settings.CAConfigMapRef.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, "Error when get CA configmap %s", settings.CAConfigMapRef.Name)
+		}
+		if cm.Data[settings.CAConfigMapRef.Key] == "" {
+			return nil, errors.Errorf("Key %s not found on CA configmap %s", settings.CAConfigMapRef.Key, cm.Name)
+		}
+		if len(tlsSettings.CA) > 0 {
+			tlsSettings.CA = append(tlsSettings.CA, '\n')
+		}
+		tlsSettings.CA = append(tlsSettings.CA, []byte(cm.Data[settings.CAConfigMapRef.Key])...)
+	}
+
+	if settings.ClientCertificateSecretRef != nil {
+		s := &corev1.Secret{}
+		if err = c.Get(ctx, types.NamespacedName{Namespace: p.Namespace, Name: settings.ClientCertificateSecretRef.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, "Error when get client certificate secret %s", settings.ClientCertificateSecretRef.Name)
+		}
+		tlsSettings.ClientCertificate = s.Data[corev1.TLSCertKey]
+		tlsSettings.ClientKey = s.Data[corev1.TLSPrivateKeyKey]
+		if len(tlsSettings.ClientCertificate) == 0 || len(tlsSettings.ClientKey) == 0 {
+			return nil, errors.Errorf("You need to set %s and %s on client certificate secret %s", corev1.TLSCertKey, corev1.TLSPrivateKeyKey, s.Name)
+		}
+	}
 
+	return tlsSettings, nil
+}
+
+func getComputedCentreonPlatform(p *monitorapi.Platform, credentials *centreonhandler.Credentials, tlsSettings *centreonTLSSettings, log *logrus.Entry) (cp *ComputedPlatform, err error) {
 	if p == nil {
 		return nil, errors.New("Platform can't be null")
 	}
-	if s == nil {
-		return nil, errors.New("Secret can't be null")
+	if credentials == nil {
+		return nil, errors.New("Credentials can't be null")
+	}
+	if tlsSettings == nil {
+		tlsSettings = &centreonTLSSettings{}
+	}
+	urls := p.GetCentreonURLs()
+	if len(urls) == 0 {
+		return nil, errors.New("You need to provide url or urls on Centreon settings")
 	}
 
-	if s.Data["token"] != nil {
-		token = string(s.Data["token"])
-	} else {
-		username = string(s.Data["username"])
-		password = string(s.Data["password"])
-		if username == "" || password == "" {
-			return nil, errors.Errorf("You need to set (username and password) or token on secret %s", s.Name)
+	cp = &ComputedPlatform{
+		Platform:        p,
+		CredentialsHash: credentials.Hash(),
+	}
+
+	// Create one client per endpoint
+	// The rate limit is for the platform, so the limiter is shared by all clients
+	rateLimiter := centreonhandler.NewRateLimiter(getRateLimitSettings(p))
+	endpoints := make([]*PlatformEndpoint, 0, len(urls))
+	cfgs := make([]*models.Config, 0, len(urls))
+	for _, url := range urls {
+		// Failover as soon as the active endpoint can't be reached
+		onConnectionError := func(err error) {
+			cp.onConnectionError(url, err, log)
+		}
+		handler, cfg, err := newCentreonHandler(p, url, credentials, tlsSettings, rateLimiter, onConnectionError, log)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error when create client for %s", url)
 		}
+		endpoints = append(endpoints, &PlatformEndpoint{
+			URL:    url,
+			Client: handler,
+		})
+		cfgs = append(cfgs, cfg)
+	}
+
+	shaByte, err := json.Marshal(struct {
+		Configs   []*models.Config              `json:"configs"`
+		TLS       *centreonTLSSettings          `json:"tls"`
+		Proxy     string                        `json:"proxy,omitempty"`
+		RateLimit *monitorapi.PlatformRateLimit `json:"rateLimit,omitempty"`
+	}{
+		Configs:   cfgs,
+		TLS:       tlsSettings,
+		Proxy:     p.Spec.CentreonSettings.Proxy,
+		RateLimit: p.Spec.RateLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sha := sha256.New()
+	if _, err := sha.Write([]byte(shaByte)); err != nil {
+		return nil, err
 	}
 
+	cp.Client = endpoints[0].Client
+	cp.Hash = hex.EncodeToString(sha.Sum(nil))
+	cp.Endpoints = endpoints
+
+	return cp, nil
+}
+
+// newCentreonHandler permit to create the Centreon handler to access on URL
+// It return the config used by client
+func newCentreonHandler(p *monitorapi.Platform, url string, credentials *centreonhandler.Credentials, tlsSettings *centreonTLSSettings, rateLimiter *centreonhandler.RateLimiter, onConnectionError func(err error), log *logrus.Entry) (handler centreonhandler.CentreonHandler, cfg *models.Config, err error) {
 	// Create client
 	secretHook := logredact.New([]string{`password=.*`, `Centreon-Auth-Token: .*`, `"authToken": ".+"`}, "***")
 	logger := log.WithField("component", "centreon-client")
@@ -127,33 +260,95 @@ func getComputedCentreonPlatform(p *monitorapi.Platform, s *corev1.Secret, log *
 	if p.IsDebug() {
 		logger.Logger.SetLevel(logrus.DebugLevel)
 	}
-	cfg := &models.Config{
-		Address:          p.Spec.CentreonSettings.URL,
-		Username:         username,
-		Password:         password,
-		Token:            token,
+	cfg = &models.Config{
+		Address:          url,
+		Username:         credentials.Username,
+		Password:         credentials.Password,
+		Token:            credentials.Token,
 		DisableVerifySSL: p.Spec.CentreonSettings.SelfSignedCertificate,
 		Debug:            p.IsDebug(),
 		Logger:           log.WithField("component", "centreon-client"),
 	}
+	if p.Spec.CentreonSettings.Timeout != nil {
+		cfg.Timeout = p.Spec.CentreonSettings.Timeout.Duration
+	}
 
-	client, err := centreon.NewClient(cfg)
-	if err != nil {
-		return nil, errors.Wrap(err, "Error when create Centreon client")
+	if len(tlsSettings.CA) > 0 && !p.Spec.CentreonSettings.SelfSignedCertificate {
+		if !x509.NewCertPool().AppendCertsFromPEM(tlsSettings.CA) {
+			return nil, nil, errors.New("Error when read CA bundle, it need to be PEM encoded")
+		}
+	}
+	var certificates []tls.Certificate
+	if len(tlsSettings.ClientCertificate) > 0 {
+		cert, err := tls.X509KeyPair(tlsSettings.ClientCertificate, tlsSettings.ClientKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "Error when read client certificate")
+		}
+		certificates = append(certificates, cert)
 	}
+	// The client can be created again when the credentials change
+	newClient := func(cfg *models.Config) (*centreon.Client, error) {
+		client, err := centreon.NewClient(cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error when create Centreon client")
+		}
 
-	shaByte, err := json.Marshal(cfg)
+		// Set TLS and proxy settings
+		restyClient := client.API.Client()
+		if len(tlsSettings.CA) > 0 && !p.Spec.CentreonSettings.SelfSignedCertificate {
+			restyClient.SetRootCertificateFromString(string(tlsSettings.CA))
+		}
+		if len(certificates) > 0 {
+			restyClient.SetCertificates(certificates...)
+		}
+		if p.Spec.CentreonSettings.Proxy != "" {
+			restyClient.SetProxy(p.Spec.CentreonSettings.Proxy)
+		}
+
+		// Notify when API can't be reached and limit the requests on API
+		centreonhandler.SetOnConnectionError(client, onConnectionError)
+		centreonhandler.SetRateLimit(client, rateLimiter)
+
+		return client, nil
+	}
+
+	// Reload credentials from files when need to authenticate
+	if p.Spec.CentreonSettings.CredentialsPath != "" {
+		path := p.Spec.CentreonSettings.CredentialsPath
+		handler, err = centreonhandler.NewCentreonHandlerWithCredentials(cfg, newClient, func() (*centreonhandler.Credentials, error) {
+			return centreonhandler.ReadCredentialsFromPath(path)
+		}, log)
+		if err != nil {
+			return nil, nil, err
+		}
+		return handler, cfg, nil
+	}
+
+	client, err := newClient(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	sha := sha256.New()
-	if _, err := sha.Write([]byte(shaByte)); err != nil {
-		return nil, err
+
+	return centreonhandler.NewCentreonHandler(client, log), cfg, nil
+}
+
+// getRateLimitSettings permit to get the client side limits from platform
+func getRateLimitSettings(p *monitorapi.Platform) *centreonhandler.RateLimitSettings {
+	settings := &centreonhandler.RateLimitSettings{
+		MaxRetries: centreonhandler.DefaultMaxRetries,
+		OnThrottle: func(reason string) {
+			common.PlatformThrottledRequests.WithLabelValues(p.Namespace, p.Name, reason).Inc()
+		},
+	}
+
+	if p.Spec.RateLimit != nil {
+		settings.QPS = float64(p.Spec.RateLimit.QPS)
+		settings.Burst = int(p.Spec.RateLimit.Burst)
+		settings.MaxInFlight = int(p.Spec.RateLimit.MaxInFlight)
+		if p.Spec.RateLimit.MaxRetries != nil {
+			settings.MaxRetries = int(*p.Spec.RateLimit.MaxRetries)
+		}
 	}
 
-	return &ComputedPlatform{
-		Client:   centreonhandler.NewCentreonHandler(client, log),
-		Platform: p,
-		Hash:     hex.EncodeToString(sha.Sum(nil)),
-	}, nil
+	return settings
 }
@@ -0,0 +1,176 @@
+package platform
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	monitorapi "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestCertificate generate self signed certificate and key (PEM)
+func newTestCertificate(t *testing.T) (cert []byte, key []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestGetComputedCentreonPlatformWithTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"web": {"version": "21.10.4"}}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	clientCert, clientKey := newTestCertificate(t)
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "default"},
+				Data:       map[string]string{"ca.crt": string(ca)},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "default"},
+				Data:       map[string][]byte{corev1.TLSCertKey: clientCert, corev1.TLSPrivateKeyKey: clientKey},
+			},
+		).
+		Build()
+
+	p := &monitorapi.Platform{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "default"},
+		Spec: monitorapi.PlatformSpec{
+			PlatformType: "centreon",
+			CentreonSettings: &monitorapi.PlatformSpecCentreonSettings{
+				URL:     server.URL + "/centreon/api/index.php",
+				Timeout: &metav1.Duration{Duration: 5 * time.Second},
+			},
+		},
+	}
+	credentials := &centreonhandler.Credentials{Token: "token"}
+	logger := logrus.NewEntry(logrus.New())
+
+	// When CA is not trusted
+	cp, err := getComputedCentreonPlatform(p, credentials, nil, logger)
+	assert.NoError(t, err)
+	_, err = cp.Client.(centreonhandler.CentreonHandler).GetVersion()
+	assert.Error(t, err)
+
+	// When CA is trusted but without client certificate
+	p.Spec.CentreonSettings.CAConfigMapRef = &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "ca"},
+		Key:                  "ca.crt",
+	}
+	tlsSettings, err := getCentreonTLSSettings(context.Background(), c, p)
+	assert.NoError(t, err)
+	cp, err = getComputedCentreonPlatform(p, credentials, tlsSettings, logger)
+	assert.NoError(t, err)
+	_, err = cp.Client.(centreonhandler.CentreonHandler).GetVersion()
+	assert.Error(t, err)
+	hash := cp.Hash
+
+	// With mTLS
+	p.Spec.CentreonSettings.ClientCertificateSecretRef = &corev1.LocalObjectReference{Name: "client"}
+	tlsSettings, err = getCentreonTLSSettings(context.Background(), c, p)
+	assert.NoError(t, err)
+	cp, err = getComputedCentreonPlatform(p, credentials, tlsSettings, logger)
+	assert.NoError(t, err)
+	version, err := cp.Client.(centreonhandler.CentreonHandler).GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "21.10.4", version)
+	assert.NotEqual(t, hash, cp.Hash)
+
+	// When CA key not exist
+	p.Spec.CentreonSettings.CAConfigMapRef.Key = "bad"
+	_, err = getCentreonTLSSettings(context.Background(), c, p)
+	assert.Error(t, err)
+
+	// When CA is not PEM
+	_, err = getComputedCentreonPlatform(p, credentials, &centreonTLSSettings{CA: []byte("bad")}, logger)
+	assert.Error(t, err)
+}
+
+func TestGetComputedCentreonPlatformWithURLs(t *testing.T) {
+	p := &monitorapi.Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "p1",
+			Namespace: "default",
+		},
+		Spec: monitorapi.PlatformSpec{
+			PlatformType: "centreon",
+			CentreonSettings: &monitorapi.PlatformSpecCentreonSettings{
+				URLs: []string{"https://primary/centreon/api/index.php", "https://secondary/centreon/api/index.php"},
+			},
+		},
+	}
+	credentials := &centreonhandler.Credentials{Token: "token"}
+	logger := logrus.NewEntry(logrus.New())
+
+	cp, err := getComputedCentreonPlatform(p, credentials, nil, logger)
+	assert.NoError(t, err)
+	assert.Len(t, cp.Endpoints, 2)
+	assert.Equal(t, "https://primary/centreon/api/index.php", cp.ActiveEndpoint().URL)
+	assert.Equal(t, cp.Client, cp.ActiveClient())
+	hash := cp.Hash
+
+	// Switch on secondary
+	assert.True(t, cp.SetActiveEndpoint("https://secondary/centreon/api/index.php"))
+	assert.Equal(t, cp.Endpoints[1].Client, cp.ActiveClient())
+	assert.False(t, cp.SetActiveEndpoint("https://unknown"))
+	assert.Equal(t, cp.Endpoints[1].Client, cp.ActiveClient())
+
+	// Hash change when endpoints change
+	p.Spec.CentreonSettings.URLs = []string{"https://primary/centreon/api/index.php"}
+	cp, err = getComputedCentreonPlatform(p, credentials, nil, logger)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, cp.Hash)
+
+	// When no URL
+	p.Spec.CentreonSettings.URLs = nil
+	_, err = getComputedCentreonPlatform(p, credentials, nil, logger)
+	assert.Error(t, err)
+}
@@ -1,9 +1,6 @@
 package platform
 
 import (
-	"os"
-
-	"emperror.dev/errors"
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
@@ -13,10 +10,10 @@ import (
 type platformApiClient struct {
 	*controller.BasicRemoteExternalReconciler[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler]
 	logger    *logrus.Entry
-	platforms map[string]*ComputedPlatform
+	platforms *PlatformRegistry
 }
 
-func newPlaformApiClient(client centreonhandler.CentreonHandler, logger *logrus.Entry, platforms map[string]*ComputedPlatform) controller.RemoteExternalReconciler[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler] {
+func newPlaformApiClient(client centreonhandler.CentreonHandler, logger *logrus.Entry, platforms *PlatformRegistry) controller.RemoteExternalReconciler[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler] {
 	return &platformApiClient{
 		BasicRemoteExternalReconciler: controller.NewBasicRemoteExternalReconciler[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler](client),
 		logger:                        logger,
@@ -31,21 +28,22 @@ func (h *platformApiClient) Build(o *centreoncrd.Platform) (p *ComputedPlatform,
 }
 
 func (h *platformApiClient) Get(o *centreoncrd.Platform) (object *ComputedPlatform, err error) {
-	object = h.platforms[o.Name]
+	object, _ = h.platforms.Get(o.Name)
 
 	return object, nil
 }
 
 func (h *platformApiClient) Create(object *ComputedPlatform, o *centreoncrd.Platform) (err error) {
-	if os.Getenv("TEST") != "true" {
-		if err = object.Client.(centreonhandler.CentreonHandler).Auth(); err != nil {
-			return errors.Wrapf(err, "Error when authentificate on platform %s", o.Name)
+	// Keep the last health check result and the active endpoint until the next probe
+	if current, ok := h.platforms.Get(o.Name); ok && current != object {
+		if health := current.Health(); health != nil {
+			object.SetHealth(health)
+		}
+		if endpoint := current.ActiveEndpoint(); endpoint != nil {
+			object.SetActiveEndpoint(endpoint.URL)
 		}
 	}
-	if o.Spec.IsDefault {
-		h.platforms["default"] = object
-	}
-	h.platforms[o.Name] = object
+	h.platforms.Add(object)
 
 	h.logger.Infof("Add platform '%s'", o.Name)
 	if o.Spec.IsDefault {
@@ -60,10 +58,7 @@ func (h *platformApiClient) Update(object *ComputedPlatform, o *centreoncrd.Plat
 }
 
 func (h *platformApiClient) Delete(o *centreoncrd.Platform) (err error) {
-	if o.Spec.IsDefault {
-		delete(h.platforms, "default")
-	}
-	delete(h.platforms, o.Name)
+	h.platforms.Remove(o.Name)
 
 	h.logger.Infof("Remove platform '%s'", o.Name)
 
@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -48,10 +49,14 @@ type PlatformReconciler struct {
 	controller.Controller
 	controller.RemoteReconciler[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler]
 	controller.RemoteReconcilerAction[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler]
-	name string
+	name               string
+	credentialsWatcher *credentialsWatcher
+	orphanScanner      *orphanScanner
 }
 
-func NewPlatformReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder, platforms map[string]*ComputedPlatform) controller.Controller {
+func NewPlatformReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder, platforms *PlatformRegistry, clusterID string) controller.Controller {
+	credentialsWatcher := newCredentialsWatcher(logger.WithField("component", "credentials-watcher"))
+
 	return &PlatformReconciler{
 		Controller: controller.NewBasicController(),
 		RemoteReconciler: controller.NewBasicRemoteReconciler[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler](
@@ -66,8 +71,11 @@ func NewPlatformReconciler(client client.Client, logger *logrus.Entry, recorder
 			client,
 			recorder,
 			platforms,
+			credentialsWatcher,
 		),
-		name: plaformName,
+		name:               plaformName,
+		credentialsWatcher: credentialsWatcher,
+		orphanScanner:      newOrphanScanner(client, recorder, platforms, clusterID, logger.WithField("component", "orphan-scanner")),
 	}
 }
 
@@ -76,6 +84,7 @@ func NewPlatformReconciler(client client.Client, logger *logrus.Entry, recorder
 //+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=platforms/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -101,10 +110,19 @@ func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 // SetupWithManager sets up the controller with the Manager.
 func (h *PlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(h.credentialsWatcher); err != nil {
+		return err
+	}
+	if err := mgr.Add(h.orphanScanner); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(h.name).
 		For(&centreoncrd.Platform{}).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(watchCentreonPlatformSecret(h.Client()))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(watchCentreonPlatformConfigMap(h.Client()))).
+		WatchesRawSource(source.Channel(h.credentialsWatcher.Events(), &handler.EnqueueRequestForObject{})).
 		WithEventFilter(viewOperatorNamespacePredicate()).
 		WithOptions(k8scontroller.Options{
 			RateLimiter: helper.DefaultControllerRateLimiter[reconcile.Request](),
@@ -133,16 +151,28 @@ func viewOperatorNamespacePredicate() predicate.Predicate {
 	}
 }
 
-// watchPlatformSecret permit to update client if platform secret change
+// watchCentreonPlatformSecret permit to update client if platform secret change
+// It use the index `spec.centreonSettings.secret`
 func watchCentreonPlatformSecret(c client.Client) handler.MapFunc {
+	return watchCentreonPlatformReference(c, "spec.centreonSettings.secret")
+}
+
+// watchCentreonPlatformConfigMap permit to update client if platform configmap change
+// It use the index `spec.centreonSettings.configMap`
+func watchCentreonPlatformConfigMap(c client.Client) handler.MapFunc {
+	return watchCentreonPlatformReference(c, "spec.centreonSettings.configMap")
+}
+
+// watchCentreonPlatformReference permit to reconcile the platforms that reference the object with the index
+func watchCentreonPlatformReference(c client.Client, index string) handler.MapFunc {
 	return func(ctx context.Context, a client.Object) []reconcile.Request {
 		reconcileRequests := make([]reconcile.Request, 0)
 		listPlatforms := &centreoncrd.PlatformList{}
 
-		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.centreonSettings.secret=%s", a.GetName()))
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("%s=%s", index, a.GetName()))
 
-		// Get all platforms that use the current secret
-		if err := c.List(context.Background(), listPlatforms, &client.ListOptions{Namespace: a.GetNamespace(), FieldSelector: fs}); err != nil {
+		// Get all platforms that use the current object
+		if err := c.List(ctx, listPlatforms, &client.ListOptions{Namespace: a.GetNamespace(), FieldSelector: fs}); err != nil {
 			panic(err)
 		}
 
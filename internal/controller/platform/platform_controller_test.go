@@ -99,7 +99,7 @@ func doCreatePlatformStep() test.TestStep {
 			if err != nil {
 				t.Fatal(err)
 			}
-			platforms := d.(map[string]*ComputedPlatform)
+			platforms := d.(*PlatformRegistry)
 
 			isTimeout, err := test.RunWithTimeout(func() error {
 				if err := c.Get(context.Background(), key, p); err != nil {
@@ -115,11 +115,11 @@ func doCreatePlatformStep() test.TestStep {
 				t.Fatalf("Failed to get Platform: %s", err.Error())
 			}
 
-			assert.NotEmpty(t, platforms[key.Name])
-			assert.NotNil(t, platforms[key.Name].Client)
-			assert.NotEmpty(t, platforms[key.Name].Hash)
+			assert.NotEmpty(t, platforms.List()[key.Name])
+			assert.NotNil(t, platforms.List()[key.Name].Client)
+			assert.NotEmpty(t, platforms.List()[key.Name].Hash)
 
-			data["platform"] = platforms[key.Name]
+			data["platform"] = platforms.List()[key.Name]
 			return nil
 		},
 	}
@@ -155,7 +155,7 @@ func doUpdatePlatformStep() test.TestStep {
 			if err != nil {
 				t.Fatal(err)
 			}
-			platforms := d.(map[string]*ComputedPlatform)
+			platforms := d.(*PlatformRegistry)
 
 			d, err = helper.Get(data, "platform")
 			if err != nil {
@@ -178,9 +178,9 @@ func doUpdatePlatformStep() test.TestStep {
 				t.Fatalf("Failed to get platform: %s", err.Error())
 			}
 
-			assert.Equal(t, "http://localhost2", platforms[key.Name].Platform.Spec.CentreonSettings.URL)
-			assert.NotEqual(t, platforms[key.Name].Hash, platform.Hash)
-			assert.NotEqual(t, platforms[key.Name].Client, platform.Client)
+			assert.Equal(t, "http://localhost2", platforms.List()[key.Name].Platform.Spec.CentreonSettings.URL)
+			assert.NotEqual(t, platforms.List()[key.Name].Hash, platform.Hash)
+			assert.NotEqual(t, platforms.List()[key.Name].Client, platform.Client)
 			return nil
 		},
 	}
@@ -216,7 +216,7 @@ func doUpdatePlatformSecretStep() test.TestStep {
 			if err != nil {
 				t.Fatal(err)
 			}
-			platforms := d.(map[string]*ComputedPlatform)
+			platforms := d.(*PlatformRegistry)
 
 			d, err = helper.Get(data, "platform")
 			if err != nil {
@@ -229,7 +229,7 @@ func doUpdatePlatformSecretStep() test.TestStep {
 					t.Fatalf("Error when get Centreon service: %s", err.Error())
 				}
 
-				if platforms[key.Name].Client == platform.Client {
+				if platforms.List()[key.Name].Client == platform.Client {
 					return errors.New("Not yet updated")
 				}
 
@@ -239,8 +239,8 @@ func doUpdatePlatformSecretStep() test.TestStep {
 				t.Fatalf("Failed to get platform: %s", err.Error())
 			}
 
-			assert.Equal(t, "http://localhost2", platforms[key.Name].Platform.Spec.CentreonSettings.URL)
-			assert.NotEqual(t, platforms[key.Name].Client, platform.Client)
+			assert.Equal(t, "http://localhost2", platforms.List()[key.Name].Platform.Spec.CentreonSettings.URL)
+			assert.NotEqual(t, platforms.List()[key.Name].Client, platform.Client)
 			return nil
 		},
 	}
@@ -297,7 +297,7 @@ func doDeletePlatformStep() test.TestStep {
 			if err != nil {
 				t.Fatal(err)
 			}
-			platforms := d.(map[string]*ComputedPlatform)
+			platforms := d.(*PlatformRegistry)
 
 			// Object can be deleted or marked as deleted
 			isTimeout, err := test.RunWithTimeout(func() error {
@@ -316,7 +316,7 @@ func doDeletePlatformStep() test.TestStep {
 				t.Fatalf("Platform not deleted: %s", err.Error())
 			}
 			assert.True(t, isDeleted)
-			assert.Nil(t, platforms[key.Name])
+			assert.Nil(t, platforms.List()[key.Name])
 
 			return nil
 		},
@@ -0,0 +1,245 @@
+package platform
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/common"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+const (
+	AuthenticatedCondition string = "Authenticated"
+
+	// failoverProbeInterval is the min duration between two probes triggered by connection errors
+	failoverProbeInterval = 10 * time.Second
+)
+
+// PlatformHealth is the result of platform health check
+type PlatformHealth struct {
+	// IsAuthenticated is true when the authentification succeed
+	IsAuthenticated bool
+
+	// IsReachable is true when the platform API answer
+	IsReachable bool
+
+	// Version is the platform version
+	Version string
+
+	// Latency is the duration of health check
+	Latency time.Duration
+
+	// Err is the error that cause the platform to be not ready
+	Err error
+
+	// Endpoint is the URL of endpoint that has been checked
+	Endpoint string
+}
+
+// IsReady return true if the platform can be used
+func (h *PlatformHealth) IsReady() bool {
+	return h.IsAuthenticated && h.IsReachable
+}
+
+// checkPlatformHealth permit to probe the platform API
+// It check the authentification, the latency and get the platform version.
+// When the active endpoint is not ready, it try the other endpoints in order and switch on the first ready.
+// The selected endpoint is sticky: it is kept until it is not ready.
+func checkPlatformHealth(cp *ComputedPlatform) *PlatformHealth {
+	active := cp.ActiveEndpoint()
+	if active == nil {
+		return checkClientHealth(cp.Client)
+	}
+
+	health := checkClientHealth(active.Client)
+	health.Endpoint = active.URL
+	if health.IsReady() {
+		return health
+	}
+
+	// Failover on other endpoints
+	for _, endpoint := range cp.Endpoints {
+		if endpoint == active {
+			continue
+		}
+		h := checkClientHealth(endpoint.Client)
+		h.Endpoint = endpoint.URL
+		if h.IsReady() {
+			cp.SetActiveEndpoint(endpoint.URL)
+			return h
+		}
+	}
+
+	return health
+}
+
+// onConnectionError permit to failover without waiting the next health check when the active endpoint can't be reached
+// The endpoints are probed on background, and at most one time per failoverProbeInterval
+func (h *ComputedPlatform) onConnectionError(url string, err error, logger *logrus.Entry) {
+	if !h.needFailoverProbe(url, time.Now()) {
+		return
+	}
+	logger.Warnf("Endpoint %s of platform %s can't be reached, probe the endpoints: %s", url, h.Platform.Name, err.Error())
+
+	go func() {
+		health := checkPlatformHealth(h)
+		h.SetHealth(health)
+		if health.Endpoint != url {
+			logger.Warnf("Platform %s switch on endpoint %s", h.Platform.Name, health.Endpoint)
+		}
+	}()
+}
+
+// needFailoverProbe return true if the endpoints need to be probed after connection error on url
+// Only the errors of the active endpoint are handled, because the other endpoints are not used
+func (h *ComputedPlatform) needFailoverProbe(url string, now time.Time) bool {
+	if len(h.Endpoints) < 2 {
+		return false
+	}
+	if active := h.ActiveEndpoint(); active == nil || active.URL != url {
+		return false
+	}
+
+	probedAt := h.failoverProbedAt.Load()
+	if now.UnixNano()-probedAt < int64(failoverProbeInterval) {
+		return false
+	}
+
+	return h.failoverProbedAt.CompareAndSwap(probedAt, now.UnixNano())
+}
+
+// checkClientHealth permit to probe the API with the client
+func checkClientHealth(client any) *PlatformHealth {
+	health := &PlatformHealth{}
+
+	switch c := client.(type) {
+	case centreonhandler.CentreonHandler:
+		start := time.Now()
+		if err := c.Auth(); err != nil {
+			health.Latency = time.Since(start)
+			health.Err = errors.Wrap(err, "Error when authentificate on platform")
+			return health
+		}
+		health.IsAuthenticated = true
+
+		version, err := c.GetVersion()
+		health.Latency = time.Since(start)
+		if err != nil {
+			health.Err = errors.Wrap(err, "Error when get platform version")
+			return health
+		}
+		health.IsReachable = true
+		health.Version = version
+	default:
+		health.Err = errors.Errorf("Client of type %T is not supported", client)
+	}
+
+	return health
+}
+
+// setHealthStatus permit to set the health check result on platform status
+// It return true if the ready state change
+func setHealthStatus(p *centreoncrd.Platform, health *PlatformHealth) (isChanged bool) {
+	conditions := p.Status.GetConditions()
+	isReady := meta.IsStatusConditionTrue(conditions, controller.ReadyCondition.String())
+
+	if health.IsAuthenticated {
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:   AuthenticatedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: "Authenticated",
+		})
+	} else {
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:    AuthenticatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "AuthenticationFailed",
+			Message: health.Err.Error(),
+		})
+	}
+
+	if health.IsReady() {
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:   controller.ReadyCondition.String(),
+			Status: metav1.ConditionTrue,
+			Reason: "Ready",
+		})
+		p.Status.Version = health.Version
+	} else {
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:    controller.ReadyCondition.String(),
+			Status:  metav1.ConditionFalse,
+			Reason:  "HealthCheckFailed",
+			Message: health.Err.Error(),
+		})
+	}
+	p.Status.SetConditions(conditions)
+	if health.Endpoint != "" {
+		p.Status.ActiveEndpoint = health.Endpoint
+	}
+
+	// Set prometheus metrics
+	if health.IsReady() {
+		common.PlatformUp.WithLabelValues(p.Namespace, p.Name).Set(1)
+	} else {
+		common.PlatformUp.WithLabelValues(p.Namespace, p.Name).Set(0)
+	}
+	common.PlatformLatency.WithLabelValues(p.Namespace, p.Name).Set(health.Latency.Seconds())
+	for _, url := range p.GetCentreonURLs() {
+		if url == p.Status.ActiveEndpoint {
+			common.PlatformActiveEndpoint.WithLabelValues(p.Namespace, p.Name, url).Set(1)
+		} else {
+			common.PlatformActiveEndpoint.WithLabelValues(p.Namespace, p.Name, url).Set(0)
+		}
+	}
+
+	return isReady != health.IsReady()
+}
+
+// NewDefaultPlatformChecker permit to get readiness checker that reflect if default platform is ready
+// The operator is ready when there are no default platform
+// Only the leader compute the platforms, so the replica that is not elected is always ready
+func NewDefaultPlatformChecker(c client.Client, platforms *PlatformRegistry, elected <-chan struct{}) healthz.Checker {
+	return func(req *http.Request) error {
+		select {
+		case <-elected:
+		default:
+			return nil
+		}
+
+		if cp, ok := platforms.Get(defaultPlatformKey); ok {
+			if health := cp.Health(); health != nil && !health.IsReady() {
+				return errors.Wrapf(health.Err, "Default platform %s is not ready", cp.Platform.Name)
+			}
+			return nil
+		}
+
+		// Check if default platform is expected
+		ns, err := helpers.GetOperatorNamespace()
+		if err != nil {
+			return err
+		}
+		platformList := &centreoncrd.PlatformList{}
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.isDefault=%s", helpers.BoolToString(ptr.To(true))))
+		if err = c.List(req.Context(), platformList, &client.ListOptions{Namespace: ns, FieldSelector: fs}); err != nil {
+			return errors.Wrap(err, "Error when read default platform")
+		}
+		if len(platformList.Items) > 0 {
+			return errors.Errorf("Default platform %s is not yet available", platformList.Items[0].Name)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,225 @@
+package platform
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	monitorapi "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"github.com/disaster37/monitoring-operator/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckPlatformHealth(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	cp := newTestComputedPlatform("p1", true, "hash1")
+	cp.Client = mockCentreon
+
+	// When platform is ready
+	mockCentreon.EXPECT().Auth().Return(nil)
+	mockCentreon.EXPECT().GetVersion().Return("21.10.4", nil)
+	health := checkPlatformHealth(cp)
+	assert.True(t, health.IsReady())
+	assert.True(t, health.IsAuthenticated)
+	assert.Equal(t, "21.10.4", health.Version)
+	assert.NoError(t, health.Err)
+
+	// When authentification failed
+	mockCentreon.EXPECT().Auth().Return(errors.New("Bad credentials"))
+	health = checkPlatformHealth(cp)
+	assert.False(t, health.IsReady())
+	assert.False(t, health.IsAuthenticated)
+	assert.Error(t, health.Err)
+
+	// When API is not reachable
+	mockCentreon.EXPECT().Auth().Return(nil)
+	mockCentreon.EXPECT().GetVersion().Return("", errors.New("Timeout"))
+	health = checkPlatformHealth(cp)
+	assert.False(t, health.IsReady())
+	assert.True(t, health.IsAuthenticated)
+	assert.Error(t, health.Err)
+
+	// When client is not supported
+	cp.Client = "fake"
+	health = checkPlatformHealth(cp)
+	assert.False(t, health.IsReady())
+	assert.Error(t, health.Err)
+}
+
+func TestCheckPlatformHealthWithFailover(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockPrimary := mocks.NewMockCentreonHandler(mockCtrl)
+	mockSecondary := mocks.NewMockCentreonHandler(mockCtrl)
+	cp := newTestComputedPlatform("p1", true, "hash1")
+	cp.Client = mockPrimary
+	cp.Endpoints = []*PlatformEndpoint{
+		{URL: "https://primary", Client: mockPrimary},
+		{URL: "https://secondary", Client: mockSecondary},
+	}
+
+	// When primary is ready
+	mockPrimary.EXPECT().Auth().Return(nil)
+	mockPrimary.EXPECT().GetVersion().Return("21.10.4", nil)
+	health := checkPlatformHealth(cp)
+	assert.True(t, health.IsReady())
+	assert.Equal(t, "https://primary", health.Endpoint)
+	assert.Equal(t, mockPrimary, cp.ActiveClient())
+
+	// When primary is down, it switch on secondary
+	mockPrimary.EXPECT().Auth().Return(errors.New("Timeout"))
+	mockSecondary.EXPECT().Auth().Return(nil)
+	mockSecondary.EXPECT().GetVersion().Return("21.10.4", nil)
+	health = checkPlatformHealth(cp)
+	assert.True(t, health.IsReady())
+	assert.Equal(t, "https://secondary", health.Endpoint)
+	assert.Equal(t, mockSecondary, cp.ActiveClient())
+
+	// When primary come back, it keep the secondary
+	mockSecondary.EXPECT().Auth().Return(nil)
+	mockSecondary.EXPECT().GetVersion().Return("21.10.4", nil)
+	health = checkPlatformHealth(cp)
+	assert.True(t, health.IsReady())
+	assert.Equal(t, "https://secondary", health.Endpoint)
+
+	// When all endpoints are down, it keep the active endpoint
+	mockSecondary.EXPECT().Auth().Return(errors.New("Timeout"))
+	mockPrimary.EXPECT().Auth().Return(errors.New("Timeout"))
+	health = checkPlatformHealth(cp)
+	assert.False(t, health.IsReady())
+	assert.Equal(t, "https://secondary", health.Endpoint)
+	assert.Equal(t, mockSecondary, cp.ActiveClient())
+}
+
+func TestOnConnectionError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockPrimary := mocks.NewMockCentreonHandler(mockCtrl)
+	mockSecondary := mocks.NewMockCentreonHandler(mockCtrl)
+	cp := newTestComputedPlatform("p1", true, "hash1")
+	cp.Client = mockPrimary
+	cp.Endpoints = []*PlatformEndpoint{
+		{URL: "https://primary", Client: mockPrimary},
+		{URL: "https://secondary", Client: mockSecondary},
+	}
+	now := time.Now()
+
+	// Errors of endpoint that is not active are ignored
+	assert.False(t, cp.needFailoverProbe("https://secondary", now))
+
+	// Only one probe per interval
+	assert.True(t, cp.needFailoverProbe("https://primary", now))
+	assert.False(t, cp.needFailoverProbe("https://primary", now.Add(time.Second)))
+	assert.True(t, cp.needFailoverProbe("https://primary", now.Add(failoverProbeInterval)))
+
+	// It switch on secondary without waiting the next health check
+	cp.failoverProbedAt.Store(0)
+	mockPrimary.EXPECT().Auth().Return(errors.New("connection refused"))
+	mockSecondary.EXPECT().Auth().Return(nil)
+	mockSecondary.EXPECT().GetVersion().Return("21.10.4", nil)
+	cp.onConnectionError("https://primary", errors.New("connection refused"), logrus.NewEntry(logrus.New()))
+	assert.Eventually(t, func() bool {
+		return cp.Health() != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, cp.Health().IsReady())
+	assert.Equal(t, mockSecondary, cp.ActiveClient())
+
+	// When platform has only one endpoint
+	cp.Endpoints = cp.Endpoints[:1]
+	cp.active.Store(nil)
+	cp.failoverProbedAt.Store(0)
+	assert.False(t, cp.needFailoverProbe("https://primary", now))
+}
+
+func TestSetHealthStatus(t *testing.T) {
+	p := &monitorapi.Platform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "p1",
+			Namespace: "default",
+		},
+	}
+
+	// When platform is ready
+	isChanged := setHealthStatus(p, &PlatformHealth{IsAuthenticated: true, IsReachable: true, Version: "21.10.4"})
+	assert.True(t, isChanged)
+	assert.True(t, meta.IsStatusConditionTrue(p.Status.Conditions, controller.ReadyCondition.String()))
+	assert.True(t, meta.IsStatusConditionTrue(p.Status.Conditions, AuthenticatedCondition))
+	assert.Equal(t, "21.10.4", p.Status.Version)
+
+	// When nothing change
+	isChanged = setHealthStatus(p, &PlatformHealth{IsAuthenticated: true, IsReachable: true, Version: "21.10.4"})
+	assert.False(t, isChanged)
+
+	// When authentification failed
+	isChanged = setHealthStatus(p, &PlatformHealth{Err: errors.New("Bad credentials")})
+	assert.True(t, isChanged)
+	assert.True(t, meta.IsStatusConditionFalse(p.Status.Conditions, controller.ReadyCondition.String()))
+	assert.True(t, meta.IsStatusConditionFalse(p.Status.Conditions, AuthenticatedCondition))
+	assert.Equal(t, "21.10.4", p.Status.Version)
+
+	// When endpoint is checked
+	setHealthStatus(p, &PlatformHealth{IsAuthenticated: true, IsReachable: true, Version: "21.10.4", Endpoint: "https://secondary"})
+	assert.Equal(t, "https://secondary", p.Status.ActiveEndpoint)
+}
+
+func TestNewDefaultPlatformChecker(t *testing.T) {
+	_ = os.Setenv("POD_NAMESPACE", "default")
+	defer os.Unsetenv("POD_NAMESPACE")
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = monitorapi.AddToScheme(scheme)
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithIndex(&monitorapi.Platform{}, "spec.isDefault", func(o client.Object) []string {
+				return []string{helpers.BoolToString(&o.(*monitorapi.Platform).Spec.IsDefault)}
+			}).
+			Build()
+	}
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	elected := make(chan struct{})
+	close(elected)
+
+	// When there are no default platform
+	checker := NewDefaultPlatformChecker(newClient(), NewPlatformRegistry(map[string]*ComputedPlatform{}), elected)
+	assert.NoError(t, checker(req))
+
+	// When default platform is not yet available
+	p := newTestComputedPlatform("p1", true, "hash1")
+	checker = NewDefaultPlatformChecker(newClient(p.Platform), NewPlatformRegistry(map[string]*ComputedPlatform{}), elected)
+	assert.Error(t, checker(req))
+
+	// When replica is not the leader
+	checker = NewDefaultPlatformChecker(newClient(p.Platform), NewPlatformRegistry(map[string]*ComputedPlatform{}), make(chan struct{}))
+	assert.NoError(t, checker(req))
+
+	// When default platform is not yet checked
+	registry := NewPlatformRegistry(map[string]*ComputedPlatform{})
+	registry.Add(p)
+	checker = NewDefaultPlatformChecker(newClient(p.Platform), registry, elected)
+	assert.NoError(t, checker(req))
+
+	// When default platform is ready
+	p.SetHealth(&PlatformHealth{IsAuthenticated: true, IsReachable: true})
+	assert.NoError(t, checker(req))
+
+	// When default platform is not ready
+	p.SetHealth(&PlatformHealth{Err: errors.New("Bad credentials")})
+	assert.Error(t, checker(req))
+}
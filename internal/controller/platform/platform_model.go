@@ -1,9 +1,81 @@
 package platform
 
-import centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+import (
+	"sync/atomic"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+)
+
+// PlatformEndpoint is the client to access on one URL of platform
+type PlatformEndpoint struct {
+	URL    string
+	Client any
+}
 
 type ComputedPlatform struct {
+	// Client is the client of primary endpoint
 	Client   any
 	Platform *centreoncrd.Platform
 	Hash     string
+
+	// Endpoints is the ordered list of endpoints. The first is the primary
+	Endpoints []*PlatformEndpoint
+
+	// CredentialsHash is the hash of credentials used by client
+	CredentialsHash string
+
+	// health is the result of the last health check
+	health atomic.Pointer[PlatformHealth]
+
+	// active is the endpoint currently used
+	active atomic.Pointer[PlatformEndpoint]
+
+	// failoverProbedAt is the time, in Unix nano, of the last probe triggered by connection errors
+	failoverProbedAt atomic.Int64
+}
+
+// Health return the result of the last health check
+// It return nil if the platform is not yet checked
+func (h *ComputedPlatform) Health() *PlatformHealth {
+	return h.health.Load()
+}
+
+// SetHealth permit to store the result of the health check
+func (h *ComputedPlatform) SetHealth(health *PlatformHealth) {
+	h.health.Store(health)
+}
+
+// ActiveEndpoint return the endpoint currently used
+// It return the primary endpoint when no failover occurs, or nil if there are no endpoints
+func (h *ComputedPlatform) ActiveEndpoint() *PlatformEndpoint {
+	if e := h.active.Load(); e != nil {
+		return e
+	}
+	if len(h.Endpoints) > 0 {
+		return h.Endpoints[0]
+	}
+
+	return nil
+}
+
+// ActiveClient return the client of endpoint currently used
+func (h *ComputedPlatform) ActiveClient() any {
+	if e := h.ActiveEndpoint(); e != nil {
+		return e.Client
+	}
+
+	return h.Client
+}
+
+// SetActiveEndpoint permit to switch on endpoint by URL
+// It return false if the endpoint not exist
+func (h *ComputedPlatform) SetActiveEndpoint(url string) bool {
+	for _, e := range h.Endpoints {
+		if e.URL == url {
+			h.active.Store(e)
+			return true
+		}
+	}
+
+	return false
 }
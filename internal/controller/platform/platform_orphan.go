@@ -0,0 +1,297 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"emperror.dev/errors"
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/internal/controller/common"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// orphanScanPeriod is the period to check the platforms where orphan services need to be scanned
+	orphanScanPeriod time.Duration = time.Minute
+)
+
+// serviceKey return the unique key of service on platform
+func serviceKey(host, name string) string {
+	return fmt.Sprintf("%s/%s", host, name)
+}
+
+// isOrphanScanDue return true if the orphan services need to be scanned
+func isOrphanScanDue(p *centreoncrd.Platform, now time.Time) bool {
+	if !p.IsOrphanDetectionEnabled() {
+		return false
+	}
+
+	return p.Status.LastOrphanScan == nil || !now.Before(p.Status.LastOrphanScan.Add(p.GetOrphanScanInterval()))
+}
+
+// isTargetPlatform return true if the platform ref used by resource on namespace resolve to the platform
+func isTargetPlatform(platformRef string, namespace string, p *centreoncrd.Platform, platforms *PlatformRegistry) bool {
+	if platformRef == p.Name {
+		return true
+	}
+	if platformRef != defaultPlatformKey {
+		return false
+	}
+
+	cp, ok := platforms.GetDefault(namespace)
+	return ok && cp.Platform != nil && cp.Platform.Name == p.Name && cp.Platform.Namespace == p.Namespace
+}
+
+// getReferencedServices return the keys of services handled by CentreonService resources on the platform
+// It use the identity from status, that is the service really created, and the identity from spec, that is the service expected
+func getReferencedServices(ctx context.Context, c client.Client, p *centreoncrd.Platform, platforms *PlatformRegistry) (referenced map[string]struct{}, err error) {
+	csList := &centreoncrd.CentreonServiceList{}
+	if err = c.List(ctx, csList); err != nil {
+		return nil, errors.Wrap(err, "Error when list CentreonService")
+	}
+
+	referenced = map[string]struct{}{}
+	add := func(host, name string) {
+		if host != "" && name != "" {
+			referenced[serviceKey(host, name)] = struct{}{}
+		}
+	}
+
+	for _, cs := range csList.Items {
+		for _, platformRef := range cs.GetPlatforms() {
+			if !isTargetPlatform(platformRef, cs.Namespace, p, platforms) {
+				continue
+			}
+
+			add(cs.GetHost(), cs.GetExternalName())
+			if platformRef == cs.GetPlatform() {
+				add(cs.Status.Host, cs.Status.ServiceName)
+			}
+			if status := centreoncrd.GetPlatformRefStatus(cs.Status.Platforms, platformRef); status != nil {
+				add(status.Host, status.ExternalName)
+			}
+		}
+	}
+
+	return referenced, nil
+}
+
+// findOrphanServices return the services on platform that are managed by the operator on this cluster and that are not referenced
+// The service is managed when its owner is on this cluster, or when it has no owner but the comment set by the operator
+// Only the service with owner is flagged as owned, the comment can be set by another cluster or by hand
+// It keep the detection time of services already known as orphan
+func findOrphanServices(handler centreonhandler.CentreonHandler, clusterID string, comment string, referenced map[string]struct{}, previous []centreoncrd.PlatformOrphan, now metav1.Time) (orphans []centreoncrd.PlatformOrphan, err error) {
+	services, err := handler.ListServices()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when list services")
+	}
+
+	orphans = make([]centreoncrd.PlatformOrphan, 0)
+	for _, service := range services {
+		if _, ok := referenced[serviceKey(service.Host, service.Name)]; ok {
+			continue
+		}
+
+		owner, err := handler.GetServiceOwner(service.Host, service.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error when get owner of service %s/%s", service.Host, service.Name)
+		}
+		if owner != nil && owner.ClusterID != clusterID {
+			continue
+		}
+		if owner == nil {
+			serviceComment, err := handler.GetServiceComment(service.Host, service.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error when get comment of service %s/%s", service.Host, service.Name)
+			}
+			if serviceComment != comment {
+				continue
+			}
+		}
+
+		orphan := centreoncrd.PlatformOrphan{
+			Host:       service.Host,
+			Name:       service.Name,
+			DetectedAt: now,
+			Owned:      owner != nil,
+		}
+		if i := slices.IndexFunc(previous, func(item centreoncrd.PlatformOrphan) bool {
+			return item.Host == service.Host && item.Name == service.Name
+		}); i >= 0 {
+			orphan.DetectedAt = previous[i].DetectedAt
+		}
+		orphans = append(orphans, orphan)
+	}
+
+	return orphans, nil
+}
+
+// deleteOrphanServices permit to delete the owned orphan services when the grace period is expired
+// The orphan services without owner are never deleted
+// It return the orphan services that are not deleted
+func deleteOrphanServices(handler centreonhandler.CentreonHandler, p *centreoncrd.Platform, orphans []centreoncrd.PlatformOrphan, now time.Time, recorder record.EventRecorder, logger *logrus.Entry) (remaining []centreoncrd.PlatformOrphan, err error) {
+	errs := make([]error, 0)
+	remaining = make([]centreoncrd.PlatformOrphan, 0, len(orphans))
+
+	for _, orphan := range orphans {
+		if !orphan.Owned || now.Sub(orphan.DetectedAt.Time) < p.GetOrphanGracePeriod() {
+			remaining = append(remaining, orphan)
+			continue
+		}
+
+		if err = handler.DeleteService(orphan.Host, orphan.Name); err != nil {
+			errs = append(errs, errors.Wrapf(err, "Error when delete orphan service %s/%s", orphan.Host, orphan.Name))
+			remaining = append(remaining, orphan)
+			continue
+		}
+		common.PlatformOrphanServicesDeleted.WithLabelValues(p.Namespace, p.Name).Inc()
+		recorder.Eventf(p, corev1.EventTypeNormal, "OrphanDeleted", "Orphan service %s/%s is deleted from platform %s", orphan.Host, orphan.Name, p.Name)
+		logger.Infof("Orphan service %s/%s is deleted from platform %s", orphan.Host, orphan.Name, p.Name)
+	}
+
+	return remaining, utilerrors.NewAggregate(errs)
+}
+
+// resetOrphanServices permit to clean the orphan services from status when the orphan detection is disabled
+func resetOrphanServices(p *centreoncrd.Platform) {
+	p.Status.LastOrphanScan = nil
+	p.Status.Orphans = nil
+	common.PlatformOrphanServices.DeleteLabelValues(p.Namespace, p.Name)
+}
+
+// reconcileOrphanServices permit to scan the platform to find the orphan services, report them on status and delete them if needed
+func reconcileOrphanServices(ctx context.Context, c client.Client, recorder record.EventRecorder, p *centreoncrd.Platform, cp *ComputedPlatform, platforms *PlatformRegistry, clusterID string, logger *logrus.Entry) (err error) {
+	handler, ok := cp.ActiveClient().(centreonhandler.CentreonHandler)
+	if !ok {
+		return errors.Errorf("Client of type %T is not supported", cp.ActiveClient())
+	}
+
+	referenced, err := getReferencedServices(ctx, c, p, platforms)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	orphans, err := findOrphanServices(handler, clusterID, p.GetManagedComment(), referenced, p.Status.Orphans, now)
+	if err != nil {
+		return err
+	}
+	for _, orphan := range orphans {
+		if !orphan.DetectedAt.Equal(&now) {
+			continue
+		}
+		if !orphan.Owned && p.Spec.OrphanDetection.Delete {
+			recorder.Eventf(p, corev1.EventTypeWarning, "OrphanDetected", "Service %s/%s on platform %s is not handled anymore by any resource, it is not deleted because it has no owner", orphan.Host, orphan.Name, p.Name)
+			logger.Warnf("Service %s/%s on platform %s is not handled anymore by any resource, it is not deleted because it has no owner", orphan.Host, orphan.Name, p.Name)
+			continue
+		}
+		recorder.Eventf(p, corev1.EventTypeWarning, "OrphanDetected", "Service %s/%s on platform %s is not handled anymore by any resource", orphan.Host, orphan.Name, p.Name)
+		logger.Warnf("Service %s/%s on platform %s is not handled anymore by any resource", orphan.Host, orphan.Name, p.Name)
+	}
+
+	if p.Spec.OrphanDetection.Delete {
+		orphans, err = deleteOrphanServices(handler, p, orphans, now.Time, recorder, logger)
+	}
+
+	p.Status.LastOrphanScan = &now
+	p.Status.Orphans = orphans
+	common.PlatformOrphanServices.WithLabelValues(p.Namespace, p.Name).Set(float64(len(orphans)))
+
+	return err
+}
+
+// orphanScanner permit to scan periodically the platforms to find the orphan services
+// It run outside of the platform reconcile, because the scan call the platform API for each service
+type orphanScanner struct {
+	client    client.Client
+	recorder  record.EventRecorder
+	platforms *PlatformRegistry
+	clusterID string
+	period    time.Duration
+	logger    *logrus.Entry
+}
+
+func newOrphanScanner(client client.Client, recorder record.EventRecorder, platforms *PlatformRegistry, clusterID string, logger *logrus.Entry) *orphanScanner {
+	return &orphanScanner{
+		client:    client,
+		recorder:  recorder,
+		platforms: platforms,
+		clusterID: clusterID,
+		period:    orphanScanPeriod,
+		logger:    logger,
+	}
+}
+
+// NeedLeaderElection implement manager.LeaderElectionRunnable
+// Only the leader compute the platforms, so only it can scan them
+func (s *orphanScanner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implement manager.Runnable
+// It scan the platforms until the context is done
+func (s *orphanScanner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.scan(ctx)
+		}
+	}
+}
+
+// scan permit to scan each platform where the scan is due
+func (s *orphanScanner) scan(ctx context.Context) {
+	for name, cp := range s.platforms.List() {
+		if name == defaultPlatformKey || cp.Platform == nil {
+			continue
+		}
+		if err := s.scanPlatform(ctx, cp); err != nil {
+			s.logger.Errorf("Error when scan orphan services on platform %s: %s", name, err.Error())
+		}
+	}
+}
+
+// scanPlatform permit to scan the platform when it is ready and to store the orphan services on its status
+func (s *orphanScanner) scanPlatform(ctx context.Context, cp *ComputedPlatform) (err error) {
+	p := &centreoncrd.Platform{}
+	if err = s.client.Get(ctx, client.ObjectKeyFromObject(cp.Platform), p); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "Error when get platform %s", cp.Platform.Name)
+	}
+	if !isOrphanScanDue(p, time.Now()) {
+		return nil
+	}
+	if health := cp.Health(); health == nil || !health.IsReady() {
+		return nil
+	}
+
+	logger := s.logger.WithField("platform", p.Name)
+	original := p.DeepCopy()
+	if err = reconcileOrphanServices(ctx, s.client, s.recorder, p, cp, s.platforms, s.clusterID, logger); err != nil {
+		s.recorder.Eventf(p, corev1.EventTypeWarning, "OrphanScanFailed", "Error when scan orphan services on platform %s: %s", p.Name, err.Error())
+		logger.Warnf("Error when scan orphan services on platform %s: %s", p.Name, err.Error())
+	}
+
+	// The status is patched even on error, to keep the orphan services already deleted out of it
+	if err = s.client.Status().Patch(ctx, p, client.MergeFrom(original)); err != nil {
+		return errors.Wrapf(err, "Error when update status of platform %s", p.Name)
+	}
+
+	return nil
+}
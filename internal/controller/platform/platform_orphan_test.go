@@ -0,0 +1,225 @@
+package platform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	monitorapi "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsOrphanScanDue(t *testing.T) {
+	now := time.Now()
+	p := &monitorapi.Platform{}
+
+	// When disabled
+	assert.False(t, isOrphanScanDue(p, now))
+
+	// When never scanned
+	p.Spec.OrphanDetection = &monitorapi.PlatformOrphanDetection{Enabled: true}
+	assert.True(t, isOrphanScanDue(p, now))
+
+	// When scanned recently
+	p.Status.LastOrphanScan = &metav1.Time{Time: now.Add(-10 * time.Minute)}
+	assert.False(t, isOrphanScanDue(p, now))
+
+	// When interval is expired
+	p.Status.LastOrphanScan = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+	assert.True(t, isOrphanScanDue(p, now))
+}
+
+func TestGetReferencedServices(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = monitorapi.AddToScheme(scheme)
+
+	p1 := newTestComputedPlatform("p1", true, "hash1")
+	p2 := newTestComputedPlatform("p2", false, "hash2")
+	p2.Platform.Spec.DefaultForNamespaces = []string{"team-b"}
+	platforms := NewPlatformRegistry(map[string]*ComputedPlatform{
+		"p1":               p1,
+		"p2":               p2,
+		defaultPlatformKey: p1,
+	})
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			// Explicit platform, already created
+			&monitorapi.CentreonService{
+				ObjectMeta: metav1.ObjectMeta{Name: "s1", Namespace: "team-a"},
+				Spec:       monitorapi.CentreonServiceSpec{PlatformRef: "p1", Host: "central", Name: "s1"},
+				Status:     monitorapi.CentreonServiceStatus{Host: "central", ServiceName: "s1-old"},
+			},
+			// Default platform
+			&monitorapi.CentreonService{
+				ObjectMeta: metav1.ObjectMeta{Name: "s2", Namespace: "team-a"},
+				Spec:       monitorapi.CentreonServiceSpec{Host: "central", Name: "s2"},
+			},
+			// Default platform of namespace is p2
+			&monitorapi.CentreonService{
+				ObjectMeta: metav1.ObjectMeta{Name: "s3", Namespace: "team-b"},
+				Spec:       monitorapi.CentreonServiceSpec{Host: "central", Name: "s3"},
+			},
+			// Mirrored on p1
+			&monitorapi.CentreonService{
+				ObjectMeta: metav1.ObjectMeta{Name: "s4", Namespace: "team-b"},
+				Spec:       monitorapi.CentreonServiceSpec{PlatformRef: "p2", PlatformRefs: []string{"p1"}, Host: "central", Name: "s4"},
+				Status: monitorapi.CentreonServiceStatus{
+					Platforms: []monitorapi.PlatformRefStatus{
+						{Name: "p1", Host: "poller", ExternalName: "s4"},
+					},
+				},
+			},
+		).
+		Build()
+
+	referenced, err := getReferencedServices(context.Background(), c, p1.Platform, platforms)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{
+		"central/s1":     {},
+		"central/s1-old": {},
+		"central/s2":     {},
+		"central/s4":     {},
+		"poller/s4":      {},
+	}, referenced)
+
+	referenced, err = getReferencedServices(context.Background(), c, p2.Platform, platforms)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{
+		"central/s3": {},
+		"central/s4": {},
+	}, referenced)
+}
+
+func TestFindOrphanServices(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+
+	now := metav1.Now()
+	detectedAt := metav1.NewTime(now.Add(-time.Hour))
+	referenced := map[string]struct{}{"central/s1": {}}
+
+	mockCentreon.EXPECT().ListServices().Return([]*centreonhandler.CentreonService{
+		{Host: "central", Name: "s1"},
+		{Host: "central", Name: "s2"},
+		{Host: "central", Name: "s3"},
+		{Host: "central", Name: "s5"},
+		{Host: "central", Name: "manual"},
+	}, nil)
+	mockCentreon.EXPECT().GetServiceOwner("central", "s2").Return(nil, nil)
+	mockCentreon.EXPECT().GetServiceComment("central", "s2").Return(monitorapi.DefaultComment, nil)
+	mockCentreon.EXPECT().GetServiceOwner("central", "s3").Return(&centreonhandler.Owner{ClusterID: "cluster1", Namespace: "default", Name: "s3"}, nil)
+	mockCentreon.EXPECT().GetServiceOwner("central", "s5").Return(&centreonhandler.Owner{ClusterID: "cluster2", Namespace: "default", Name: "s5"}, nil)
+	mockCentreon.EXPECT().GetServiceOwner("central", "manual").Return(nil, nil)
+	mockCentreon.EXPECT().GetServiceComment("central", "manual").Return("", nil)
+
+	orphans, err := findOrphanServices(mockCentreon, "cluster1", monitorapi.DefaultComment, referenced, []monitorapi.PlatformOrphan{{Host: "central", Name: "s3", DetectedAt: detectedAt}}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []monitorapi.PlatformOrphan{
+		{Host: "central", Name: "s2", DetectedAt: now},
+		{Host: "central", Name: "s3", DetectedAt: detectedAt, Owned: true},
+	}, orphans)
+
+	// When error
+	mockCentreon.EXPECT().ListServices().Return(nil, errors.New("boom"))
+	_, err = findOrphanServices(mockCentreon, "cluster1", monitorapi.DefaultComment, referenced, nil, now)
+	assert.Error(t, err)
+}
+
+func TestDeleteOrphanServices(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	recorder := record.NewFakeRecorder(10)
+	logger := logrus.NewEntry(logrus.New())
+
+	now := time.Now()
+	p := &monitorapi.Platform{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "default"},
+		Spec: monitorapi.PlatformSpec{
+			OrphanDetection: &monitorapi.PlatformOrphanDetection{
+				Enabled:     true,
+				Delete:      true,
+				GracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	orphans := []monitorapi.PlatformOrphan{
+		{Host: "central", Name: "recent", DetectedAt: metav1.NewTime(now.Add(-10 * time.Minute))},
+		{Host: "central", Name: "expired", DetectedAt: metav1.NewTime(now.Add(-2 * time.Hour))},
+		{Host: "central", Name: "failed", DetectedAt: metav1.NewTime(now.Add(-2 * time.Hour)), Owned: true},
+		{Host: "central", Name: "comment", DetectedAt: metav1.NewTime(now.Add(-2 * time.Hour))},
+	}
+	orphans[1].Owned = true
+
+	mockCentreon.EXPECT().DeleteService("central", "expired").Return(nil)
+	mockCentreon.EXPECT().DeleteService("central", "failed").Return(errors.New("boom"))
+
+	remaining, err := deleteOrphanServices(mockCentreon, p, orphans, now, recorder, logger)
+	assert.Error(t, err)
+	assert.Equal(t, []monitorapi.PlatformOrphan{orphans[0], orphans[2], orphans[3]}, remaining)
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestOrphanScannerScanPlatform(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCentreon := mocks.NewMockCentreonHandler(mockCtrl)
+	recorder := record.NewFakeRecorder(10)
+	logger := logrus.NewEntry(logrus.New())
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = monitorapi.AddToScheme(scheme)
+
+	cp := newTestComputedPlatform("p1", true, "hash1")
+	cp.Client = mockCentreon
+	cp.Platform.Spec.OrphanDetection = &monitorapi.PlatformOrphanDetection{Enabled: true, Delete: true}
+	platforms := NewPlatformRegistry(map[string]*ComputedPlatform{
+		"p1":               cp,
+		defaultPlatformKey: cp,
+	})
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&monitorapi.Platform{}).
+		WithObjects(cp.Platform.DeepCopy()).
+		Build()
+	s := newOrphanScanner(c, recorder, platforms, "cluster1", logger)
+
+	// When platform is not yet checked
+	assert.NoError(t, s.scanPlatform(context.Background(), cp))
+
+	// When platform is ready
+	cp.SetHealth(&PlatformHealth{IsAuthenticated: true, IsReachable: true})
+	mockCentreon.EXPECT().ListServices().Return([]*centreonhandler.CentreonService{
+		{Host: "central", Name: "owned"},
+		{Host: "central", Name: "comment"},
+	}, nil)
+	mockCentreon.EXPECT().GetServiceOwner("central", "owned").Return(&centreonhandler.Owner{ClusterID: "cluster1", Namespace: "default", Name: "owned"}, nil)
+	mockCentreon.EXPECT().GetServiceOwner("central", "comment").Return(nil, nil)
+	mockCentreon.EXPECT().GetServiceComment("central", "comment").Return(monitorapi.DefaultComment, nil)
+	assert.NoError(t, s.scanPlatform(context.Background(), cp))
+
+	p := &monitorapi.Platform{}
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cp.Platform), p))
+	assert.NotNil(t, p.Status.LastOrphanScan)
+	assert.Len(t, p.Status.Orphans, 2)
+	assert.Len(t, recorder.Events, 2)
+
+	// When scan is not due
+	assert.NoError(t, s.scanPlatform(context.Background(), cp))
+}
@@ -2,6 +2,7 @@ package platform
 
 import (
 	"context"
+	"os"
 
 	"emperror.dev/errors"
 	"github.com/disaster37/generic-objectmatcher/patch"
@@ -13,7 +14,6 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -22,18 +22,20 @@ import (
 
 type platformReconciler struct {
 	controller.RemoteReconcilerAction[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler]
-	name      string
-	platforms map[string]*ComputedPlatform
+	name               string
+	platforms          *PlatformRegistry
+	credentialsWatcher *credentialsWatcher
 }
 
-func newPlatformReconciler(name string, client client.Client, recorder record.EventRecorder, platforms map[string]*ComputedPlatform) controller.RemoteReconcilerAction[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler] {
+func newPlatformReconciler(name string, client client.Client, recorder record.EventRecorder, platforms *PlatformRegistry, credentialsWatcher *credentialsWatcher) controller.RemoteReconcilerAction[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler] {
 	return &platformReconciler{
 		RemoteReconcilerAction: controller.NewRemoteReconcilerAction[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler](
 			client,
 			recorder,
 		),
-		name:      name,
-		platforms: platforms,
+		name:               name,
+		platforms:          platforms,
+		credentialsWatcher: credentialsWatcher,
 	}
 }
 
@@ -53,20 +55,26 @@ func (h *platformReconciler) Read(ctx context.Context, o object.RemoteObject, da
 
 	switch p.Spec.PlatformType {
 	case "centreon":
-		// Get secret
-		s := &corev1.Secret{}
-		k := types.NamespacedName{
-			Namespace: p.Namespace,
-			Name:      p.Spec.CentreonSettings.Secret,
-		}
-		if err = h.Client().Get(ctx, k, s); err != nil {
-			if k8serrors.IsNotFound(err) {
-				logger.Warnf("Secret %s not yet exist, try later", p.Spec.CentreonSettings.Secret)
-				return nil, res, errors.Errorf("Secret %s not yet exist", p.Spec.CentreonSettings.Secret)
+		// Watch credentials files to reload them when they change
+		if p.Spec.CentreonSettings.CredentialsPath != "" {
+			if err = h.credentialsWatcher.Watch(p.Spec.CentreonSettings.CredentialsPath, types.NamespacedName{Namespace: p.Namespace, Name: p.Name}); err != nil {
+				logger.Warnf("Error when watch credentials path %s: %s", p.Spec.CentreonSettings.CredentialsPath, err.Error())
 			}
+		} else {
+			h.credentialsWatcher.Unwatch(types.NamespacedName{Namespace: p.Namespace, Name: p.Name})
+		}
+
+		credentials, err := getCentreonCredentials(ctx, h.Client(), p)
+		if err != nil {
+			return nil, res, errors.Wrapf(err, "Error when get credentials of platform %s", p.Name)
+		}
+
+		tlsSettings, err := getCentreonTLSSettings(ctx, h.Client(), p)
+		if err != nil {
+			return nil, res, errors.Wrapf(err, "Error when get TLS settings of platform %s", p.Name)
 		}
 
-		computedPlatform, err := getComputedCentreonPlatform(p, s, logger)
+		computedPlatform, err := getComputedCentreonPlatform(p, credentials, tlsSettings, logger)
 		if err != nil {
 			return nil, res, errors.Wrapf(err, "Error when compute platform %s", p.Name)
 		}
@@ -91,6 +99,8 @@ func (h *platformReconciler) Delete(ctx context.Context, o object.RemoteObject,
 	// Set prometheus Metrics
 	common.ControllerInstances.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
 
+	h.credentialsWatcher.Unwatch(types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()})
+
 	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
 }
 
@@ -105,7 +115,48 @@ func (h *platformReconciler) OnSuccess(ctx context.Context, o object.RemoteObjec
 	// Reset the current cluster errors
 	common.ControllerErrors.WithLabelValues(h.name, o.GetNamespace(), o.GetName()).Set(0)
 
-	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil {
+		return res, err
+	}
+
+	p := o.(*centreoncrd.Platform)
+	cp, ok := h.platforms.Get(p.Name)
+	if !ok {
+		return res, nil
+	}
+	p.Status.CredentialsHash = cp.CredentialsHash
+	if os.Getenv("TEST") == "true" {
+		return res, nil
+	}
+
+	// Probe the platform API
+	previousEndpoint := p.Status.ActiveEndpoint
+	health := checkPlatformHealth(cp)
+	cp.SetHealth(health)
+	if previousEndpoint != "" && health.Endpoint != "" && previousEndpoint != health.Endpoint {
+		h.Recorder().Eventf(o, corev1.EventTypeWarning, "EndpointChanged", "Platform %s switch from endpoint %s to %s", p.Name, previousEndpoint, health.Endpoint)
+		logger.Warnf("Platform %s switch from endpoint %s to %s", p.Name, previousEndpoint, health.Endpoint)
+	}
+	if isChanged := setHealthStatus(p, health); isChanged {
+		if health.IsReady() {
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "PlatformReady", "Platform %s is ready (version %s)", p.Name, health.Version)
+		} else {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "PlatformNotReady", "Platform %s is not ready: %s", p.Name, health.Err.Error())
+		}
+	}
+	if !health.IsReady() {
+		logger.Warnf("Platform %s is not ready: %s", p.Name, health.Err.Error())
+	}
+
+	res.RequeueAfter = p.GetHealthCheckInterval()
+
+	// The orphan services are scanned by the orphan scanner, outside of reconcile
+	if !p.IsOrphanDetectionEnabled() {
+		resetOrphanServices(p)
+	}
+
+	return res, nil
 }
 
 func (h *platformReconciler) Diff(ctx context.Context, o object.RemoteObject, read controller.RemoteRead[*ComputedPlatform], data map[string]any, handler controller.RemoteExternalReconciler[*centreoncrd.Platform, *ComputedPlatform, centreonhandler.CentreonHandler], logger *logrus.Entry, ignoreDiff ...patch.CalculateOption) (diff controller.RemoteDiff[*ComputedPlatform], res ctrl.Result, err error) {
@@ -122,7 +173,12 @@ func (h *platformReconciler) Diff(ctx context.Context, o object.RemoteObject, re
 	// Client change
 	if read.GetCurrentObject().Hash != read.GetExpectedObject().Hash {
 		diff.SetObjectToUpdate(read.GetExpectedObject())
-		diff.AddDiff("Secret change on platform")
+		if read.GetCurrentObject().CredentialsHash != read.GetExpectedObject().CredentialsHash {
+			diff.AddDiff("Credentials change on platform")
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "CredentialsChanged", "Credentials of platform %s change, the client is recreated", o.GetName())
+		} else {
+			diff.AddDiff("Client settings change on platform")
+		}
 		return diff, res, nil
 	}
 
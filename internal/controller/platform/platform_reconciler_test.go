@@ -0,0 +1,88 @@
+package platform
+
+import (
+	"context"
+	"testing"
+
+	monitorapi "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPlatformReconcilerDiff(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	logger := logrus.NewEntry(logrus.New())
+	r := newPlatformReconciler("platform", fake.NewClientBuilder().Build(), recorder, NewPlatformRegistry(map[string]*ComputedPlatform{}), newCredentialsWatcher(logger))
+	p := newTestComputedPlatform("p1", true, "hash1")
+	p.CredentialsHash = "credentials1"
+
+	// When new platform
+	read := controller.NewBasicRemoteRead[*ComputedPlatform]()
+	read.SetExpectedObject(p)
+	diff, _, err := r.Diff(context.Background(), p.Platform, read, nil, nil, logger)
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedCreate())
+
+	// When nothing change
+	read.SetCurrentObject(p)
+	diff, _, err = r.Diff(context.Background(), p.Platform, read, nil, nil, logger)
+	assert.NoError(t, err)
+	assert.False(t, diff.IsDiff())
+	assert.Empty(t, recorder.Events)
+
+	// When credentials are rotated
+	expected := newTestComputedPlatform("p1", true, "hash2")
+	expected.CredentialsHash = "credentials2"
+	read.SetExpectedObject(expected)
+	diff, _, err = r.Diff(context.Background(), p.Platform, read, nil, nil, logger)
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "CredentialsChanged")
+
+	// When other client settings change
+	expected = newTestComputedPlatform("p1", true, "hash3")
+	expected.CredentialsHash = "credentials1"
+	read.SetExpectedObject(expected)
+	diff, _, err = r.Diff(context.Background(), p.Platform, read, nil, nil, logger)
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Empty(t, recorder.Events)
+}
+
+func TestWatchCentreonPlatformSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = monitorapi.AddToScheme(scheme)
+
+	p1 := newTestComputedPlatform("p1", true, "hash1").Platform
+	p1.Spec.CentreonSettings = &monitorapi.PlatformSpecCentreonSettings{Secret: "centreon"}
+	p2 := newTestComputedPlatform("p2", false, "hash2").Platform
+	p2.Spec.CentreonSettings = &monitorapi.PlatformSpecCentreonSettings{Secret: "other"}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(p1, p2).
+		WithIndex(&monitorapi.Platform{}, "spec.centreonSettings.secret", func(o client.Object) []string {
+			return []string{o.(*monitorapi.Platform).Spec.CentreonSettings.Secret}
+		}).
+		Build()
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "centreon",
+			Namespace: "default",
+		},
+	}
+	requests := watchCentreonPlatformSecret(c)(context.Background(), s)
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "p1"}}}, requests)
+}
@@ -0,0 +1,203 @@
+package platform
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	// defaultPlatformKey is the key used to store the default platform
+	defaultPlatformKey string = "default"
+
+	// subscriberBufferSize is the size of channel buffer of each subscriber
+	subscriberBufferSize int = 100
+)
+
+// PlatformRegistry store the computed platforms and can be shared between controllers
+// It's safe for concurrent use
+type PlatformRegistry struct {
+	mu          sync.RWMutex
+	platforms   map[string]*ComputedPlatform
+	subscribers []*subscriber
+}
+
+// NewPlatformRegistry permit to init the registry from the computed platform list
+func NewPlatformRegistry(platforms map[string]*ComputedPlatform) *PlatformRegistry {
+	r := &PlatformRegistry{
+		platforms:   make(map[string]*ComputedPlatform, len(platforms)),
+		subscribers: make([]*subscriber, 0),
+	}
+	for name, cp := range platforms {
+		r.platforms[name] = cp
+	}
+
+	return r
+}
+
+// Get permit to get computed platform by name
+// Use `default` to get the default platform
+func (r *PlatformRegistry) Get(name string) (cp *ComputedPlatform, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cp, ok = r.platforms[name]
+	return cp, ok
+}
+
+// GetDefault permit to get the default computed platform of namespace
+// It return the platform set as default for the namespace, else the platform set as default
+// When multiple platforms are set as default for the namespace, the first one by name is used, so it is always the same
+func (r *PlatformRegistry) GetDefault(namespace string) (cp *ComputedPlatform, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, name := range slices.Sorted(maps.Keys(r.platforms)) {
+		p := r.platforms[name]
+		if name != defaultPlatformKey && p.Platform != nil && p.Platform.IsDefaultForNamespace(namespace) {
+			return p, true
+		}
+	}
+
+	cp, ok = r.platforms[defaultPlatformKey]
+	return cp, ok
+}
+
+// List permit to get a copy of all computed platforms
+func (r *PlatformRegistry) List() map[string]*ComputedPlatform {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	platforms := make(map[string]*ComputedPlatform, len(r.platforms))
+	for name, cp := range r.platforms {
+		platforms[name] = cp
+	}
+
+	return platforms
+}
+
+// Add permit to add or replace the computed platform
+// It's also registered as default platform if needed
+// Subscribers are notified when platform is new or when the client settings or the spec change
+func (r *PlatformRegistry) Add(cp *ComputedPlatform) {
+	if cp == nil || cp.Platform == nil {
+		return
+	}
+	name := cp.Platform.Name
+
+	r.mu.Lock()
+	current, isExist := r.platforms[name]
+	r.platforms[name] = cp
+	if cp.Platform.Spec.IsDefault {
+		r.platforms[defaultPlatformKey] = cp
+	} else if d, ok := r.platforms[defaultPlatformKey]; ok && d.Platform != nil && d.Platform.Name == name {
+		delete(r.platforms, defaultPlatformKey)
+	}
+	subscribers := r.subscribers
+	r.mu.Unlock()
+
+	if !isExist || current.Hash != cp.Hash || !equality.Semantic.DeepEqual(current.Platform.Spec, cp.Platform.Spec) {
+		r.notify(subscribers, cp)
+	}
+}
+
+// Remove permit to remove the computed platform
+// It's also unregistered as default platform if needed
+func (r *PlatformRegistry) Remove(name string) {
+	r.mu.Lock()
+	current, isExist := r.platforms[name]
+	delete(r.platforms, name)
+	if d, ok := r.platforms[defaultPlatformKey]; ok && d.Platform != nil && d.Platform.Name == name {
+		delete(r.platforms, defaultPlatformKey)
+	}
+	subscribers := r.subscribers
+	r.mu.Unlock()
+
+	if isExist {
+		r.notify(subscribers, current)
+	}
+}
+
+// Subscribe permit to be notified when platform is added, when it change or when it's removed
+// The event object is the Platform. The channel can be used as source on controller
+func (r *PlatformRegistry) Subscribe() <-chan event.GenericEvent {
+	s := newSubscriber()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Copy on write to not share the slice with notifications in progress
+	subscribers := make([]*subscriber, 0, len(r.subscribers)+1)
+	subscribers = append(subscribers, r.subscribers...)
+	r.subscribers = append(subscribers, s)
+
+	return s.ch
+}
+
+// notify send the event to all subscribers without blocking the caller
+func (r *PlatformRegistry) notify(subscribers []*subscriber, cp *ComputedPlatform) {
+	if cp == nil || cp.Platform == nil {
+		return
+	}
+
+	for _, s := range subscribers {
+		s.push(event.GenericEvent{Object: cp.Platform.DeepCopy()})
+	}
+}
+
+// subscriber is the channel of subscriber and the events not yet sent on it
+// The pending events are coalesced by platform, so a slow subscriber only get the last state of each platform
+type subscriber struct {
+	ch      chan event.GenericEvent
+	mu      sync.Mutex
+	pending []event.GenericEvent
+	wakeup  chan struct{}
+}
+
+// newSubscriber permit to get subscriber that send the pending events in background
+// The background sender live as long as the process, like the controllers that subscribe
+func newSubscriber() *subscriber {
+	s := &subscriber{
+		ch:     make(chan event.GenericEvent, subscriberBufferSize),
+		wakeup: make(chan struct{}, 1),
+	}
+	go s.run()
+
+	return s
+}
+
+// push add the event on pending events without blocking
+// It replace the pending event of the same platform, so it keep its place on the order
+func (s *subscriber) push(e event.GenericEvent) {
+	s.mu.Lock()
+	if i := slices.IndexFunc(s.pending, func(item event.GenericEvent) bool {
+		return item.Object.GetNamespace() == e.Object.GetNamespace() && item.Object.GetName() == e.Object.GetName()
+	}); i >= 0 {
+		s.pending[i] = e
+	} else {
+		s.pending = append(s.pending, e)
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// run send the pending events in order on channel
+func (s *subscriber) run() {
+	for range s.wakeup {
+		s.mu.Lock()
+		events := s.pending
+		s.pending = nil
+		s.mu.Unlock()
+
+		for _, e := range events {
+			s.ch <- e
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package platform
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	monitorapi "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func newTestComputedPlatform(name string, isDefault bool, hash string) *ComputedPlatform {
+	return &ComputedPlatform{
+		Platform: &monitorapi.Platform{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: monitorapi.PlatformSpec{
+				IsDefault:    isDefault,
+				PlatformType: "centreon",
+			},
+		},
+		Hash: hash,
+	}
+}
+
+func waitEvent(t *testing.T, ch <-chan event.GenericEvent) event.GenericEvent {
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("No event received")
+	}
+	return event.GenericEvent{}
+}
+
+func assertNoEvent(t *testing.T, ch <-chan event.GenericEvent) {
+	select {
+	case e := <-ch:
+		t.Fatalf("Unexpected event received for %s", e.Object.GetName())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPlatformRegistry(t *testing.T) {
+	registry := NewPlatformRegistry(map[string]*ComputedPlatform{})
+
+	// Add default platform
+	registry.Add(newTestComputedPlatform("p1", true, "hash1"))
+	p, ok := registry.Get("p1")
+	assert.True(t, ok)
+	assert.Equal(t, "hash1", p.Hash)
+	p, ok = registry.Get("default")
+	assert.True(t, ok)
+	assert.Equal(t, "p1", p.Platform.Name)
+
+	// Add other platform
+	registry.Add(newTestComputedPlatform("p2", false, "hash2"))
+	assert.Len(t, registry.List(), 3)
+	p, _ = registry.Get("default")
+	assert.Equal(t, "p1", p.Platform.Name)
+
+	// Platform is not more the default
+	registry.Add(newTestComputedPlatform("p1", false, "hash1"))
+	_, ok = registry.Get("default")
+	assert.False(t, ok)
+
+	// Remove default platform
+	registry.Add(newTestComputedPlatform("p2", true, "hash2"))
+	registry.Remove("p2")
+	_, ok = registry.Get("p2")
+	assert.False(t, ok)
+	_, ok = registry.Get("default")
+	assert.False(t, ok)
+
+	// Remove platform that not exist
+	registry.Remove("p3")
+	assert.Len(t, registry.List(), 1)
+
+	// List is a copy
+	platforms := registry.List()
+	delete(platforms, "p1")
+	_, ok = registry.Get("p1")
+	assert.True(t, ok)
+}
+
+func TestPlatformRegistryGetDefault(t *testing.T) {
+	registry := NewPlatformRegistry(map[string]*ComputedPlatform{})
+
+	// When there are no default platform
+	_, ok := registry.GetDefault("tenant1")
+	assert.False(t, ok)
+
+	// When there are only the default platform
+	registry.Add(newTestComputedPlatform("p1", true, "hash1"))
+	p, ok := registry.GetDefault("tenant1")
+	assert.True(t, ok)
+	assert.Equal(t, "p1", p.Platform.Name)
+
+	// When there are default platform for namespace
+	p2 := newTestComputedPlatform("p2", false, "hash2")
+	p2.Platform.Spec.DefaultForNamespaces = []string{"tenant1"}
+	registry.Add(p2)
+	p, ok = registry.GetDefault("tenant1")
+	assert.True(t, ok)
+	assert.Equal(t, "p2", p.Platform.Name)
+	p, ok = registry.GetDefault("tenant2")
+	assert.True(t, ok)
+	assert.Equal(t, "p1", p.Platform.Name)
+
+	// When there are multiple default platforms for namespace, the first one by name is always used
+	for _, name := range []string{"p5", "p3", "p4"} {
+		cp := newTestComputedPlatform(name, false, "hash")
+		cp.Platform.Spec.DefaultForNamespaces = []string{"tenant3"}
+		registry.Add(cp)
+	}
+	for i := 0; i < 20; i++ {
+		p, ok = registry.GetDefault("tenant3")
+		assert.True(t, ok)
+		assert.Equal(t, "p3", p.Platform.Name)
+	}
+}
+
+func TestGetClient(t *testing.T) {
+	registry := NewPlatformRegistry(map[string]*ComputedPlatform{})
+
+	// When there are no default platform
+	_, _, err := GetClient("", "tenant1", registry)
+	assert.Error(t, err)
+
+	// When platform not exist
+	_, _, err = GetClient("p1", "tenant1", registry)
+	assert.Error(t, err)
+
+	// When use the default platform
+	registry.Add(newTestComputedPlatform("p1", true, "hash1"))
+	_, p, err := GetClient("", "tenant1", registry)
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", p.Name)
+	_, p, err = GetClient("default", "tenant1", registry)
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", p.Name)
+
+	// When use the default platform of namespace
+	p2 := newTestComputedPlatform("p2", false, "hash2")
+	p2.Platform.Spec.AllowedNamespaces = []string{"tenant2"}
+	p2.Platform.Spec.DefaultForNamespaces = []string{"tenant2"}
+	registry.Add(p2)
+	_, p, err = GetClient("", "tenant2", registry)
+	assert.NoError(t, err)
+	assert.Equal(t, "p2", p.Name)
+
+	// When namespace is not allowed
+	_, _, err = GetClient("p2", "tenant1", registry)
+	assert.Error(t, err)
+	_, p, err = GetClient("p2", "tenant2", registry)
+	assert.NoError(t, err)
+	assert.Equal(t, "p2", p.Name)
+}
+
+func TestPlatformRegistrySubscribe(t *testing.T) {
+	registry := NewPlatformRegistry(map[string]*ComputedPlatform{})
+	ch1 := registry.Subscribe()
+	ch2 := registry.Subscribe()
+
+	// New platform
+	registry.Add(newTestComputedPlatform("p1", true, "hash1"))
+	assert.Equal(t, "p1", waitEvent(t, ch1).Object.GetName())
+	assert.Equal(t, "p1", waitEvent(t, ch2).Object.GetName())
+
+	// Same client settings
+	registry.Add(newTestComputedPlatform("p1", true, "hash1"))
+	assertNoEvent(t, ch1)
+
+	// Credentials rotation
+	registry.Add(newTestComputedPlatform("p1", true, "hash2"))
+	e := waitEvent(t, ch1)
+	assert.Equal(t, "p1", e.Object.GetName())
+	assert.True(t, e.Object.(*monitorapi.Platform).Spec.IsDefault)
+	waitEvent(t, ch2)
+
+	// Spec change
+	p1 := newTestComputedPlatform("p1", true, "hash2")
+	p1.Platform.Spec.AllowedNamespaces = []string{"tenant1"}
+	registry.Add(p1)
+	assert.Equal(t, "p1", waitEvent(t, ch1).Object.GetName())
+	waitEvent(t, ch2)
+
+	// Remove platform
+	registry.Remove("p1")
+	assert.Equal(t, "p1", waitEvent(t, ch1).Object.GetName())
+	waitEvent(t, ch2)
+
+	// Remove platform that not exist
+	registry.Remove("p1")
+	assertNoEvent(t, ch1)
+
+	// Event are not lost when the buffer is full
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		registry.Add(newTestComputedPlatform(fmt.Sprintf("p%d", i), false, "hash"))
+	}
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		waitEvent(t, ch1)
+	}
+	assertNoEvent(t, ch1)
+
+	// Pending events are coalesced by platform when the subscriber is slow
+	ch3 := registry.Subscribe()
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		cp := newTestComputedPlatform(fmt.Sprintf("p%d", i%2), false, fmt.Sprintf("hash%d", i))
+		cp.Platform.Spec.DefaultForNamespaces = []string{fmt.Sprintf("ns%d", i)}
+		registry.Add(cp)
+	}
+	last := map[string]string{}
+	count := 0
+	for {
+		select {
+		case e := <-ch3:
+			count++
+			last[e.Object.GetName()] = e.Object.(*monitorapi.Platform).Spec.DefaultForNamespaces[0]
+			continue
+		case <-time.After(100 * time.Millisecond):
+		}
+		break
+	}
+	assert.LessOrEqual(t, count, subscriberBufferSize+4)
+	assert.Equal(t, map[string]string{"p0": "ns108", "p1": "ns109"}, last)
+}
+
+// TestPlatformRegistryConcurrency must be run with race detector: go test -race
+func TestPlatformRegistryConcurrency(t *testing.T) {
+	registry := NewPlatformRegistry(map[string]*ComputedPlatform{})
+	ch := registry.Subscribe()
+
+	// Consume events
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ch:
+			case <-done:
+				return
+			}
+		}
+	}()
+	defer close(done)
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("p%d", i%3)
+			for j := 0; j < 100; j++ {
+				registry.Add(newTestComputedPlatform(name, j%2 == 0, fmt.Sprintf("hash%d", j)))
+				_, _ = registry.Get(name)
+				_, _, _ = GetClient("", "default", registry)
+				for _, cp := range registry.List() {
+					_ = cp.Hash
+				}
+				if j%10 == 0 {
+					registry.Remove(name)
+				}
+			}
+		}(i)
+
+		// Subscribe while platforms change
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := registry.Subscribe()
+			go func() {
+				for {
+					select {
+					case <-c:
+					case <-done:
+						return
+					}
+				}
+			}()
+		}()
+	}
+	wg.Wait()
+}
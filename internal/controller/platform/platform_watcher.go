@@ -0,0 +1,52 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// WatchPlatform permit to reconcile the resources that target the platform notified by the registry
+// It use the index `spec.targetPlatform`
+// It also reconcile the resources without platform on namespaces where the platform is the default
+func WatchPlatform(c client.Client, list client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		p, ok := a.(*centreoncrd.Platform)
+		if !ok {
+			return nil
+		}
+
+		type target struct {
+			name      string
+			namespace string
+		}
+		targets := []target{{name: p.Name}}
+		if p.Spec.IsDefault && p.Name != defaultPlatformKey {
+			targets = append(targets, target{name: defaultPlatformKey})
+		}
+		for _, ns := range p.Spec.DefaultForNamespaces {
+			targets = append(targets, target{name: defaultPlatformKey, namespace: ns})
+		}
+
+		reconcileRequests := make([]reconcile.Request, 0)
+		for _, t := range targets {
+			objectList := helpers.CloneObject(list)
+			fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.targetPlatform=%s", t.name))
+			if err := c.List(ctx, objectList, &client.ListOptions{FieldSelector: fs, Namespace: t.namespace}); err != nil {
+				panic(err)
+			}
+			for _, o := range helpers.GetItems(objectList) {
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()}})
+			}
+		}
+
+		return reconcileRequests
+	}
+}
@@ -29,7 +29,7 @@ type PlatformControllerTestSuite struct {
 	suite.Suite
 	k8sClient client.Client
 	cfg       *rest.Config
-	platforms map[string]*ComputedPlatform
+	platforms *PlatformRegistry
 }
 
 func TestPlatformControllerSuite(t *testing.T) {
@@ -123,14 +123,14 @@ func (t *PlatformControllerTestSuite) SetupSuite() {
 		panic(err)
 	}
 
-	platforms := map[string]*ComputedPlatform{}
-	t.platforms = platforms
+	t.platforms = NewPlatformRegistry(map[string]*ComputedPlatform{})
 
 	platformReconsiler := NewPlatformReconciler(
 		k8sClient,
 		logrus.NewEntry(logrus.StandardLogger()),
 		k8sManager.GetEventRecorderFor("plateform-controller"),
 		t.platforms,
+		"",
 	)
 	if err = platformReconsiler.SetupWithManager(k8sManager); err != nil {
 		panic(err)
@@ -2,23 +2,35 @@ package route
 
 import (
 	"context"
+	"fmt"
 
+	"emperror.dev/errors"
 	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
 	"github.com/disaster37/monitoring-operator/internal/controller/template"
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
-	name string = "route"
+	name                string = "route"
+	templatesAnnotation string = centreoncrd.MonitoringAnnotationKey + "/templates"
 )
 
 // RouteReconciler reconciles a route
@@ -48,6 +60,7 @@ func NewRouteReconciler(client client.Client, logger *logrus.Entry, recorder rec
 //+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitor.k8s.webcenter.fr,resources=centreonservicegroups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -72,18 +85,19 @@ func (r *RouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// The template filter is set per watch because the services haven't the template annotation
 func (r *RouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		// Uncomment the following line adding a pointer to an instance of the controlled resource as an argument
 		Named(r.name).
-		For(&routev1.Route{}).
-		Owns(&centreoncrd.CentreonService{}).
-		Owns(&centreoncrd.CentreonServiceGroup{}).
+		For(&routev1.Route{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Owns(&centreoncrd.CentreonService{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Owns(&centreoncrd.CentreonServiceGroup{}, builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
 		WithOptions(k8scontroller.Options{
 			RateLimiter: helper.DefaultControllerRateLimiter[reconcile.Request](),
 		}).
-		WithEventFilter(template.ViewResourceWithMonitoringTemplate()).
-		Watches(&centreoncrd.Template{}, handler.EnqueueRequestsFromMapFunc(template.WatchTemplate(r.Client(), &routev1.RouteList{}))).
+		Watches(&centreoncrd.Template{}, handler.EnqueueRequestsFromMapFunc(template.WatchTemplate(r.Client(), &routev1.RouteList{})), builder.WithPredicates(template.ViewResourceWithMonitoringTemplate())).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(watchService(r.Client())), builder.WithPredicates(viewServicePredicate())).
 		Complete(r)
 }
 
@@ -96,21 +110,185 @@ func (h *RouteReconciler) Read(ctx context.Context, o client.Object, data map[st
 	rule := map[string]any{
 		"host": r.Spec.Host,
 	}
-	if r.Spec.Path != "" {
-		rule["paths"] = []string{r.Spec.Path}
-	} else {
-		rule["paths"] = []string{"/"}
+	path := r.Spec.Path
+	if path == "" {
+		path = "/"
 	}
+	rule["paths"] = []string{path}
 	if r.Spec.TLS != nil && r.Spec.TLS.Termination != "" {
 		rule["scheme"] = "https"
 	} else {
 		rule["scheme"] = "http"
 	}
 
+	// Add backends
+	// The route only reference the target port on pods, so the service port is read from the target service
+	backends := make([]map[string]any, 0, len(r.Spec.AlternateBackends)+1)
+	for _, target := range append([]routev1.RouteTargetReference{r.Spec.To}, r.Spec.AlternateBackends...) {
+		service, err := h.getTargetService(ctx, r.Namespace, target)
+		if err != nil {
+			return nil, res, err
+		}
+		backends = append(backends, getBackendPlaceholders(target, r.Spec.Port, service, path))
+	}
+	rule["backends"] = backends
+
 	rules = append(rules, rule)
 	placeholders["rules"] = rules
 
+	// TLS
+	tls := make([]map[string]any, 0, 1)
+	if r.Spec.TLS != nil {
+		tls = append(tls, map[string]any{
+			"hosts":                         []string{r.Spec.Host},
+			"termination":                   string(r.Spec.TLS.Termination),
+			"insecureEdgeTerminationPolicy": string(r.Spec.TLS.InsecureEdgeTerminationPolicy),
+		})
+	}
+	placeholders["tls"] = tls
+
+	// Routers that admit the route
+	// The router has no IP and ports, the keys are set empty to have the same format as ingress
+	loadBalancers := make([]map[string]any, 0, len(r.Status.Ingress))
+	for _, ingress := range r.Status.Ingress {
+		loadBalancers = append(loadBalancers, map[string]any{
+			"ip":         "",
+			"hostname":   ingress.RouterCanonicalHostname,
+			"ports":      []int32{},
+			"routerName": ingress.RouterName,
+		})
+	}
+	placeholders["loadBalancers"] = loadBalancers
+
 	data["placeholders"] = placeholders
 
 	return h.SentinelReconcilerAction.Read(ctx, o, data, logger)
 }
+
+// getTargetService return the service targeted by the route backend
+// It return nil if the backend is not a service or if the service not exist
+func (h *RouteReconciler) getTargetService(ctx context.Context, namespace string, target routev1.RouteTargetReference) (service *corev1.Service, err error) {
+	if target.Kind != "" && target.Kind != "Service" {
+		return nil, nil
+	}
+
+	service = &corev1.Service{}
+	if err = h.Client().Get(ctx, types.NamespacedName{Namespace: namespace, Name: target.Name}, service); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Error when read service %s/%s", namespace, target.Name)
+	}
+
+	return service, nil
+}
+
+// getBackendPlaceholders permit to convert route target on placeholders
+// It use the same format as ingress backend, without path type because route has not this concept
+// The service port is only set when the route port is found on the target service
+func getBackendPlaceholders(target routev1.RouteTargetReference, port *routev1.RoutePort, service *corev1.Service, path string) map[string]any {
+	backend := map[string]any{
+		"path":        path,
+		"serviceName": target.Name,
+		"weight":      int32(0),
+	}
+
+	if target.Weight != nil {
+		backend["weight"] = *target.Weight
+	}
+
+	if port != nil {
+		if port.TargetPort.Type == intstr.Int {
+			backend["targetPort"] = port.TargetPort.IntVal
+		} else {
+			backend["targetPortName"] = port.TargetPort.StrVal
+		}
+		if servicePort := findServicePort(service, port.TargetPort); servicePort != nil {
+			backend["servicePort"] = servicePort.Port
+			backend["servicePortName"] = servicePort.Name
+		}
+	}
+
+	return backend
+}
+
+// findServicePort return the service port that expose the route target port
+// The target port name is the service port name, and the target port number is the port on pods
+func findServicePort(service *corev1.Service, targetPort intstr.IntOrString) *corev1.ServicePort {
+	if service == nil {
+		return nil
+	}
+
+	for i, servicePort := range service.Spec.Ports {
+		if targetPort.Type == intstr.String {
+			if servicePort.Name == targetPort.StrVal {
+				return &service.Spec.Ports[i]
+			}
+			continue
+		}
+
+		// The service target port is the service port when it not set
+		podPort := servicePort.TargetPort
+		if podPort.Type == intstr.Int && podPort.IntVal == 0 {
+			podPort = intstr.FromInt32(servicePort.Port)
+		}
+		if podPort.Type == intstr.Int && podPort.IntVal == targetPort.IntVal {
+			return &service.Spec.Ports[i]
+		}
+	}
+
+	return nil
+}
+
+// watchService permit to reconcile the routes that target the service, when its ports change
+// It use the index `spec.services`
+func watchService(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		reconcileRequests := make([]reconcile.Request, 0)
+		routeList := &routev1.RouteList{}
+
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.services=%s", a.GetName()))
+
+		// Get all routes that target the current service
+		if err := c.List(ctx, routeList, &client.ListOptions{Namespace: a.GetNamespace(), FieldSelector: fs}); err != nil {
+			panic(err)
+		}
+
+		for _, r := range routeList.Items {
+			// Only route with monitoring templates
+			if r.GetAnnotations()[templatesAnnotation] == "" {
+				continue
+			}
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: r.Namespace, Name: r.Name}})
+		}
+
+		return reconcileRequests
+	}
+}
+
+// viewServicePredicate permit to only handle the service changes used by route placeholders
+// On update, it only handle the change of ports
+func viewServicePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldService, ok := e.ObjectOld.(*corev1.Service)
+			if !ok {
+				return false
+			}
+			newService, ok := e.ObjectNew.(*corev1.Service)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldService.Spec.Ports, newService.Spec.Ports)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
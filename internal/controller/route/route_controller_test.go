@@ -13,11 +13,17 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func (t *RouteControllerTestSuite) TestRouteCentreonController() {
@@ -424,11 +430,57 @@ spec:
 			}
 			logrus.Infof("Create template template-route4")
 
+			template = &monitorapi.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "template-route5",
+					Namespace: "default",
+				},
+				Spec: monitorapi.TemplateSpec{
+					Template: `
+{{ $rule := index .rules 0 }}
+{{ $backend := index $rule.backends 0 }}
+apiVersion: monitor.k8s.webcenter.fr/v1
+kind: CentreonService
+spec:
+  host: "localhost"
+  name: "ping5"
+  template: "template5"
+  macros:
+    url: "{{ $rule.scheme }}://{{ $rule.host }}{{ $backend.path }}"
+    backend: "{{ $backend.serviceName }}:{{ $backend.servicePortName }}:{{ $backend.servicePort }}:{{ $backend.targetPort }}"
+  activate: true`,
+				},
+			}
+			if err := c.Create(context.Background(), template); err != nil {
+				return err
+			}
+			logrus.Infof("Create template template-route5")
+
 			return nil
 		},
 		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
 			logrus.Infof("=== Add new Route %s/%s ===", key.Namespace, key.Name)
 
+			// Create the service targeted by route
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-" + key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "http",
+							Port:       80,
+							TargetPort: intstr.FromInt32(8080),
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), service); err != nil {
+				return err
+			}
+
 			// Create route without annotations
 			route := &routev1.Route{
 				ObjectMeta: metav1.ObjectMeta{
@@ -439,7 +491,7 @@ spec:
 						"env": "dev",
 					},
 					Annotations: map[string]string{
-						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-route3\"}, {\"namespace\":\"default\", \"name\": \"template-route4\"}]",
+						"monitor.k8s.webcenter.fr/templates": "[{\"namespace\":\"default\", \"name\": \"template-route3\"}, {\"namespace\":\"default\", \"name\": \"template-route4\"}, {\"namespace\":\"default\", \"name\": \"template-route5\"}]",
 					},
 				},
 				Spec: routev1.RouteSpec{
@@ -447,10 +499,10 @@ spec:
 					Path: "/",
 					To: routev1.RouteTargetReference{
 						Kind: "Service",
-						Name: "fake",
+						Name: "fake-" + key.Name,
 					},
 					Port: &routev1.RoutePort{
-						TargetPort: intstr.FromString("8080"),
+						TargetPort: intstr.FromInt32(8080),
 					},
 				},
 			}
@@ -528,6 +580,31 @@ spec:
 			assert.Equal(t, fmt.Sprintf("%s.%s", key.Namespace, key.Name), cs.Labels["monitor.k8s.webcenter.fr/parent"])
 			assert.Equal(t, expectedCSSpec, cs.Spec)
 			assert.NotEmpty(t, cs.OwnerReferences)
+
+			// Get service generated by template-route5
+			isTimeout, err = test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: "template-route5"}, cs); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return errors.New("Not yet created")
+					}
+					t.Fatalf("Error when get Centreon service template-route5: %s", err.Error())
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Centreon service template-route5: %s", err.Error())
+			}
+			expectedCSSpec = monitorapi.CentreonServiceSpec{
+				Host:     "localhost",
+				Name:     "ping5",
+				Template: "template5",
+				Macros: map[string]string{
+					"url":     "http://front.local.local/",
+					"backend": fmt.Sprintf("fake-%s:http:80:8080", key.Name),
+				},
+				Activated: true,
+			}
+			assert.Equal(t, expectedCSSpec, cs.Spec)
 			return nil
 		},
 	}
@@ -681,3 +758,183 @@ func doDeleteRouteStep() test.TestStep {
 		},
 	}
 }
+
+func TestFindServicePort(t *testing.T) {
+	service := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt32(8080),
+				},
+				{
+					Name: "metrics",
+					Port: 9090,
+				},
+				{
+					Name:       "admin",
+					Port:       81,
+					TargetPort: intstr.FromString("admin"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		Name         string
+		Service      *corev1.Service
+		TargetPort   intstr.IntOrString
+		ExpectedPort int32
+	}{
+		{
+			Name:         "Target port name",
+			Service:      service,
+			TargetPort:   intstr.FromString("http"),
+			ExpectedPort: 80,
+		},
+		{
+			Name:         "Target port number",
+			Service:      service,
+			TargetPort:   intstr.FromInt32(8080),
+			ExpectedPort: 80,
+		},
+		{
+			Name:         "Target port number without service target port",
+			Service:      service,
+			TargetPort:   intstr.FromInt32(9090),
+			ExpectedPort: 9090,
+		},
+		{
+			Name:       "Target port not found",
+			Service:    service,
+			TargetPort: intstr.FromInt32(80),
+		},
+		{
+			Name:       "Service not found",
+			TargetPort: intstr.FromString("http"),
+		},
+	}
+
+	for _, test := range tests {
+		servicePort := findServicePort(test.Service, test.TargetPort)
+		if test.ExpectedPort == 0 {
+			assert.Nil(t, servicePort, test.Name)
+			continue
+		}
+		if assert.NotNil(t, servicePort, test.Name) {
+			assert.Equal(t, test.ExpectedPort, servicePort.Port, test.Name)
+		}
+	}
+}
+
+func TestGetBackendPlaceholders(t *testing.T) {
+	service := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt32(8080),
+				},
+			},
+		},
+	}
+	weight := int32(100)
+	target := routev1.RouteTargetReference{
+		Kind:   "Service",
+		Name:   "front",
+		Weight: &weight,
+	}
+
+	// When the port is found on service
+	backend := getBackendPlaceholders(target, &routev1.RoutePort{TargetPort: intstr.FromInt32(8080)}, service, "/")
+	assert.Equal(t, map[string]any{
+		"path":            "/",
+		"serviceName":     "front",
+		"weight":          int32(100),
+		"targetPort":      int32(8080),
+		"servicePort":     int32(80),
+		"servicePortName": "http",
+	}, backend)
+
+	// When the service not exist
+	backend = getBackendPlaceholders(target, &routev1.RoutePort{TargetPort: intstr.FromString("http")}, nil, "/")
+	assert.Equal(t, map[string]any{
+		"path":           "/",
+		"serviceName":    "front",
+		"weight":         int32(100),
+		"targetPortName": "http",
+	}, backend)
+
+	// When the route has no port
+	backend = getBackendPlaceholders(target, nil, service, "/")
+	assert.Equal(t, map[string]any{
+		"path":        "/",
+		"serviceName": "front",
+		"weight":      int32(100),
+	}, backend)
+}
+
+func TestWatchService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = routev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&routev1.Route{}, "spec.services", monitorapi.RouteServicesIndexer).
+		WithObjects(
+			// Target service
+			&routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{Name: "to", Namespace: "default", Annotations: map[string]string{templatesAnnotation: `[{"namespace":"default","name":"t1"}]`}},
+				Spec:       routev1.RouteSpec{To: routev1.RouteTargetReference{Kind: "Service", Name: "front"}},
+			},
+			// Alternate backend
+			&routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{Name: "alternate", Namespace: "default", Annotations: map[string]string{templatesAnnotation: `[{"namespace":"default","name":"t1"}]`}},
+				Spec: routev1.RouteSpec{
+					To:                routev1.RouteTargetReference{Kind: "Service", Name: "back"},
+					AlternateBackends: []routev1.RouteTargetReference{{Kind: "Service", Name: "front"}},
+				},
+			},
+			// Without templates
+			&routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-template", Namespace: "default"},
+				Spec:       routev1.RouteSpec{To: routev1.RouteTargetReference{Kind: "Service", Name: "front"}},
+			},
+			// Other namespace
+			&routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other", Annotations: map[string]string{templatesAnnotation: `[{"namespace":"default","name":"t1"}]`}},
+				Spec:       routev1.RouteSpec{To: routev1.RouteTargetReference{Kind: "Service", Name: "front"}},
+			},
+		).
+		Build()
+
+	requests := watchService(c)(context.Background(), &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "front", Namespace: "default"}})
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "to"}},
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "alternate"}},
+	}, requests)
+}
+
+func TestViewServicePredicate(t *testing.T) {
+	p := viewServicePredicate()
+	oldService := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080)}},
+		},
+	}
+
+	// When ports not change
+	newService := oldService.DeepCopy()
+	newService.Labels = map[string]string{"app": "front"}
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: oldService, ObjectNew: newService}))
+
+	// When ports change
+	newService.Spec.Ports[0].Port = 8000
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: oldService, ObjectNew: newService}))
+
+	assert.True(t, p.Create(event.CreateEvent{Object: newService}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: newService}))
+}
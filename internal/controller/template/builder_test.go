@@ -0,0 +1,53 @@
+package template
+
+import (
+	"testing"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestBuilderProcessCentreonService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, centreoncrd.AddToScheme(scheme))
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	}
+	tmpl := &centreoncrd.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "template1",
+			Namespace: "default",
+		},
+		Spec: centreoncrd.TemplateSpec{
+			Type: "CentreonService",
+			Name: "check-{{ .name }}",
+			Template: `
+name: "ping-{{ .name }}"
+activate: true`,
+		},
+	}
+
+	// When host and template are provided by the platform defaults
+	o, err := newBuilder(ns, scheme).Process(tmpl)
+	assert.NoError(t, err)
+	cs, ok := o.(*centreoncrd.CentreonService)
+	assert.True(t, ok)
+	assert.Equal(t, "ping-test", cs.Spec.Name)
+	assert.Empty(t, cs.Spec.Host)
+	assert.Empty(t, cs.Spec.Template)
+
+	// When the service name is missing
+	tmpl.Spec.Template = `
+host: "central"
+activate: true`
+	_, err = newBuilder(ns, scheme).Process(tmpl)
+	assert.Error(t, err)
+}
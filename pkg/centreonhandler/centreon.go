@@ -1,31 +1,70 @@
 package centreonhandler
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
 	"github.com/disaster37/go-centreon-rest/v21"
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// versionPath is the API v2 endpoint that return the Centreon versions
+	versionPath string = "latest/platform/versions"
+)
+
 type CentreonHandler interface {
 	CreateService(service *CentreonService) (err error)
 	UpdateService(service *CentreonServiceDiff) (err error)
 	DeleteService(host, service string) (err error)
 	GetService(host, name string) (service *CentreonService, err error)
+	GetServiceComment(host, name string) (comment string, err error)
+	GetServiceContacts(host, name string) (contacts []string, err error)
+	GetServiceContactGroups(host, name string) (contactGroups []string, err error)
+	GetServiceOwner(host, name string) (owner *Owner, err error)
+	ListServices() (services []*CentreonService, err error)
 	DiffService(actual, expected *CentreonService, ignoreFields []string) (diff *CentreonServiceDiff, err error)
 	CreateServiceGroup(sg *CentreonServiceGroup) (err error)
 	UpdateServiceGroup(sg *CentreonServiceGroupDiff) (err error)
 	DeleteServiceGroup(name string) (err error)
 	GetServiceGroup(name string) (sg *CentreonServiceGroup, err error)
+	ListServiceGroups() (sgs []*CentreonServiceGroup, err error)
 	DiffServiceGroup(actual, expected *CentreonServiceGroup, ignoreFields []string) (diff *CentreonServiceGroupDiff, err error)
+	CreateServiceDependency(dependency *CentreonServiceDependency) (err error)
+	UpdateServiceDependency(dependency *CentreonServiceDependencyDiff) (err error)
+	DeleteServiceDependency(name string) (err error)
+	GetServiceDependency(name string) (dependency *CentreonServiceDependency, err error)
+	DiffServiceDependency(actual, expected *CentreonServiceDependency, ignoreFields []string) (diff *CentreonServiceDependencyDiff, err error)
+	CreateEscalation(escalation *CentreonEscalation) (err error)
+	UpdateEscalation(escalation *CentreonEscalationDiff) (err error)
+	DeleteEscalation(name string) (err error)
+	GetEscalation(name string) (escalation *CentreonEscalation, err error)
+	DiffEscalation(actual, expected *CentreonEscalation, ignoreFields []string) (diff *CentreonEscalationDiff, err error)
 
 	Auth() error
+	GetVersion() (version string, err error)
 	SetLogger(log *logrus.Entry)
 }
 
 type CentreonHandlerImpl struct {
-	client *centreon.Client
-	log    *logrus.Entry
+	client      *centreon.Client
+	log         *logrus.Entry
+	config      *models.Config
+	newClient   ClientFactory
+	credentials CredentialsProvider
+	mu          sync.RWMutex
 }
 
+// ClientFactory permit to create the Centreon client from config
+// It's used to create a new client when the credentials change
+type ClientFactory func(config *models.Config) (client *centreon.Client, err error)
+
 func NewCentreonHandler(client *centreon.Client, log *logrus.Entry) CentreonHandler {
 	return &CentreonHandlerImpl{
 		client: client,
@@ -33,10 +72,140 @@ func NewCentreonHandler(client *centreon.Client, log *logrus.Entry) CentreonHand
 	}
 }
 
+// NewCentreonHandlerWithCredentials permit to get handler that reload the credentials before each authentification
+// When the API return unauthorized, the credentials are reloaded.
+// The config is never modified, a new client is created from a copy of config when the credentials change
+func NewCentreonHandlerWithCredentials(config *models.Config, newClient ClientFactory, credentials CredentialsProvider, log *logrus.Entry) (CentreonHandler, error) {
+	h := &CentreonHandlerImpl{
+		log:         log,
+		config:      config,
+		newClient:   newClient,
+		credentials: credentials,
+	}
+
+	client, err := newClient(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when create Centreon client")
+	}
+	h.client = h.reloadCredentialsOnUnauthorized(client)
+
+	return h, nil
+}
+
+// reloadCredentialsOnUnauthorized permit to reload the credentials when the API return unauthorized
+func (h *CentreonHandlerImpl) reloadCredentialsOnUnauthorized(client *centreon.Client) *centreon.Client {
+	client.API.Client().OnAfterResponse(func(c *resty.Client, r *resty.Response) error {
+		if r.StatusCode() == http.StatusUnauthorized || r.StatusCode() == http.StatusForbidden {
+			if _, err := h.refreshCredentials(); err != nil {
+				h.log.Errorf("Error when reload credentials: %s", err.Error())
+			}
+		}
+		return nil
+	})
+
+	return client
+}
+
+// getClient return the current client
+// The client is replaced when the credentials change, so it need to be read under lock
+func (h *CentreonHandlerImpl) getClient() *centreon.Client {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.client
+}
+
 func (h *CentreonHandlerImpl) SetLogger(log *logrus.Entry) {
 	h.log = log
 }
 
 func (h *CentreonHandlerImpl) Auth() error {
-	return h.client.API.Auth()
+	isRefreshed, err := h.refreshCredentials()
+	if err != nil {
+		return err
+	}
+
+	// The new client is already authenticated
+	if isRefreshed {
+		return nil
+	}
+
+	return h.getClient().API.Auth()
+}
+
+// refreshCredentials permit to reload the credentials used by client to authenticate
+// The client is read by concurrent requests, so a new client is created and authenticated with the new credentials before it replace the current one.
+// The requests in progress finish with the previous client.
+// It return true if the client is replaced
+func (h *CentreonHandlerImpl) refreshCredentials() (isRefreshed bool, err error) {
+	if h.credentials == nil || h.newClient == nil {
+		return false, nil
+	}
+
+	credentials, err := h.credentials()
+	if err != nil {
+		return false, errors.Wrap(err, "Error when get credentials")
+	}
+
+	h.mu.RLock()
+	config := *h.config
+	h.mu.RUnlock()
+	if config.Username == credentials.Username && config.Password == credentials.Password && config.Token == credentials.Token {
+		return false, nil
+	}
+
+	// The client not reload credentials until it replace the current one, to not loop when the new credentials are refused
+	config.Username = credentials.Username
+	config.Password = credentials.Password
+	config.Token = credentials.Token
+	client, err := h.newClient(&config)
+	if err != nil {
+		return false, errors.Wrap(err, "Error when create Centreon client")
+	}
+	if err = client.API.Auth(); err != nil {
+		return false, errors.Wrap(err, "Error when authenticate with new credentials")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.config = &config
+	h.client = h.reloadCredentialsOnUnauthorized(client)
+	h.log.Info("Credentials are reloaded")
+
+	return true, nil
+}
+
+// GetVersion permit to get the Centreon web version
+// It call the API v2 endpoint `platform/versions` that not need authentification.
+// The API v2 URL is computed from the CLAPI URL (`<centreon>/api/index.php`)
+func (h *CentreonHandlerImpl) GetVersion() (version string, err error) {
+	url := strings.TrimSuffix(strings.TrimSuffix(h.getClient().API.Client().BaseURL, "index.php"), "/") + "/" + versionPath
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "Error when create request to get Centreon version")
+	}
+	resp, err := h.getClient().API.Client().GetClient().Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "Error when get Centreon version")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "Error when read Centreon version")
+	}
+	if resp.StatusCode >= 300 {
+		return "", errors.Errorf("Error when get Centreon version (%d): %s", resp.StatusCode, body)
+	}
+
+	result := map[string]map[string]any{}
+	if err = json.Unmarshal(body, &result); err != nil {
+		return "", errors.Wrap(err, "Error when decode Centreon version")
+	}
+	if v, ok := result["web"]["version"].(string); ok && v != "" {
+		return v, nil
+	}
+
+	return "", errors.New("Centreon web version not found")
 }
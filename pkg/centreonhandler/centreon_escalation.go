@@ -0,0 +1,275 @@
+package centreonhandler
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+const (
+	// objectEscalation is the CLAPI object to handle escalations
+	objectEscalation string = "ESCALATION"
+)
+
+// escalationResult is the escalation returned by CLAPI
+type escalationResult struct {
+	Name                 string `json:"name"`
+	Alias                string `json:"alias"`
+	FirstNotification    string `json:"first_notification"`
+	LastNotification     string `json:"last_notification"`
+	NotificationInterval string `json:"notification_interval"`
+	Period               string `json:"escalation_period"`
+	NotificationOptions  string `json:"service_notification_options"`
+	Comment              string `json:"comment"`
+}
+
+// escalationServiceResult is the service of escalation returned by CLAPI
+type escalationServiceResult struct {
+	Host    string `json:"host_name"`
+	Service string `json:"service_description"`
+}
+
+// CreateEscalation permit to create new escalation on Centreon from spec
+func (h *CentreonHandlerImpl) CreateEscalation(escalation *CentreonEscalation) (err error) {
+	if escalation == nil {
+		return errors.New("Escalation must be provided")
+	}
+	if escalation.Name == "" {
+		return errors.New("Escalation name must be provided")
+	}
+	if escalation.Description == "" {
+		return errors.New("Escalation description must be provided")
+	}
+	if len(escalation.ContactGroups) == 0 {
+		return errors.New("Escalation contact groups must be provided")
+	}
+	if len(escalation.Services) == 0 {
+		return errors.New("Escalation services must be provided")
+	}
+
+	// Create main object
+	if err = h.clapi("add", objectEscalation, fmt.Sprintf("%s;%s", escalation.Name, escalation.Description), nil); err != nil {
+		return err
+	}
+	h.log.Debug("Create escalation core from Centreon")
+
+	// Set extra params
+	params := map[string]string{
+		"first_notification":           escalation.FirstNotification,
+		"last_notification":            escalation.LastNotification,
+		"notification_interval":        escalation.NotificationInterval,
+		"escalation_period":            escalation.Period,
+		"service_notification_options": escalation.NotificationOptions,
+		"comment":                      escalation.Comment,
+	}
+	for _, param := range slices.Sorted(maps.Keys(params)) {
+		if params[param] != "" {
+			if err = h.clapi("setparam", objectEscalation, fmt.Sprintf("%s;%s;%s", escalation.Name, param, params[param]), nil); err != nil {
+				return err
+			}
+			h.log.Debugf("Set param %s on escalation from Centreon", param)
+		}
+	}
+
+	// Set contact groups
+	if err = h.clapi("addcontactgroup", objectEscalation, fmt.Sprintf("%s;%s", escalation.Name, strings.Join(escalation.ContactGroups, "|")), nil); err != nil {
+		return err
+	}
+	h.log.Debug("Set contact groups on escalation from Centreon")
+
+	// Set services
+	for _, service := range escalation.Services {
+		if err = h.clapi("addservice", objectEscalation, fmt.Sprintf("%s;%s", escalation.Name, service), nil); err != nil {
+			return err
+		}
+		h.log.Debugf("Add service %s on escalation from Centreon", service)
+	}
+
+	h.log.Debug("Create escalation successfully on Centreon")
+
+	return nil
+}
+
+// UpdateEscalation permit to update existing escalation on Centreon from spec
+func (h *CentreonHandlerImpl) UpdateEscalation(escalationDiff *CentreonEscalationDiff) (err error) {
+	if escalationDiff == nil {
+		return errors.New("EscalationDiff must be provided")
+	}
+	if escalationDiff.Name == "" {
+		return errors.New("Escalation name must be provided")
+	}
+
+	if !escalationDiff.IsDiff {
+		h.log.Debug("No update needed, skip it")
+		return nil
+	}
+
+	// Rename first, so the other changes are applied on the new name
+	if name, ok := escalationDiff.ParamsToSet["name"]; ok {
+		if err = h.clapi("setparam", objectEscalation, fmt.Sprintf("%s;name;%s", escalationDiff.Name, name), nil); err != nil {
+			return err
+		}
+		h.log.Debugf("Rename escalation %s to %s from Centreon", escalationDiff.Name, name)
+		escalationDiff.Name = name
+	}
+
+	// Update properties
+	for _, param := range slices.Sorted(maps.Keys(escalationDiff.ParamsToSet)) {
+		if param == "name" {
+			continue
+		}
+		if err = h.clapi("setparam", objectEscalation, fmt.Sprintf("%s;%s;%s", escalationDiff.Name, param, escalationDiff.ParamsToSet[param]), nil); err != nil {
+			return err
+		}
+		h.log.Debugf("Update param %s from Centreon", param)
+	}
+
+	// Update contact groups and services
+	changes := []struct {
+		action string
+		items  []string
+	}{
+		{action: "addcontactgroup", items: escalationDiff.ContactGroupsToSet},
+		{action: "addservice", items: escalationDiff.ServicesToSet},
+		{action: "delcontactgroup", items: escalationDiff.ContactGroupsToDelete},
+		{action: "delservice", items: escalationDiff.ServicesToDelete},
+	}
+	for _, change := range changes {
+		for _, item := range change.items {
+			if err = h.clapi(change.action, objectEscalation, fmt.Sprintf("%s;%s", escalationDiff.Name, item), nil); err != nil {
+				return err
+			}
+			h.log.Debugf("%s %s on escalation from Centreon", change.action, item)
+		}
+	}
+
+	return nil
+}
+
+// DeleteEscalation permit to delete an existing escalation on Centreon
+func (h *CentreonHandlerImpl) DeleteEscalation(name string) (err error) {
+	if name == "" {
+		return errors.New("Escalation name must be provided")
+	}
+
+	err = h.clapi("del", objectEscalation, name, nil)
+	if err != nil && IsErrorNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// GetEscalation permit to get escalation by it name
+func (h *CentreonHandlerImpl) GetEscalation(name string) (escalation *CentreonEscalation, err error) {
+	if name == "" {
+		return nil, errors.New("Escalation name must be provided")
+	}
+
+	// The show action search the escalations that contain the name
+	escalations := make([]escalationResult, 0)
+	if err = h.clapi("show", objectEscalation, name, &escalations); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(escalations, func(e escalationResult) bool {
+		return e.Name == name
+	})
+	if i < 0 {
+		return nil, nil
+	}
+
+	// Get contact groups
+	contactGroups := make([]nameResult, 0)
+	if err = h.clapi("getcontactgroup", objectEscalation, name, &contactGroups); err != nil {
+		return nil, err
+	}
+
+	// Get services
+	services := make([]escalationServiceResult, 0)
+	if err = h.clapi("getservice", objectEscalation, name, &services); err != nil {
+		return nil, err
+	}
+
+	escalation = &CentreonEscalation{
+		Name:                 name,
+		Description:          escalations[i].Alias,
+		FirstNotification:    escalations[i].FirstNotification,
+		LastNotification:     escalations[i].LastNotification,
+		NotificationInterval: escalations[i].NotificationInterval,
+		Period:               escalations[i].Period,
+		NotificationOptions:  sortOptions(escalations[i].NotificationOptions),
+		Comment:              escalations[i].Comment,
+		ContactGroups:        names(contactGroups),
+		Services:             make([]string, 0, len(services)),
+	}
+	for _, service := range services {
+		escalation.Services = append(escalation.Services, ServiceKey(service.Host, service.Service))
+	}
+
+	h.log.Debugf("Actual escalation: %s", escalation)
+
+	return escalation, nil
+}
+
+// DiffEscalation permit to diff actual and expected escalation to know what it need to modify
+func (h *CentreonHandlerImpl) DiffEscalation(actual, expected *CentreonEscalation, ignoreFields []string) (diff *CentreonEscalationDiff, err error) {
+	diff = &CentreonEscalationDiff{
+		Name:                  actual.Name,
+		IsDiff:                false,
+		ParamsToSet:           map[string]string{},
+		ContactGroupsToSet:    make([]string, 0),
+		ContactGroupsToDelete: make([]string, 0),
+		ServicesToSet:         make([]string, 0),
+		ServicesToDelete:      make([]string, 0),
+	}
+
+	// Check params
+	if !funk.Contains(ignoreFields, "name") && actual.Name != expected.Name {
+		diff.ParamsToSet["name"] = expected.Name
+	}
+	if !funk.Contains(ignoreFields, "description") && actual.Description != expected.Description {
+		diff.ParamsToSet["alias"] = expected.Description
+	}
+	if !funk.Contains(ignoreFields, "firstNotification") && actual.FirstNotification != expected.FirstNotification {
+		diff.ParamsToSet["first_notification"] = expected.FirstNotification
+	}
+	if !funk.Contains(ignoreFields, "lastNotification") && actual.LastNotification != expected.LastNotification {
+		diff.ParamsToSet["last_notification"] = expected.LastNotification
+	}
+	if !funk.Contains(ignoreFields, "notificationInterval") && actual.NotificationInterval != expected.NotificationInterval {
+		diff.ParamsToSet["notification_interval"] = expected.NotificationInterval
+	}
+	if !funk.Contains(ignoreFields, "escalationPeriod") && actual.Period != expected.Period {
+		diff.ParamsToSet["escalation_period"] = expected.Period
+	}
+	if !funk.Contains(ignoreFields, "notificationOptions") && actual.NotificationOptions != expected.NotificationOptions {
+		diff.ParamsToSet["service_notification_options"] = expected.NotificationOptions
+	}
+	if !funk.Contains(ignoreFields, "comment") && actual.Comment != expected.Comment {
+		diff.ParamsToSet["comment"] = expected.Comment
+	}
+
+	// Check the contact groups
+	if !funk.Contains(ignoreFields, "contactGroups") {
+		diff.ContactGroupsToSet, diff.ContactGroupsToDelete = funk.DifferenceString(expected.ContactGroups, actual.ContactGroups)
+	}
+
+	// Check the services
+	if !funk.Contains(ignoreFields, "services") {
+		diff.ServicesToSet, diff.ServicesToDelete = funk.DifferenceString(expected.Services, actual.Services)
+	}
+
+	// Compute IsDiff
+	if len(diff.ParamsToSet) > 0 || len(diff.ContactGroupsToSet) > 0 || len(diff.ContactGroupsToDelete) > 0 || len(diff.ServicesToSet) > 0 || len(diff.ServicesToDelete) > 0 {
+		diff.IsDiff = true
+		h.log.Debugf("Some diff founds :%s", diff)
+	} else {
+		h.log.Debug("No diff found")
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,48 @@
+package centreonhandler
+
+import (
+	"encoding/json"
+)
+
+// CentreonEscalation is an escalation of service notifications
+// The services are referenced with the format `host,service`
+type CentreonEscalation struct {
+	Name                 string
+	Description          string
+	FirstNotification    string
+	LastNotification     string
+	NotificationInterval string
+	Period               string
+	NotificationOptions  string
+	Comment              string
+	ContactGroups        []string
+	Services             []string
+}
+
+type CentreonEscalationDiff struct {
+	Name                  string
+	IsDiff                bool
+	ParamsToSet           map[string]string
+	ContactGroupsToSet    []string
+	ContactGroupsToDelete []string
+	ServicesToSet         []string
+	ServicesToDelete      []string
+}
+
+func (ce *CentreonEscalation) String() string {
+	b, err := json.Marshal(ce)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+func (ced *CentreonEscalationDiff) String() string {
+	b, err := json.Marshal(ced)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
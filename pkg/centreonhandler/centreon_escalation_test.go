@@ -0,0 +1,204 @@
+package centreonhandler
+
+import (
+	centreonapi "github.com/disaster37/go-centreon-rest/v21/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *CentreonHandlerTestSuite) TestCreateEscalation() {
+	toCreate := &CentreonEscalation{
+		Name:              "esc1",
+		Description:       "my escalation",
+		FirstNotification: "2",
+		LastNotification:  "0",
+		Period:            "24x7",
+		Comment:           "Managed by monitoring-operator",
+		ContactGroups:     []string{"cg1", "cg2"},
+		Services:          []string{"host1,service1"},
+	}
+
+	// Normal use case
+	client, payloads := t.newCLAPIHandler(nil)
+	err := client.CreateEscalation(toCreate)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []centreonapi.Payload{
+		{Action: "add", Object: "ESCALATION", Values: "esc1;my escalation"},
+		{Action: "setparam", Object: "ESCALATION", Values: "esc1;comment;Managed by monitoring-operator"},
+		{Action: "setparam", Object: "ESCALATION", Values: "esc1;escalation_period;24x7"},
+		{Action: "setparam", Object: "ESCALATION", Values: "esc1;first_notification;2"},
+		{Action: "setparam", Object: "ESCALATION", Values: "esc1;last_notification;0"},
+		{Action: "addcontactgroup", Object: "ESCALATION", Values: "esc1;cg1|cg2"},
+		{Action: "addservice", Object: "ESCALATION", Values: "esc1;host1,service1"},
+	}, *payloads)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"addservice": "error"})
+	err = client.CreateEscalation(toCreate)
+	assert.Error(t.T(), err)
+
+	// When use bad parameter
+	err = client.CreateEscalation(nil)
+	assert.Error(t.T(), err)
+
+	// When no contact groups
+	err = client.CreateEscalation(&CentreonEscalation{
+		Name:        "esc1",
+		Description: "my escalation",
+		Services:    []string{"host1,service1"},
+	})
+	assert.Error(t.T(), err)
+
+	// When no services
+	err = client.CreateEscalation(&CentreonEscalation{
+		Name:          "esc1",
+		Description:   "my escalation",
+		ContactGroups: []string{"cg1"},
+	})
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestUpdateEscalation() {
+	toUpdate := &CentreonEscalationDiff{
+		Name:   "esc1",
+		IsDiff: true,
+		ParamsToSet: map[string]string{
+			"name":              "esc2",
+			"last_notification": "5",
+		},
+		ContactGroupsToSet:    []string{"cg3"},
+		ContactGroupsToDelete: []string{"cg1"},
+		ServicesToSet:         []string{"host1,service2"},
+		ServicesToDelete:      []string{"host1,service1"},
+	}
+
+	// Normal use case
+	client, payloads := t.newCLAPIHandler(nil)
+	err := client.UpdateEscalation(toUpdate)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "esc2", toUpdate.Name)
+	assert.Equal(t.T(), []centreonapi.Payload{
+		{Action: "setparam", Object: "ESCALATION", Values: "esc1;name;esc2"},
+		{Action: "setparam", Object: "ESCALATION", Values: "esc2;last_notification;5"},
+		{Action: "addcontactgroup", Object: "ESCALATION", Values: "esc2;cg3"},
+		{Action: "addservice", Object: "ESCALATION", Values: "esc2;host1,service2"},
+		{Action: "delcontactgroup", Object: "ESCALATION", Values: "esc2;cg1"},
+		{Action: "delservice", Object: "ESCALATION", Values: "esc2;host1,service1"},
+	}, *payloads)
+
+	// When no diff
+	client, payloads = t.newCLAPIHandler(nil)
+	err = client.UpdateEscalation(&CentreonEscalationDiff{Name: "esc1"})
+	assert.NoError(t.T(), err)
+	assert.Empty(t.T(), *payloads)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"setparam": "error"})
+	err = client.UpdateEscalation(&CentreonEscalationDiff{
+		Name:        "esc1",
+		IsDiff:      true,
+		ParamsToSet: map[string]string{"comment": "test"},
+	})
+	assert.Error(t.T(), err)
+
+	// When use bad parameter
+	err = client.UpdateEscalation(nil)
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestDeleteEscalation() {
+	// Normal use case
+	client, payloads := t.newCLAPIHandler(nil)
+	err := client.DeleteEscalation("esc1")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []centreonapi.Payload{{Action: "del", Object: "ESCALATION", Values: "esc1"}}, *payloads)
+
+	// When not found
+	client, _ = t.newCLAPIHandler(map[string]string{"del": "Object not found"})
+	err = client.DeleteEscalation("esc1")
+	assert.NoError(t.T(), err)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"del": "error"})
+	err = client.DeleteEscalation("esc1")
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestGetEscalation() {
+	// Normal use case
+	client, _ := t.newCLAPIHandler(map[string]string{
+		"show":            `{"result": [{"id": "1", "name": "esc1", "alias": "my escalation", "first_notification": "2", "last_notification": "0", "notification_interval": "", "escalation_period": "24x7", "service_notification_options": "c,w", "comment": "test"}]}`,
+		"getcontactgroup": `{"result": [{"id": "1", "name": "cg1"}, {"id": "2", "name": "cg2"}]}`,
+		"getservice":      `{"result": [{"host_name": "host1", "service_description": "service1"}]}`,
+	})
+	escalation, err := client.GetEscalation("esc1")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), &CentreonEscalation{
+		Name:                "esc1",
+		Description:         "my escalation",
+		FirstNotification:   "2",
+		LastNotification:    "0",
+		Period:              "24x7",
+		NotificationOptions: "c,w",
+		Comment:             "test",
+		ContactGroups:       []string{"cg1", "cg2"},
+		Services:            []string{"host1,service1"},
+	}, escalation)
+
+	// When not found
+	client, _ = t.newCLAPIHandler(nil)
+	escalation, err = client.GetEscalation("esc1")
+	assert.NoError(t.T(), err)
+	assert.Nil(t.T(), escalation)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"show": "error"})
+	_, err = client.GetEscalation("esc1")
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestDiffEscalation() {
+	actual := &CentreonEscalation{
+		Name:              "esc1",
+		Description:       "my escalation",
+		FirstNotification: "2",
+		Comment:           "test",
+		ContactGroups:     []string{"cg1", "cg2"},
+		Services:          []string{"host1,service1"},
+	}
+
+	// When no diff
+	expected := &CentreonEscalation{
+		Name:              "esc1",
+		Description:       "my escalation",
+		FirstNotification: "2",
+		Comment:           "test",
+		ContactGroups:     []string{"cg2", "cg1"},
+		Services:          []string{"host1,service1"},
+	}
+	diff, err := t.client.DiffEscalation(actual, expected, nil)
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), diff.IsDiff)
+
+	// When diff
+	expected = &CentreonEscalation{
+		Name:              "esc1",
+		Description:       "my new escalation",
+		FirstNotification: "3",
+		Comment:           "test",
+		ContactGroups:     []string{"cg1"},
+		Services:          []string{"host1,service1", "host1,service2"},
+	}
+	diff, err = t.client.DiffEscalation(actual, expected, nil)
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), diff.IsDiff)
+	assert.Equal(t.T(), map[string]string{"alias": "my new escalation", "first_notification": "3"}, diff.ParamsToSet)
+	assert.Empty(t.T(), diff.ContactGroupsToSet)
+	assert.Equal(t.T(), []string{"cg2"}, diff.ContactGroupsToDelete)
+	assert.Equal(t.T(), []string{"host1,service2"}, diff.ServicesToSet)
+	assert.Empty(t.T(), diff.ServicesToDelete)
+
+	// When ignore fields
+	diff, err = t.client.DiffEscalation(actual, expected, []string{"description", "firstNotification", "contactGroups", "services"})
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), diff.IsDiff)
+}
@@ -1,6 +1,9 @@
 package centreonhandler
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/disaster37/go-centreon-rest/v21/models"
@@ -8,6 +11,11 @@ import (
 	"github.com/thoas/go-funk"
 )
 
+const (
+	// objectService is the CLAPI object to handle services
+	objectService string = "SERVICE"
+)
+
 // CreateService permit to create new service on Centreon from spec
 func (h *CentreonHandlerImpl) CreateService(service *CentreonService) (err error) {
 	if service == nil {
@@ -21,7 +29,7 @@ func (h *CentreonHandlerImpl) CreateService(service *CentreonService) (err error
 	}
 
 	// Create main object
-	if err = h.client.API.Service().Add(service.Host, service.Name, service.Template); err != nil {
+	if err = h.getClient().API.Service().Add(service.Host, service.Name, service.Template); err != nil {
 		return err
 	}
 	h.log.Debug("Create service core from Centreon")
@@ -37,10 +45,14 @@ func (h *CentreonHandlerImpl) CreateService(service *CentreonService) (err error
 		"active_checks_enabled":   service.ActiveCheckEnabled,
 		"passive_checks_enabled":  service.PassiveCheckEnabled,
 		"comment":                 service.Comment,
+		"notification_options":    service.NotificationOptions,
+		"notification_interval":   service.NotificationInterval,
+		"notification_period":     service.NotificationPeriod,
+		"notifications_enabled":   service.NotificationsEnabled,
 	}
 	for param, value := range params {
 		if value != "" {
-			if err = h.client.API.Service().SetParam(service.Host, service.Name, param, value); err != nil {
+			if err = h.getClient().API.Service().SetParam(service.Host, service.Name, param, value); err != nil {
 				return err
 			}
 			h.log.Debugf("Set param %s on service from Centreon", param)
@@ -49,7 +61,7 @@ func (h *CentreonHandlerImpl) CreateService(service *CentreonService) (err error
 
 	// Set service groups
 	if len(service.Groups) > 0 {
-		if err = h.client.API.Service().SetServiceGroups(service.Host, service.Name, service.Groups); err != nil {
+		if err = h.getClient().API.Service().SetServiceGroups(service.Host, service.Name, service.Groups); err != nil {
 			return err
 		}
 		h.log.Debugf("Set service groups %s from Centreon", strings.Join(service.Groups, "|"))
@@ -57,7 +69,7 @@ func (h *CentreonHandlerImpl) CreateService(service *CentreonService) (err error
 
 	// Set categories
 	if len(service.Categories) > 0 {
-		if err = h.client.API.Service().SetCategories(service.Host, service.Name, service.Categories); err != nil {
+		if err = h.getClient().API.Service().SetCategories(service.Host, service.Name, service.Categories); err != nil {
 			return err
 		}
 		h.log.Debugf("Set categories %s from Centreon", strings.Join(service.Categories, "|"))
@@ -66,13 +78,31 @@ func (h *CentreonHandlerImpl) CreateService(service *CentreonService) (err error
 	// Set macros
 	if len(service.Macros) > 0 {
 		for _, macro := range service.Macros {
-			if err = h.client.API.Service().SetMacro(service.Host, service.Name, macro); err != nil {
+			if err = h.getClient().API.Service().SetMacro(service.Host, service.Name, macro); err != nil {
 				return err
 			}
 			h.log.Debugf("Set macro %s from Centreon", macro.Name)
 		}
 	}
 
+	// Set contacts
+	if err = h.linkService("addcontact", service.Host, service.Name, service.Contacts); err != nil {
+		return err
+	}
+
+	// Set contact groups
+	if err = h.linkService("addcontactgroup", service.Host, service.Name, service.ContactGroups); err != nil {
+		return err
+	}
+
+	// Set owner
+	if service.Owner != nil {
+		if err = h.getClient().API.Service().SetMacro(service.Host, service.Name, ownerMacro(service.Owner)); err != nil {
+			return err
+		}
+		h.log.Debugf("Set owner %s from Centreon", service.Owner)
+	}
+
 	h.log.Debug("Create service successfully on Centreon")
 
 	return nil
@@ -96,31 +126,33 @@ func (h *CentreonHandlerImpl) UpdateService(serviceDiff *CentreonServiceDiff) (e
 		return nil
 	}
 
+	// Rename and move the service first, so the other changes are applied on its new identity
+	if err = h.moveService(serviceDiff); err != nil {
+		return err
+	}
+
 	// Update properties
-	if len(serviceDiff.ParamsToSet) > 0 {
-		for param, value := range serviceDiff.ParamsToSet {
-			err = h.client.API.Service().SetParam(serviceDiff.Host, serviceDiff.Name, param, value)
-			if err != nil {
-				return err
-			}
-			h.log.Debugf("Update param %s from Centreon", param)
-			// Handle special param name "description". It change service name
-			if param == "description" {
-				serviceDiff.Name = value
-			}
+	// They are applied in the same order on each update
+	for _, param := range slices.Sorted(maps.Keys(serviceDiff.ParamsToSet)) {
+		if param == "description" {
+			continue
 		}
+		if err = h.getClient().API.Service().SetParam(serviceDiff.Host, serviceDiff.Name, param, serviceDiff.ParamsToSet[param]); err != nil {
+			return err
+		}
+		h.log.Debugf("Update param %s from Centreon", param)
 	}
 
 	// Update service groups
 	if len(serviceDiff.GroupsToSet) > 0 {
-		err = h.client.API.Service().SetServiceGroups(serviceDiff.Host, serviceDiff.Name, serviceDiff.GroupsToSet)
+		err = h.getClient().API.Service().SetServiceGroups(serviceDiff.Host, serviceDiff.Name, serviceDiff.GroupsToSet)
 		if err != nil {
 			return err
 		}
 		h.log.Debugf("Set service groups %s from Centreon", strings.Join(serviceDiff.GroupsToSet, "|"))
 	}
 	if len(serviceDiff.GroupsToDelete) > 0 {
-		err = h.client.API.Service().DeleteServiceGroups(serviceDiff.Host, serviceDiff.Name, serviceDiff.GroupsToDelete)
+		err = h.getClient().API.Service().DeleteServiceGroups(serviceDiff.Host, serviceDiff.Name, serviceDiff.GroupsToDelete)
 		if err != nil {
 			return err
 		}
@@ -129,14 +161,14 @@ func (h *CentreonHandlerImpl) UpdateService(serviceDiff *CentreonServiceDiff) (e
 
 	// Update categories
 	if len(serviceDiff.CategoriesToSet) > 0 {
-		err = h.client.API.Service().SetCategories(serviceDiff.Host, serviceDiff.Name, serviceDiff.CategoriesToSet)
+		err = h.getClient().API.Service().SetCategories(serviceDiff.Host, serviceDiff.Name, serviceDiff.CategoriesToSet)
 		if err != nil {
 			return err
 		}
 		h.log.Debugf("Set categories %s from Centreon", strings.Join(serviceDiff.CategoriesToSet, "|"))
 	}
 	if len(serviceDiff.CategoriesToDelete) > 0 {
-		err = h.client.API.Service().DeleteCategories(serviceDiff.Host, serviceDiff.Name, serviceDiff.CategoriesToDelete)
+		err = h.getClient().API.Service().DeleteCategories(serviceDiff.Host, serviceDiff.Name, serviceDiff.CategoriesToDelete)
 		if err != nil {
 			return err
 		}
@@ -146,7 +178,7 @@ func (h *CentreonHandlerImpl) UpdateService(serviceDiff *CentreonServiceDiff) (e
 	// Update macros
 	if len(serviceDiff.MacrosToSet) > 0 {
 		for _, macro := range serviceDiff.MacrosToSet {
-			err = h.client.API.Service().SetMacro(serviceDiff.Host, serviceDiff.Name, macro)
+			err = h.getClient().API.Service().SetMacro(serviceDiff.Host, serviceDiff.Name, macro)
 			if err != nil {
 				return err
 			}
@@ -155,7 +187,7 @@ func (h *CentreonHandlerImpl) UpdateService(serviceDiff *CentreonServiceDiff) (e
 	}
 	if len(serviceDiff.MacrosToDelete) > 0 {
 		for _, macro := range serviceDiff.MacrosToDelete {
-			err = h.client.API.Service().DeleteMacro(serviceDiff.Host, serviceDiff.Name, macro.Name)
+			err = h.getClient().API.Service().DeleteMacro(serviceDiff.Host, serviceDiff.Name, macro.Name)
 			if err != nil {
 				return err
 			}
@@ -163,22 +195,77 @@ func (h *CentreonHandlerImpl) UpdateService(serviceDiff *CentreonServiceDiff) (e
 		}
 	}
 
-	// Finnaly update host
-	if serviceDiff.HostToSet != "" {
-		if err = h.client.API.Service().SetHost(serviceDiff.Host, serviceDiff.Name, serviceDiff.HostToSet); err != nil {
+	// Update contacts and contact groups
+	changes := []struct {
+		action string
+		items  []string
+	}{
+		{action: "addcontact", items: serviceDiff.ContactsToSet},
+		{action: "delcontact", items: serviceDiff.ContactsToDelete},
+		{action: "addcontactgroup", items: serviceDiff.ContactGroupsToSet},
+		{action: "delcontactgroup", items: serviceDiff.ContactGroupsToDelete},
+	}
+	for _, change := range changes {
+		if err = h.linkService(change.action, serviceDiff.Host, serviceDiff.Name, change.items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// linkService permit to add or delete the objects linked to service, like contacts
+// The service API not provide them, so it use CLAPI
+func (h *CentreonHandlerImpl) linkService(action, host, name string, items []string) (err error) {
+	if len(items) == 0 {
+		return nil
+	}
+
+	if err = h.clapi(action, objectService, fmt.Sprintf("%s;%s;%s", host, name, strings.Join(items, "|")), nil); err != nil {
+		return err
+	}
+	h.log.Debugf("%s %s on service from Centreon", action, strings.Join(items, "|"))
+
+	return nil
+}
+
+// moveService permit to rename the service and move it to another host as one operation
+// The rename is rolled back when the move failed, so the service is never left between its previous and its new identity
+func (h *CentreonHandlerImpl) moveService(serviceDiff *CentreonServiceDiff) (err error) {
+	name, isRename := serviceDiff.ParamsToSet["description"]
+	isRename = isRename && name != "" && name != serviceDiff.Name
+	isMove := serviceDiff.HostToSet != "" && serviceDiff.HostToSet != serviceDiff.Host
+
+	if isRename {
+		if err = h.getClient().API.Service().SetParam(serviceDiff.Host, serviceDiff.Name, "description", name); err != nil {
 			return err
 		}
-		h.log.Debugf("Set host %s on service %s from Centreon", serviceDiff.HostToSet, serviceDiff.Name)
+		h.log.Debugf("Rename service %s to %s from Centreon", serviceDiff.Name, name)
+	} else {
+		name = serviceDiff.Name
+	}
 
+	if isMove {
+		if err = h.getClient().API.Service().SetHost(serviceDiff.Host, name, serviceDiff.HostToSet); err != nil {
+			if isRename {
+				if errRollback := h.getClient().API.Service().SetParam(serviceDiff.Host, name, "description", serviceDiff.Name); errRollback != nil {
+					return errors.Wrapf(err, "Error when move service %s/%s to host %s, and error when rollback its name to %s: %s", serviceDiff.Host, name, serviceDiff.HostToSet, serviceDiff.Name, errRollback.Error())
+				}
+				h.log.Debugf("Rollback the name of service %s to %s from Centreon", name, serviceDiff.Name)
+			}
+			return errors.Wrapf(err, "Error when move service %s/%s to host %s", serviceDiff.Host, serviceDiff.Name, serviceDiff.HostToSet)
+		}
+		h.log.Debugf("Set host %s on service %s from Centreon", serviceDiff.HostToSet, name)
 		serviceDiff.Host = serviceDiff.HostToSet
 	}
+	serviceDiff.Name = name
 
 	return nil
 }
 
 // DeleteService permit to delete an existing service on Centreon
 func (h *CentreonHandlerImpl) DeleteService(host, name string) (err error) {
-	err = h.client.API.Service().Delete(host, name)
+	err = h.getClient().API.Service().Delete(host, name)
 
 	if err != nil && IsErrorNotFound(err) {
 		return nil
@@ -190,16 +277,25 @@ func (h *CentreonHandlerImpl) DeleteService(host, name string) (err error) {
 // DiffService permit to compare actual and expected service to compute what is modified
 func (h *CentreonHandlerImpl) DiffService(actual, expected *CentreonService, ignoreFields []string) (diff *CentreonServiceDiff, err error) {
 	diff = &CentreonServiceDiff{
-		Host:               actual.Host,
-		Name:               actual.Name,
-		IsDiff:             false,
-		ParamsToSet:        map[string]string{},
-		MacrosToSet:        make([]*models.Macro, 0),
-		MacrosToDelete:     make([]*models.Macro, 0),
-		GroupsToSet:        make([]string, 0),
-		GroupsToDelete:     make([]string, 0),
-		CategoriesToSet:    make([]string, 0),
-		CategoriesToDelete: make([]string, 0),
+		Host:                  actual.Host,
+		Name:                  actual.Name,
+		IsDiff:                false,
+		ParamsToSet:           map[string]string{},
+		MacrosToSet:           make([]*models.Macro, 0),
+		MacrosToDelete:        make([]*models.Macro, 0),
+		GroupsToSet:           make([]string, 0),
+		GroupsToDelete:        make([]string, 0),
+		CategoriesToSet:       make([]string, 0),
+		CategoriesToDelete:    make([]string, 0),
+		ContactsToSet:         make([]string, 0),
+		ContactsToDelete:      make([]string, 0),
+		ContactGroupsToSet:    make([]string, 0),
+		ContactGroupsToDelete: make([]string, 0),
+	}
+
+	// Check the owner before to compute the diff, to not update service managed by another resource
+	if err = CheckOwner(fmt.Sprintf("Service %s/%s", actual.Host, actual.Name), actual.Owner, expected.Owner); err != nil {
+		return nil, err
 	}
 
 	// Check params
@@ -236,6 +332,19 @@ func (h *CentreonHandlerImpl) DiffService(actual, expected *CentreonService, ign
 	if !funk.Contains(ignoreFields, "comment") && actual.Comment != expected.Comment {
 		diff.ParamsToSet["comment"] = expected.Comment
 	}
+	// The notification settings not set are not managed, so the values set on Centreon are kept
+	if !funk.Contains(ignoreFields, "notificationOptions") && expected.NotificationOptions != "" && actual.NotificationOptions != expected.NotificationOptions {
+		diff.ParamsToSet["notification_options"] = expected.NotificationOptions
+	}
+	if !funk.Contains(ignoreFields, "notificationInterval") && expected.NotificationInterval != "" && actual.NotificationInterval != expected.NotificationInterval {
+		diff.ParamsToSet["notification_interval"] = expected.NotificationInterval
+	}
+	if !funk.Contains(ignoreFields, "notificationPeriod") && expected.NotificationPeriod != "" && actual.NotificationPeriod != expected.NotificationPeriod {
+		diff.ParamsToSet["notification_period"] = expected.NotificationPeriod
+	}
+	if !funk.Contains(ignoreFields, "notificationsEnabled") && expected.NotificationsEnabled != "" && actual.NotificationsEnabled != expected.NotificationsEnabled {
+		diff.ParamsToSet["notifications_enabled"] = expected.NotificationsEnabled
+	}
 
 	// Check the host
 	if !funk.Contains(ignoreFields, "host") && actual.Host != expected.Host {
@@ -256,6 +365,17 @@ func (h *CentreonHandlerImpl) DiffService(actual, expected *CentreonService, ign
 		diff.CategoriesToDelete = catDelete.([]string)
 	}
 
+	// Check the contacts
+	// They are not managed when nil, so the contacts set on Centreon are kept
+	if !funk.Contains(ignoreFields, "contacts") && expected.Contacts != nil {
+		diff.ContactsToSet, diff.ContactsToDelete = funk.DifferenceString(expected.Contacts, actual.Contacts)
+	}
+
+	// Check the contact groups
+	if !funk.Contains(ignoreFields, "contactGroups") && expected.ContactGroups != nil {
+		diff.ContactGroupsToSet, diff.ContactGroupsToDelete = funk.DifferenceString(expected.ContactGroups, actual.ContactGroups)
+	}
+
 	// Check macros
 	if !funk.Contains(ignoreFields, "macros") {
 		if actual.Macros == nil {
@@ -283,7 +403,8 @@ func (h *CentreonHandlerImpl) DiffService(actual, expected *CentreonService, ign
 			isFound := false
 			for i, actualMacro := range macros {
 				if actualMacro.Name == expectedMacro.Name {
-					if actualMacro.Value == expectedMacro.Value && actualMacro.IsPassword == expectedMacro.IsPassword {
+					// Centreon not return the value of password macro, so we can only compare the type
+					if actualMacro.IsPassword == expectedMacro.IsPassword && (actualMacro.Value == expectedMacro.Value || expectedMacro.IsPassword == "1") {
 						isFound = true
 					}
 					macros = append(macros[:i], macros[i+1:]...)
@@ -298,14 +419,23 @@ func (h *CentreonHandlerImpl) DiffService(actual, expected *CentreonService, ign
 		// Remove indirect macro herited by templates or command (direct and null value)
 		// There are no way to differentiate macro setted between service and command
 		for _, macro := range macros {
-			if macro.Source == "direct" && macro.Value != "" {
+			if macro.Name == OwnerMacroName {
+				continue
+			}
+			if macro.Source == "direct" && (macro.Value != "" || macro.IsPassword == "1") {
 				diff.MacrosToDelete = append(diff.MacrosToDelete, macro)
 			}
 		}
 	}
 
+	// Check the owner
+	// It always set, so the service without owner is taken by the resource that manage it
+	if expected.Owner != nil && (actual.Owner == nil || *actual.Owner != *expected.Owner) {
+		diff.MacrosToSet = append(diff.MacrosToSet, ownerMacro(expected.Owner))
+	}
+
 	// Compute IsDiff
-	if len(diff.ParamsToSet) > 0 || len(diff.CategoriesToDelete) > 0 || len(diff.CategoriesToSet) > 0 || len(diff.GroupsToDelete) > 0 || len(diff.GroupsToSet) > 0 || len(diff.MacrosToDelete) > 0 || len(diff.MacrosToSet) > 0 || diff.HostToSet != "" {
+	if len(diff.ParamsToSet) > 0 || len(diff.CategoriesToDelete) > 0 || len(diff.CategoriesToSet) > 0 || len(diff.GroupsToDelete) > 0 || len(diff.GroupsToSet) > 0 || len(diff.MacrosToDelete) > 0 || len(diff.MacrosToSet) > 0 || len(diff.ContactsToSet) > 0 || len(diff.ContactsToDelete) > 0 || len(diff.ContactGroupsToSet) > 0 || len(diff.ContactGroupsToDelete) > 0 || diff.HostToSet != "" {
 		diff.IsDiff = true
 		h.log.Debugf("Some diff founds :%s", diff)
 	} else {
@@ -325,7 +455,7 @@ func (h *CentreonHandlerImpl) GetService(host, name string) (service *CentreonSe
 	}
 
 	// Get service from Centreon
-	baseService, err := h.client.API.Service().Get(host, name)
+	baseService, err := h.getClient().API.Service().Get(host, name)
 	if err != nil {
 		return nil, err
 	}
@@ -334,48 +464,142 @@ func (h *CentreonHandlerImpl) GetService(host, name string) (service *CentreonSe
 	}
 
 	// Get extras params
-	extras, err := h.client.API.Service().GetParam(host, name, []string{"template", "comment"})
+	extras, err := h.getClient().API.Service().GetParam(host, name, []string{"template", "comment", "notification_options", "notification_interval", "notification_period", "notifications_enabled"})
 	if err != nil {
 		return nil, err
 	}
 
 	// Get service groups
-	sgs, err := h.client.API.Service().GetServiceGroups(host, name)
+	sgs, err := h.getClient().API.Service().GetServiceGroups(host, name)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get catgeories
-	cats, err := h.client.API.Service().GetCategories(host, name)
+	cats, err := h.getClient().API.Service().GetCategories(host, name)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get macros
-	macros, err := h.client.API.Service().GetMacros(host, name)
+	macros, err := h.getClient().API.Service().GetMacros(host, name)
 	if err != nil {
 		return nil, err
 	}
+	owner, macros := extractOwner(macros)
 
 	service = &CentreonService{
-		Host:                host,
-		Name:                name,
-		Template:            extras["template"],
-		Comment:             extras["comment"],
-		CheckCommand:        baseService.CheckCommand,
-		CheckCommandArgs:    baseService.CheckCommandArgs,
-		NormalCheckInterval: baseService.NormalCheckInterval,
-		RetryCheckInterval:  baseService.RetryCheckInterval,
-		MaxCheckAttempts:    baseService.MaxCheckAttempts,
-		ActiveCheckEnabled:  baseService.ActiveCheckEnabled,
-		PassiveCheckEnabled: baseService.PassiveCheckEnabled,
-		Activated:           baseService.Activated,
-		Macros:              macros,
-		Categories:          cats,
-		Groups:              sgs,
+		Host:                 host,
+		Name:                 name,
+		Template:             extras["template"],
+		Comment:              extras["comment"],
+		CheckCommand:         baseService.CheckCommand,
+		CheckCommandArgs:     baseService.CheckCommandArgs,
+		NormalCheckInterval:  baseService.NormalCheckInterval,
+		RetryCheckInterval:   baseService.RetryCheckInterval,
+		MaxCheckAttempts:     baseService.MaxCheckAttempts,
+		ActiveCheckEnabled:   baseService.ActiveCheckEnabled,
+		PassiveCheckEnabled:  baseService.PassiveCheckEnabled,
+		Activated:            baseService.Activated,
+		Macros:               macros,
+		Categories:           cats,
+		Groups:               sgs,
+		NotificationOptions:  sortOptions(extras["notification_options"]),
+		NotificationInterval: extras["notification_interval"],
+		NotificationPeriod:   extras["notification_period"],
+		NotificationsEnabled: extras["notifications_enabled"],
+		Owner:                owner,
 	}
 
 	h.log.Debugf("Actual service: %s", service)
 
 	return service, nil
 }
+
+// GetServiceContacts permit to get the contacts set directly on service
+// They are not read by GetService, because the service API not provide them and it need extra call to CLAPI
+func (h *CentreonHandlerImpl) GetServiceContacts(host, name string) (contacts []string, err error) {
+	return h.getServiceLinks("getcontact", host, name)
+}
+
+// GetServiceContactGroups permit to get the contact groups set directly on service
+// They are not read by GetService, because the service API not provide them and it need extra call to CLAPI
+func (h *CentreonHandlerImpl) GetServiceContactGroups(host, name string) (contactGroups []string, err error) {
+	return h.getServiceLinks("getcontactgroup", host, name)
+}
+
+// getServiceLinks permit to get the name of objects linked to service, like contacts
+func (h *CentreonHandlerImpl) getServiceLinks(action, host, name string) (items []string, err error) {
+	if host == "" {
+		return nil, errors.New("Host must be provided")
+	}
+	if name == "" {
+		return nil, errors.New("Service name must be provided")
+	}
+
+	results := make([]nameResult, 0)
+	if err = h.clapi(action, objectService, fmt.Sprintf("%s;%s", host, name), &results); err != nil {
+		return nil, err
+	}
+
+	return names(results), nil
+}
+
+// GetServiceComment permit to get only the comment of service
+// It avoid to read the whole service when we only need to know who manage it
+func (h *CentreonHandlerImpl) GetServiceComment(host, name string) (comment string, err error) {
+	if host == "" {
+		return "", errors.New("Host must be provided")
+	}
+	if name == "" {
+		return "", errors.New("Service name must be provided")
+	}
+
+	extras, err := h.getClient().API.Service().GetParam(host, name, []string{"comment"})
+	if err != nil {
+		return "", err
+	}
+
+	return extras["comment"], nil
+}
+
+// GetServiceOwner permit to get only the owner of service
+// It return nil if the service not exist or if it has no owner
+func (h *CentreonHandlerImpl) GetServiceOwner(host, name string) (owner *Owner, err error) {
+	if host == "" {
+		return nil, errors.New("Host must be provided")
+	}
+	if name == "" {
+		return nil, errors.New("Service name must be provided")
+	}
+
+	macros, err := h.getClient().API.Service().GetMacros(host, name)
+	if err != nil {
+		if IsErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	owner, _ = extractOwner(macros)
+
+	return owner, nil
+}
+
+// ListServices permit to list all services on Centreon
+// It only return the host and the name of services, use GetService to read them
+func (h *CentreonHandlerImpl) ListServices() (services []*CentreonService, err error) {
+	baseServices, err := h.getClient().API.Service().List()
+	if err != nil {
+		return nil, err
+	}
+
+	services = make([]*CentreonService, 0, len(baseServices))
+	for _, baseService := range baseServices {
+		services = append(services, &CentreonService{
+			Host: baseService.HostName,
+			Name: baseService.Name,
+		})
+	}
+
+	return services, nil
+}
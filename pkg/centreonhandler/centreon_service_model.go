@@ -22,20 +22,35 @@ type CentreonService struct {
 	Groups              []string
 	Categories          []string
 	Macros              []*models.Macro
+	// Contacts and ContactGroups are not managed when nil
+	// They are only read with GetServiceContacts and GetServiceContactGroups
+	Contacts      []string
+	ContactGroups []string
+
+	// The notification settings are not managed when empty
+	NotificationOptions  string
+	NotificationInterval string
+	NotificationPeriod   string
+	NotificationsEnabled string
+	Owner                *Owner
 }
 
 type CentreonServiceDiff struct {
-	Host               string
-	Name               string
-	IsDiff             bool
-	GroupsToSet        []string
-	GroupsToDelete     []string
-	CategoriesToSet    []string
-	CategoriesToDelete []string
-	MacrosToSet        []*models.Macro
-	MacrosToDelete     []*models.Macro
-	ParamsToSet        map[string]string
-	HostToSet          string
+	Host                  string
+	Name                  string
+	IsDiff                bool
+	GroupsToSet           []string
+	GroupsToDelete        []string
+	CategoriesToSet       []string
+	CategoriesToDelete    []string
+	MacrosToSet           []*models.Macro
+	MacrosToDelete        []*models.Macro
+	ContactsToSet         []string
+	ContactsToDelete      []string
+	ContactGroupsToSet    []string
+	ContactGroupsToDelete []string
+	ParamsToSet           map[string]string
+	HostToSet             string
 }
 
 func (cs *CentreonService) String() string {
@@ -3,8 +3,10 @@ package centreonhandler
 import (
 	"testing"
 
+	centreonapi "github.com/disaster37/go-centreon-rest/v21/api"
 	"github.com/disaster37/go-centreon-rest/v21/models"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -84,6 +86,53 @@ func (t *CentreonHandlerTestSuite) TestCreateService() {
 	err := t.client.CreateService(toCreate)
 	assert.NoError(t.T(), err)
 
+	// When owner is provided
+	toCreate = &CentreonService{
+		Name:  "ping",
+		Host:  "central",
+		Owner: &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"},
+	}
+	t.mockService.EXPECT().
+		Add(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("")).
+		Return(nil)
+	t.mockService.EXPECT().
+		SetMacro(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq(&models.Macro{
+			Name:        OwnerMacroName,
+			Value:       "cluster1/default/ping/uid1",
+			IsPassword:  "0",
+			Description: ownerMacroDescription,
+		})).
+		Return(nil)
+	err = t.client.CreateService(toCreate)
+	assert.NoError(t.T(), err)
+
+	// When notifications are provided
+	toCreate = &CentreonService{
+		Name:                 "ping",
+		Host:                 "central",
+		Contacts:             []string{"user1", "user2"},
+		ContactGroups:        []string{"team1"},
+		NotificationOptions:  "c,w",
+		NotificationsEnabled: "1",
+	}
+	t.mockService.EXPECT().
+		Add(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("")).
+		Return(nil)
+	t.mockService.EXPECT().
+		SetParam(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("notification_options"), gomock.Eq("c,w")).
+		Return(nil)
+	t.mockService.EXPECT().
+		SetParam(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("notifications_enabled"), gomock.Eq("1")).
+		Return(nil)
+	clapiClient, payloads := t.newCLAPIClient(nil)
+	t.mockClient.EXPECT().Client().Times(2).Return(clapiClient.API.Client())
+	err = t.client.CreateService(toCreate)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []centreonapi.Payload{
+		{Action: "addcontact", Object: "SERVICE", Values: "central;ping;user1|user2"},
+		{Action: "addcontactgroup", Object: "SERVICE", Values: "central;ping;team1"},
+	}, *payloads)
+
 	// When bad parameters
 	err = t.client.CreateService(nil)
 	assert.Error(t.T(), err)
@@ -156,48 +205,123 @@ func (t *CentreonHandlerTestSuite) TestUpdateService() {
 		HostToSet: "central2",
 	}
 
-	// Mock set params on Centreon
-	t.mockService.EXPECT().
-		SetParam(gomock.Eq("central"), gomock.Any(), gomock.Eq("param1"), gomock.Eq("value1")).
-		Return(nil)
-	t.mockService.EXPECT().
-		SetParam(gomock.Eq("central"), gomock.Any(), gomock.Eq("param2"), gomock.Eq("value2")).
-		Return(nil)
-	t.mockService.EXPECT().
-		SetParam(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("description"), gomock.Eq("ping2")).
-		Return(nil)
+	// Mock rename and move service on Centreon
+	gomock.InOrder(
+		t.mockService.EXPECT().
+			SetParam(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("description"), gomock.Eq("ping2")).
+			Return(nil),
+		t.mockService.EXPECT().
+			SetHost(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq("central2")).
+			Return(nil),
+		t.mockService.EXPECT().
+			SetParam(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq("param1"), gomock.Eq("value1")).
+			Return(nil),
+		t.mockService.EXPECT().
+			SetParam(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq("param2"), gomock.Eq("value2")).
+			Return(nil),
+	)
 
-		// Mock set service groups
+	// Mock set service groups
 	t.mockService.EXPECT().
-		SetServiceGroups(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq([]string{"sg2"})).
+		SetServiceGroups(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq([]string{"sg2"})).
 		Return(nil)
 	t.mockService.EXPECT().
-		DeleteServiceGroups(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq([]string{"sg1"})).
+		DeleteServiceGroups(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq([]string{"sg1"})).
 		Return(nil)
 
 	// Mock set categories
 	t.mockService.EXPECT().
-		SetCategories(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq([]string{"cat2"})).
+		SetCategories(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq([]string{"cat2"})).
 		Return(nil)
 	t.mockService.EXPECT().
-		DeleteCategories(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq([]string{"cat1"})).
+		DeleteCategories(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq([]string{"cat1"})).
 		Return(nil)
 
 	// Mock set macros
 	t.mockService.EXPECT().
-		SetMacro(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq(macro2)).
+		SetMacro(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq(macro2)).
 		Return(nil)
 	t.mockService.EXPECT().
-		DeleteMacro(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq(macro1.Name)).
+		DeleteMacro(gomock.Eq("central2"), gomock.Eq("ping2"), gomock.Eq(macro1.Name)).
 		Return(nil)
 
-	// Mock set host
+	err := t.client.UpdateService(toUpdate)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "central2", toUpdate.Host)
+	assert.Equal(t.T(), "ping2", toUpdate.Name)
+
+	// When contacts and contact groups are updated
+	toUpdate = &CentreonServiceDiff{
+		IsDiff:                true,
+		Name:                  "ping",
+		Host:                  "central",
+		ParamsToSet:           map[string]string{"notification_interval": "60"},
+		ContactsToSet:         []string{"user2"},
+		ContactsToDelete:      []string{"user1"},
+		ContactGroupsToDelete: []string{"team1"},
+	}
 	t.mockService.EXPECT().
-		SetHost(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq("central2")).
+		SetParam(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("notification_interval"), gomock.Eq("60")).
 		Return(nil)
-
-	err := t.client.UpdateService(toUpdate)
+	clapiClient, payloads := t.newCLAPIClient(nil)
+	t.mockClient.EXPECT().Client().Times(3).Return(clapiClient.API.Client())
+	err = t.client.UpdateService(toUpdate)
 	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []centreonapi.Payload{
+		{Action: "addcontact", Object: "SERVICE", Values: "central;ping;user2"},
+		{Action: "delcontact", Object: "SERVICE", Values: "central;ping;user1"},
+		{Action: "delcontactgroup", Object: "SERVICE", Values: "central;ping;team1"},
+	}, *payloads)
+
+	// When update contacts failed
+	toUpdate = &CentreonServiceDiff{
+		IsDiff:        true,
+		Name:          "ping",
+		Host:          "central",
+		ContactsToSet: []string{"user2"},
+	}
+	clapiClient, _ = t.newCLAPIClient(map[string]string{"addcontact": "error"})
+	t.mockClient.EXPECT().Client().Times(1).Return(clapiClient.API.Client())
+	err = t.client.UpdateService(toUpdate)
+	assert.Error(t.T(), err)
+
+	// When move failed, the rename is rolled back
+	toUpdate = &CentreonServiceDiff{
+		IsDiff:      true,
+		Name:        "ping",
+		Host:        "central",
+		ParamsToSet: map[string]string{"description": "ping2"},
+		HostToSet:   "central2",
+	}
+	gomock.InOrder(
+		t.mockService.EXPECT().
+			SetParam(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("description"), gomock.Eq("ping2")).
+			Return(nil),
+		t.mockService.EXPECT().
+			SetHost(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq("central2")).
+			Return(errors.New("fake error")),
+		t.mockService.EXPECT().
+			SetParam(gomock.Eq("central"), gomock.Eq("ping2"), gomock.Eq("description"), gomock.Eq("ping")).
+			Return(nil),
+	)
+	err = t.client.UpdateService(toUpdate)
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), "central", toUpdate.Host)
+	assert.Equal(t.T(), "ping", toUpdate.Name)
+
+	// When rename failed, the service is not moved
+	toUpdate = &CentreonServiceDiff{
+		IsDiff:      true,
+		Name:        "ping",
+		Host:        "central",
+		ParamsToSet: map[string]string{"description": "ping2"},
+		HostToSet:   "central2",
+	}
+	t.mockService.EXPECT().
+		SetParam(gomock.Eq("central"), gomock.Eq("ping"), gomock.Eq("description"), gomock.Eq("ping2")).
+		Return(errors.New("fake error"))
+	err = t.client.UpdateService(toUpdate)
+	assert.Error(t.T(), err)
 
 	// When no diff
 	toUpdate = &CentreonServiceDiff{
@@ -273,6 +397,115 @@ func (t *CentreonHandlerTestSuite) TestDeleteService() {
 	assert.NoError(t.T(), err)
 }
 
+func (t *CentreonHandlerTestSuite) TestListServices() {
+	t.mockService.EXPECT().
+		List().
+		Return([]*models.ServiceGet{
+			{
+				ServiceBaseGet: &models.ServiceBaseGet{
+					HostName: "central",
+					Name:     "ping",
+				},
+			},
+		}, nil)
+
+	services, err := t.client.ListServices()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []*CentreonService{{Host: "central", Name: "ping"}}, services)
+
+	// When error
+	t.mockService.EXPECT().
+		List().
+		Return(nil, errors.New("boom"))
+	_, err = t.client.ListServices()
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestGetServiceComment() {
+	t.mockService.EXPECT().
+		GetParam(gomock.Eq("central"), gomock.Eq("ping"), []string{"comment"}).
+		Return(map[string]string{"comment": "my comment"}, nil)
+
+	comment, err := t.client.GetServiceComment("central", "ping")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "my comment", comment)
+
+	// When host is not provided
+	_, err = t.client.GetServiceComment("", "ping")
+	assert.Error(t.T(), err)
+
+	// When error
+	t.mockService.EXPECT().
+		GetParam(gomock.Eq("central"), gomock.Eq("ping"), []string{"comment"}).
+		Return(nil, errors.New("boom"))
+	_, err = t.client.GetServiceComment("central", "ping")
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestGetServiceOwner() {
+	t.mockService.EXPECT().
+		GetMacros(gomock.Eq("central"), gomock.Eq("ping")).
+		Return([]*models.Macro{
+			{Name: "USER", Value: "user"},
+			{Name: OwnerMacroName, Value: "cluster1/default/ping/uid1"},
+		}, nil)
+
+	owner, err := t.client.GetServiceOwner("central", "ping")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"}, owner)
+
+	// When no owner
+	t.mockService.EXPECT().
+		GetMacros(gomock.Eq("central"), gomock.Eq("ping")).
+		Return([]*models.Macro{{Name: "USER", Value: "user"}}, nil)
+	owner, err = t.client.GetServiceOwner("central", "ping")
+	assert.NoError(t.T(), err)
+	assert.Nil(t.T(), owner)
+
+	// When service not found
+	t.mockService.EXPECT().
+		GetMacros(gomock.Eq("central"), gomock.Eq("ping")).
+		Return(nil, errors.New("Object not found"))
+	owner, err = t.client.GetServiceOwner("central", "ping")
+	assert.NoError(t.T(), err)
+	assert.Nil(t.T(), owner)
+
+	// When error
+	t.mockService.EXPECT().
+		GetMacros(gomock.Eq("central"), gomock.Eq("ping")).
+		Return(nil, errors.New("boom"))
+	_, err = t.client.GetServiceOwner("central", "ping")
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestGetServiceContacts() {
+	clapiClient, payloads := t.newCLAPIClient(map[string]string{
+		"getcontact":      `{"result": [{"id": "1", "name": "user1"}]}`,
+		"getcontactgroup": `{"result": [{"id": "1", "name": "team1"}, {"id": "2", "name": "team2"}]}`,
+	})
+	t.mockClient.EXPECT().Client().Times(2).Return(clapiClient.API.Client())
+
+	contacts, err := t.client.GetServiceContacts("central", "ping")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []string{"user1"}, contacts)
+
+	contactGroups, err := t.client.GetServiceContactGroups("central", "ping")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []string{"team1", "team2"}, contactGroups)
+
+	assert.Equal(t.T(), []centreonapi.Payload{
+		{Action: "getcontact", Object: "SERVICE", Values: "central;ping"},
+		{Action: "getcontactgroup", Object: "SERVICE", Values: "central;ping"},
+	}, *payloads)
+
+	// When bad parameters
+	_, err = t.client.GetServiceContacts("", "ping")
+	assert.Error(t.T(), err)
+
+	_, err = t.client.GetServiceContactGroups("central", "")
+	assert.Error(t.T(), err)
+}
+
 func (t *CentreonHandlerTestSuite) TestGetService() {
 	macro1 := &models.Macro{
 		Name:       "macro1",
@@ -280,21 +513,25 @@ func (t *CentreonHandlerTestSuite) TestGetService() {
 		IsPassword: "0",
 	}
 	expected := &CentreonService{
-		Name:                "ping",
-		Host:                "central",
-		Template:            "my-template",
-		CheckCommand:        "ping",
-		CheckCommandArgs:    "!arg1",
-		Groups:              []string{"sg1"},
-		Categories:          []string{"cat1"},
-		Macros:              []*models.Macro{macro1},
-		Activated:           "1",
-		PassiveCheckEnabled: "2",
-		ActiveCheckEnabled:  "2",
-		Comment:             "my comment",
-		NormalCheckInterval: "30s",
-		RetryCheckInterval:  "1s",
-		MaxCheckAttempts:    "3",
+		Name:                 "ping",
+		Host:                 "central",
+		Template:             "my-template",
+		CheckCommand:         "ping",
+		CheckCommandArgs:     "!arg1",
+		Groups:               []string{"sg1"},
+		Categories:           []string{"cat1"},
+		Macros:               []*models.Macro{macro1},
+		Activated:            "1",
+		PassiveCheckEnabled:  "2",
+		ActiveCheckEnabled:   "2",
+		Comment:              "my comment",
+		NormalCheckInterval:  "30s",
+		RetryCheckInterval:   "1s",
+		MaxCheckAttempts:     "3",
+		NotificationOptions:  "c,w",
+		NotificationInterval: "30",
+		NotificationPeriod:   "24x7",
+		NotificationsEnabled: "1",
 	}
 
 	cs := &models.ServiceGet{
@@ -319,8 +556,15 @@ func (t *CentreonHandlerTestSuite) TestGetService() {
 
 	// Mock get params
 	t.mockService.EXPECT().
-		GetParam(gomock.Eq("central"), gomock.Eq("ping"), []string{"template", "comment"}).
-		Return(map[string]string{"template": "my-template", "comment": "my comment"}, nil)
+		GetParam(gomock.Eq("central"), gomock.Eq("ping"), []string{"template", "comment", "notification_options", "notification_interval", "notification_period", "notifications_enabled"}).
+		Return(map[string]string{
+			"template":              "my-template",
+			"comment":               "my comment",
+			"notification_options":  "w,c",
+			"notification_interval": "30",
+			"notification_period":   "24x7",
+			"notifications_enabled": "1",
+		}, nil)
 
 	// Mock get macros
 	t.mockService.EXPECT().
@@ -337,6 +581,7 @@ func (t *CentreonHandlerTestSuite) TestGetService() {
 		GetServiceGroups(gomock.Eq("central"), gomock.Eq("ping")).
 		Return([]string{"sg1"}, nil)
 
+	// The contacts are not read, so there are no call to CLAPI
 	service, err := t.client.GetService("central", "ping")
 	assert.NoError(t.T(), err)
 	assert.Equal(t.T(), expected, service)
@@ -376,16 +621,20 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 				Name: "ping",
 			},
 			ExpectedDiff: &CentreonServiceDiff{
-				IsDiff:             false,
-				Host:               "central",
-				Name:               "ping",
-				ParamsToSet:        map[string]string{},
-				GroupsToSet:        make([]string, 0),
-				GroupsToDelete:     make([]string, 0),
-				CategoriesToSet:    make([]string, 0),
-				CategoriesToDelete: make([]string, 0),
-				MacrosToSet:        make([]*models.Macro, 0),
-				MacrosToDelete:     make([]*models.Macro, 0),
+				IsDiff:                false,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
 			},
 		},
 		{
@@ -439,16 +688,20 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 				},
 			},
 			ExpectedDiff: &CentreonServiceDiff{
-				IsDiff:             false,
-				Host:               "central",
-				Name:               "ping",
-				ParamsToSet:        map[string]string{},
-				GroupsToSet:        make([]string, 0),
-				GroupsToDelete:     make([]string, 0),
-				CategoriesToSet:    make([]string, 0),
-				CategoriesToDelete: make([]string, 0),
-				MacrosToSet:        make([]*models.Macro, 0),
-				MacrosToDelete:     make([]*models.Macro, 0),
+				IsDiff:                false,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
 			},
 		},
 		{
@@ -517,10 +770,14 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 					"active_checks_enabled":   "1",
 					"passive_checks_enabled":  "1",
 				},
-				GroupsToSet:        []string{"sg2"},
-				GroupsToDelete:     []string{"sg1"},
-				CategoriesToSet:    []string{"cat2"},
-				CategoriesToDelete: []string{"cat1"},
+				GroupsToSet:           []string{"sg2"},
+				GroupsToDelete:        []string{"sg1"},
+				CategoriesToSet:       []string{"cat2"},
+				CategoriesToDelete:    []string{"cat1"},
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
 				MacrosToSet: []*models.Macro{
 					{
 						Name:       "macro2",
@@ -539,6 +796,153 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 				},
 			},
 		},
+		{
+			Name: "Need update notifications",
+			ActualService: &CentreonService{
+				Host:                 "central",
+				Name:                 "ping",
+				Contacts:             []string{"user1"},
+				ContactGroups:        []string{"team1"},
+				NotificationOptions:  "c,w",
+				NotificationInterval: "30",
+				NotificationPeriod:   "24x7",
+				NotificationsEnabled: "2",
+			},
+			ExpectedService: &CentreonService{
+				Host:                 "central",
+				Name:                 "ping",
+				Contacts:             []string{"user2"},
+				ContactGroups:        []string{"team1", "team2"},
+				NotificationOptions:  "c,r,w",
+				NotificationInterval: "60",
+				NotificationPeriod:   "workhours",
+				NotificationsEnabled: "1",
+			},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff: true,
+				Host:   "central",
+				Name:   "ping",
+				ParamsToSet: map[string]string{
+					"notification_options":  "c,r,w",
+					"notification_interval": "60",
+					"notification_period":   "workhours",
+					"notifications_enabled": "1",
+				},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         []string{"user2"},
+				ContactsToDelete:      []string{"user1"},
+				ContactGroupsToSet:    []string{"team2"},
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
+			},
+		},
+		{
+			Name: "No Need update because of notifications are not managed",
+			ActualService: &CentreonService{
+				Host:                 "central",
+				Name:                 "ping",
+				Contacts:             []string{"user1"},
+				ContactGroups:        []string{"team1"},
+				NotificationOptions:  "c,w",
+				NotificationInterval: "30",
+				NotificationPeriod:   "24x7",
+				NotificationsEnabled: "1",
+			},
+			ExpectedService: &CentreonService{
+				Host: "central",
+				Name: "ping",
+			},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff:                false,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
+			},
+		},
+		{
+			Name: "Need remove all contacts when empty list is managed",
+			ActualService: &CentreonService{
+				Host:          "central",
+				Name:          "ping",
+				Contacts:      []string{"user1"},
+				ContactGroups: []string{"team1"},
+			},
+			ExpectedService: &CentreonService{
+				Host:     "central",
+				Name:     "ping",
+				Contacts: []string{},
+			},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff:                true,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      []string{"user1"},
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
+			},
+		},
+		{
+			Name: "No Need update because of exclude notifications",
+			ActualService: &CentreonService{
+				Host:                 "central",
+				Name:                 "ping",
+				Contacts:             []string{"user1"},
+				ContactGroups:        []string{"team1"},
+				NotificationOptions:  "c,w",
+				NotificationInterval: "30",
+				NotificationPeriod:   "24x7",
+				NotificationsEnabled: "2",
+			},
+			ExpectedService: &CentreonService{
+				Host:                 "central",
+				Name:                 "ping",
+				Contacts:             []string{"user2"},
+				ContactGroups:        []string{"team1", "team2"},
+				NotificationOptions:  "c,r,w",
+				NotificationInterval: "60",
+				NotificationPeriod:   "workhours",
+				NotificationsEnabled: "1",
+			},
+			IgnoreFields: []string{"contacts", "contactGroups", "notificationOptions", "notificationInterval", "notificationPeriod", "notificationsEnabled"},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff:                false,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
+			},
+		},
 		{
 			Name: "No Need update because of exclude macro",
 			ActualService: &CentreonService{
@@ -589,16 +993,20 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 				Macros:              []*models.Macro{},
 			},
 			ExpectedDiff: &CentreonServiceDiff{
-				IsDiff:             false,
-				Host:               "central",
-				Name:               "ping",
-				ParamsToSet:        map[string]string{},
-				GroupsToSet:        make([]string, 0),
-				GroupsToDelete:     make([]string, 0),
-				CategoriesToSet:    make([]string, 0),
-				CategoriesToDelete: make([]string, 0),
-				MacrosToSet:        make([]*models.Macro, 0),
-				MacrosToDelete:     make([]*models.Macro, 0),
+				IsDiff:                false,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
 			},
 		},
 		{
@@ -669,16 +1077,20 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 				"macros",
 			},
 			ExpectedDiff: &CentreonServiceDiff{
-				IsDiff:             false,
-				Host:               "central",
-				Name:               "ping",
-				ParamsToSet:        map[string]string{},
-				GroupsToSet:        make([]string, 0),
-				GroupsToDelete:     make([]string, 0),
-				CategoriesToSet:    make([]string, 0),
-				CategoriesToDelete: make([]string, 0),
-				MacrosToSet:        make([]*models.Macro, 0),
-				MacrosToDelete:     make([]*models.Macro, 0),
+				IsDiff:                false,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
 			},
 		},
 		{
@@ -739,12 +1151,188 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 				ParamsToSet: map[string]string{
 					"description": "ping2",
 				},
-				GroupsToSet:        make([]string, 0),
-				GroupsToDelete:     make([]string, 0),
-				CategoriesToSet:    make([]string, 0),
-				CategoriesToDelete: make([]string, 0),
-				MacrosToSet:        make([]*models.Macro, 0),
-				MacrosToDelete:     make([]*models.Macro, 0),
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
+			},
+		},
+		{
+			Name: "Password macro value is not compared",
+			ActualService: &CentreonService{
+				Host: "central",
+				Name: "ping",
+				Macros: []*models.Macro{
+					{
+						Name:       "TOKEN",
+						Value:      "",
+						Source:     "direct",
+						IsPassword: "1",
+					},
+					{
+						Name:       "OLD_TOKEN",
+						Value:      "",
+						Source:     "direct",
+						IsPassword: "1",
+					},
+					{
+						Name:       "USER",
+						Value:      "user",
+						Source:     "direct",
+						IsPassword: "0",
+					},
+				},
+			},
+			ExpectedService: &CentreonService{
+				Host: "central",
+				Name: "ping",
+				Macros: []*models.Macro{
+					{
+						Name:       "TOKEN",
+						Value:      "secret",
+						IsPassword: "1",
+					},
+					{
+						Name:       "USER",
+						Value:      "user",
+						IsPassword: "1",
+					},
+				},
+			},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff:                true,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet: []*models.Macro{
+					{
+						Name:       "USER",
+						Value:      "user",
+						IsPassword: "1",
+					},
+				},
+				MacrosToDelete: []*models.Macro{
+					{
+						Name:       "OLD_TOKEN",
+						Value:      "",
+						Source:     "direct",
+						IsPassword: "1",
+					},
+				},
+			},
+		},
+		{
+			Name: "Owner is set on service without owner",
+			ActualService: &CentreonService{
+				Host: "central",
+				Name: "ping",
+			},
+			ExpectedService: &CentreonService{
+				Host:  "central",
+				Name:  "ping",
+				Owner: &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid2"},
+			},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff:                true,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet: []*models.Macro{
+					{
+						Name:        OwnerMacroName,
+						Value:       "cluster1/default/ping/uid2",
+						IsPassword:  "0",
+						Description: ownerMacroDescription,
+					},
+				},
+				MacrosToDelete: make([]*models.Macro, 0),
+			},
+		},
+		{
+			Name: "Owner is updated when resource is recreated",
+			ActualService: &CentreonService{
+				Host:  "central",
+				Name:  "ping",
+				Owner: &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"},
+			},
+			ExpectedService: &CentreonService{
+				Host:  "central",
+				Name:  "ping",
+				Owner: &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid2"},
+			},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff:                true,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet: []*models.Macro{
+					{
+						Name:        OwnerMacroName,
+						Value:       "cluster1/default/ping/uid2",
+						IsPassword:  "0",
+						Description: ownerMacroDescription,
+					},
+				},
+				MacrosToDelete: make([]*models.Macro, 0),
+			},
+		},
+		{
+			Name: "Owner is the same",
+			ActualService: &CentreonService{
+				Host:  "central",
+				Name:  "ping",
+				Owner: &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"},
+			},
+			ExpectedService: &CentreonService{
+				Host:  "central",
+				Name:  "ping",
+				Owner: &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"},
+			},
+			ExpectedDiff: &CentreonServiceDiff{
+				IsDiff:                false,
+				Host:                  "central",
+				Name:                  "ping",
+				ParamsToSet:           map[string]string{},
+				GroupsToSet:           make([]string, 0),
+				GroupsToDelete:        make([]string, 0),
+				CategoriesToSet:       make([]string, 0),
+				CategoriesToDelete:    make([]string, 0),
+				ContactsToSet:         make([]string, 0),
+				ContactsToDelete:      make([]string, 0),
+				ContactGroupsToSet:    make([]string, 0),
+				ContactGroupsToDelete: make([]string, 0),
+				MacrosToSet:           make([]*models.Macro, 0),
+				MacrosToDelete:        make([]*models.Macro, 0),
 			},
 		},
 	}
@@ -754,6 +1342,15 @@ func (t *CentreonHandlerTestSuite) TestDiffService() {
 		assert.NoErrorf(t.T(), err, test.Name)
 		assert.Equalf(t.T(), test.ExpectedDiff, diff, test.Name)
 	}
+
+	// When service is managed by another cluster
+	_, err := t.client.DiffService(
+		&CentreonService{Host: "central", Name: "ping", Owner: &Owner{ClusterID: "cluster2", Namespace: "default", Name: "ping", UID: "uid1"}},
+		&CentreonService{Host: "central", Name: "ping", Owner: &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"}},
+		nil,
+	)
+	assert.Error(t.T(), err)
+	assert.True(t.T(), IsOwnerConflict(err))
 }
 
 func TestCentreonServiceToString(t *testing.T) {
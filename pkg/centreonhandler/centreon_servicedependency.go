@@ -0,0 +1,246 @@
+package centreonhandler
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+const (
+	// objectServiceDependency is the CLAPI object to handle dependencies
+	objectServiceDependency string = "DEP"
+)
+
+// serviceDependencyResult is the service dependency returned by CLAPI
+type serviceDependencyResult struct {
+	Name                        string `json:"name"`
+	Description                 string `json:"description"`
+	InheritsParent              string `json:"inherits_parent"`
+	ExecutionFailureCriteria    string `json:"execution_failure_criteria"`
+	NotificationFailureCriteria string `json:"notification_failure_criteria"`
+}
+
+// serviceDependencyLinkResult is the services of dependency returned by CLAPI
+type serviceDependencyLinkResult struct {
+	Parents  string `json:"parents"`
+	Children string `json:"children"`
+}
+
+// CreateServiceDependency permit to create new service dependency on Centreon from spec
+func (h *CentreonHandlerImpl) CreateServiceDependency(dependency *CentreonServiceDependency) (err error) {
+	if dependency == nil {
+		return errors.New("ServiceDependency must be provided")
+	}
+	if dependency.Name == "" {
+		return errors.New("ServiceDependency name must be provided")
+	}
+	if dependency.Description == "" {
+		return errors.New("ServiceDependency description must be provided")
+	}
+	if len(dependency.ParentServices) == 0 {
+		return errors.New("ServiceDependency parent services must be provided")
+	}
+	if len(dependency.ChildServices) == 0 {
+		return errors.New("ServiceDependency child services must be provided")
+	}
+
+	// Create main object with the parent services
+	if err = h.clapi("add", objectServiceDependency, fmt.Sprintf("%s;%s;SERVICE;%s", dependency.Name, dependency.Description, strings.Join(dependency.ParentServices, "|")), nil); err != nil {
+		return err
+	}
+	h.log.Debug("Create service dependency core from Centreon")
+
+	// Set extra params
+	params := map[string]string{
+		"inherits_parent":               dependency.InheritsParent,
+		"execution_failure_criteria":    dependency.ExecutionFailureCriteria,
+		"notification_failure_criteria": dependency.NotificationFailureCriteria,
+	}
+	for _, param := range slices.Sorted(maps.Keys(params)) {
+		if params[param] != "" {
+			if err = h.clapi("setparam", objectServiceDependency, fmt.Sprintf("%s;%s;%s", dependency.Name, param, params[param]), nil); err != nil {
+				return err
+			}
+			h.log.Debugf("Set param %s on service dependency from Centreon", param)
+		}
+	}
+
+	// Set the child services
+	for _, service := range dependency.ChildServices {
+		if err = h.clapi("addchild", objectServiceDependency, fmt.Sprintf("%s;%s", dependency.Name, service), nil); err != nil {
+			return err
+		}
+		h.log.Debugf("Add child service %s on service dependency from Centreon", service)
+	}
+
+	h.log.Debug("Create service dependency successfully on Centreon")
+
+	return nil
+}
+
+// UpdateServiceDependency permit to update existing service dependency on Centreon from spec
+func (h *CentreonHandlerImpl) UpdateServiceDependency(dependencyDiff *CentreonServiceDependencyDiff) (err error) {
+	if dependencyDiff == nil {
+		return errors.New("ServiceDependencyDiff must be provided")
+	}
+	if dependencyDiff.Name == "" {
+		return errors.New("ServiceDependency name must be provided")
+	}
+
+	if !dependencyDiff.IsDiff {
+		h.log.Debug("No update needed, skip it")
+		return nil
+	}
+
+	// Rename first, so the other changes are applied on the new name
+	if name, ok := dependencyDiff.ParamsToSet["name"]; ok {
+		if err = h.clapi("setparam", objectServiceDependency, fmt.Sprintf("%s;name;%s", dependencyDiff.Name, name), nil); err != nil {
+			return err
+		}
+		h.log.Debugf("Rename service dependency %s to %s from Centreon", dependencyDiff.Name, name)
+		dependencyDiff.Name = name
+	}
+
+	// Update properties
+	for _, param := range slices.Sorted(maps.Keys(dependencyDiff.ParamsToSet)) {
+		if param == "name" {
+			continue
+		}
+		if err = h.clapi("setparam", objectServiceDependency, fmt.Sprintf("%s;%s;%s", dependencyDiff.Name, param, dependencyDiff.ParamsToSet[param]), nil); err != nil {
+			return err
+		}
+		h.log.Debugf("Update param %s from Centreon", param)
+	}
+
+	// Update the services. They are added before to be removed, because a dependency need at least one parent and one child
+	changes := []struct {
+		action   string
+		services []string
+	}{
+		{action: "addparent", services: dependencyDiff.ParentServicesToSet},
+		{action: "addchild", services: dependencyDiff.ChildServicesToSet},
+		{action: "delparent", services: dependencyDiff.ParentServicesToDelete},
+		{action: "delchild", services: dependencyDiff.ChildServicesToDelete},
+	}
+	for _, change := range changes {
+		for _, service := range change.services {
+			if err = h.clapi(change.action, objectServiceDependency, fmt.Sprintf("%s;%s", dependencyDiff.Name, service), nil); err != nil {
+				return err
+			}
+			h.log.Debugf("%s %s on service dependency from Centreon", change.action, service)
+		}
+	}
+
+	return nil
+}
+
+// DeleteServiceDependency permit to delete an existing service dependency on Centreon
+func (h *CentreonHandlerImpl) DeleteServiceDependency(name string) (err error) {
+	if name == "" {
+		return errors.New("ServiceDependency name must be provided")
+	}
+
+	err = h.clapi("del", objectServiceDependency, name, nil)
+	if err != nil && IsErrorNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// GetServiceDependency permit to get service dependency by it name
+func (h *CentreonHandlerImpl) GetServiceDependency(name string) (dependency *CentreonServiceDependency, err error) {
+	if name == "" {
+		return nil, errors.New("ServiceDependency name must be provided")
+	}
+
+	// The show action search the dependencies that contain the name
+	dependencies := make([]serviceDependencyResult, 0)
+	if err = h.clapi("show", objectServiceDependency, name, &dependencies); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(dependencies, func(d serviceDependencyResult) bool {
+		return d.Name == name
+	})
+	if i < 0 {
+		return nil, nil
+	}
+
+	// Get the services
+	links := make([]serviceDependencyLinkResult, 0)
+	if err = h.clapi("listdep", objectServiceDependency, name, &links); err != nil {
+		return nil, err
+	}
+
+	dependency = &CentreonServiceDependency{
+		Name:                        name,
+		Description:                 dependencies[i].Description,
+		InheritsParent:              dependencies[i].InheritsParent,
+		ExecutionFailureCriteria:    sortOptions(dependencies[i].ExecutionFailureCriteria),
+		NotificationFailureCriteria: sortOptions(dependencies[i].NotificationFailureCriteria),
+		ParentServices:              make([]string, 0),
+		ChildServices:               make([]string, 0),
+	}
+	for _, link := range links {
+		dependency.ParentServices = append(dependency.ParentServices, splitList(link.Parents)...)
+		dependency.ChildServices = append(dependency.ChildServices, splitList(link.Children)...)
+	}
+
+	h.log.Debugf("Actual service dependency: %s", dependency)
+
+	return dependency, nil
+}
+
+// DiffServiceDependency permit to diff actual and expected service dependency to know what it need to modify
+func (h *CentreonHandlerImpl) DiffServiceDependency(actual, expected *CentreonServiceDependency, ignoreFields []string) (diff *CentreonServiceDependencyDiff, err error) {
+	diff = &CentreonServiceDependencyDiff{
+		Name:                   actual.Name,
+		IsDiff:                 false,
+		ParamsToSet:            map[string]string{},
+		ParentServicesToSet:    make([]string, 0),
+		ParentServicesToDelete: make([]string, 0),
+		ChildServicesToSet:     make([]string, 0),
+		ChildServicesToDelete:  make([]string, 0),
+	}
+
+	// Check params
+	if !funk.Contains(ignoreFields, "name") && actual.Name != expected.Name {
+		diff.ParamsToSet["name"] = expected.Name
+	}
+	if !funk.Contains(ignoreFields, "description") && actual.Description != expected.Description {
+		diff.ParamsToSet["description"] = expected.Description
+	}
+	if !funk.Contains(ignoreFields, "inheritsParent") && actual.InheritsParent != expected.InheritsParent {
+		diff.ParamsToSet["inherits_parent"] = expected.InheritsParent
+	}
+	if !funk.Contains(ignoreFields, "executionFailureCriteria") && actual.ExecutionFailureCriteria != expected.ExecutionFailureCriteria {
+		diff.ParamsToSet["execution_failure_criteria"] = expected.ExecutionFailureCriteria
+	}
+	if !funk.Contains(ignoreFields, "notificationFailureCriteria") && actual.NotificationFailureCriteria != expected.NotificationFailureCriteria {
+		diff.ParamsToSet["notification_failure_criteria"] = expected.NotificationFailureCriteria
+	}
+
+	// Check the parent services
+	if !funk.Contains(ignoreFields, "services") {
+		diff.ParentServicesToSet, diff.ParentServicesToDelete = funk.DifferenceString(expected.ParentServices, actual.ParentServices)
+	}
+
+	// Check the child services
+	if !funk.Contains(ignoreFields, "dependentServices") {
+		diff.ChildServicesToSet, diff.ChildServicesToDelete = funk.DifferenceString(expected.ChildServices, actual.ChildServices)
+	}
+
+	// Compute IsDiff
+	if len(diff.ParamsToSet) > 0 || len(diff.ParentServicesToSet) > 0 || len(diff.ParentServicesToDelete) > 0 || len(diff.ChildServicesToSet) > 0 || len(diff.ChildServicesToDelete) > 0 {
+		diff.IsDiff = true
+		h.log.Debugf("Some diff founds :%s", diff)
+	} else {
+		h.log.Debug("No diff found")
+	}
+
+	return diff, nil
+}
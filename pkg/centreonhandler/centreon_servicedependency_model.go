@@ -0,0 +1,45 @@
+package centreonhandler
+
+import (
+	"encoding/json"
+)
+
+// CentreonServiceDependency is a dependency between services
+// The services are referenced with the format `host,service`
+type CentreonServiceDependency struct {
+	Name                        string
+	Description                 string
+	InheritsParent              string
+	ExecutionFailureCriteria    string
+	NotificationFailureCriteria string
+	ParentServices              []string
+	ChildServices               []string
+}
+
+type CentreonServiceDependencyDiff struct {
+	Name                   string
+	IsDiff                 bool
+	ParamsToSet            map[string]string
+	ParentServicesToSet    []string
+	ParentServicesToDelete []string
+	ChildServicesToSet     []string
+	ChildServicesToDelete  []string
+}
+
+func (csd *CentreonServiceDependency) String() string {
+	b, err := json.Marshal(csd)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+func (csdd *CentreonServiceDependencyDiff) String() string {
+	b, err := json.Marshal(csdd)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
@@ -0,0 +1,200 @@
+package centreonhandler
+
+import (
+	centreonapi "github.com/disaster37/go-centreon-rest/v21/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *CentreonHandlerTestSuite) TestCreateServiceDependency() {
+	toCreate := &CentreonServiceDependency{
+		Name:                        "dep1",
+		Description:                 "my dependency",
+		InheritsParent:              "1",
+		ExecutionFailureCriteria:    "c,w",
+		NotificationFailureCriteria: "",
+		ParentServices:              []string{"host1,service1", "host1,service2"},
+		ChildServices:               []string{"host2,service1"},
+	}
+
+	// Normal use case
+	client, payloads := t.newCLAPIHandler(nil)
+	err := client.CreateServiceDependency(toCreate)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []centreonapi.Payload{
+		{Action: "add", Object: "DEP", Values: "dep1;my dependency;SERVICE;host1,service1|host1,service2"},
+		{Action: "setparam", Object: "DEP", Values: "dep1;execution_failure_criteria;c,w"},
+		{Action: "setparam", Object: "DEP", Values: "dep1;inherits_parent;1"},
+		{Action: "addchild", Object: "DEP", Values: "dep1;host2,service1"},
+	}, *payloads)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"add": "error"})
+	err = client.CreateServiceDependency(toCreate)
+	assert.Error(t.T(), err)
+
+	// When use bad parameter
+	err = client.CreateServiceDependency(nil)
+	assert.Error(t.T(), err)
+
+	// When no parent services
+	err = client.CreateServiceDependency(&CentreonServiceDependency{
+		Name:          "dep1",
+		Description:   "my dependency",
+		ChildServices: []string{"host2,service1"},
+	})
+	assert.Error(t.T(), err)
+
+	// When no child services
+	err = client.CreateServiceDependency(&CentreonServiceDependency{
+		Name:           "dep1",
+		Description:    "my dependency",
+		ParentServices: []string{"host1,service1"},
+	})
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestUpdateServiceDependency() {
+	toUpdate := &CentreonServiceDependencyDiff{
+		Name:   "dep1",
+		IsDiff: true,
+		ParamsToSet: map[string]string{
+			"name":            "dep2",
+			"inherits_parent": "0",
+		},
+		ParentServicesToSet:    []string{"host1,service3"},
+		ParentServicesToDelete: []string{"host1,service1"},
+		ChildServicesToSet:     []string{"host2,service2"},
+		ChildServicesToDelete:  []string{"host2,service1"},
+	}
+
+	// Normal use case
+	client, payloads := t.newCLAPIHandler(nil)
+	err := client.UpdateServiceDependency(toUpdate)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "dep2", toUpdate.Name)
+	assert.Equal(t.T(), []centreonapi.Payload{
+		{Action: "setparam", Object: "DEP", Values: "dep1;name;dep2"},
+		{Action: "setparam", Object: "DEP", Values: "dep2;inherits_parent;0"},
+		{Action: "addparent", Object: "DEP", Values: "dep2;host1,service3"},
+		{Action: "addchild", Object: "DEP", Values: "dep2;host2,service2"},
+		{Action: "delparent", Object: "DEP", Values: "dep2;host1,service1"},
+		{Action: "delchild", Object: "DEP", Values: "dep2;host2,service1"},
+	}, *payloads)
+
+	// When no diff
+	client, payloads = t.newCLAPIHandler(nil)
+	err = client.UpdateServiceDependency(&CentreonServiceDependencyDiff{Name: "dep1"})
+	assert.NoError(t.T(), err)
+	assert.Empty(t.T(), *payloads)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"addparent": "error"})
+	err = client.UpdateServiceDependency(&CentreonServiceDependencyDiff{
+		Name:                "dep1",
+		IsDiff:              true,
+		ParentServicesToSet: []string{"host1,service3"},
+	})
+	assert.Error(t.T(), err)
+
+	// When use bad parameter
+	err = client.UpdateServiceDependency(nil)
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestDeleteServiceDependency() {
+	// Normal use case
+	client, payloads := t.newCLAPIHandler(nil)
+	err := client.DeleteServiceDependency("dep1")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []centreonapi.Payload{{Action: "del", Object: "DEP", Values: "dep1"}}, *payloads)
+
+	// When not found
+	client, _ = t.newCLAPIHandler(map[string]string{"del": "Object not found"})
+	err = client.DeleteServiceDependency("dep1")
+	assert.NoError(t.T(), err)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"del": "error"})
+	err = client.DeleteServiceDependency("dep1")
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestGetServiceDependency() {
+	// Normal use case
+	client, _ := t.newCLAPIHandler(map[string]string{
+		"show":    `{"result": [{"id": "1", "name": "dep10", "description": "other"}, {"id": "2", "name": "dep1", "description": "my dependency", "inherits_parent": "1", "execution_failure_criteria": "w,c", "notification_failure_criteria": "n"}]}`,
+		"listdep": `{"result": [{"parents": "host1,service1|host1,service2", "children": "host2,service1"}]}`,
+	})
+	dependency, err := client.GetServiceDependency("dep1")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), &CentreonServiceDependency{
+		Name:                        "dep1",
+		Description:                 "my dependency",
+		InheritsParent:              "1",
+		ExecutionFailureCriteria:    "c,w",
+		NotificationFailureCriteria: "n",
+		ParentServices:              []string{"host1,service1", "host1,service2"},
+		ChildServices:               []string{"host2,service1"},
+	}, dependency)
+
+	// When not found
+	client, _ = t.newCLAPIHandler(map[string]string{
+		"show": `{"result": [{"id": "1", "name": "dep10", "description": "other"}]}`,
+	})
+	dependency, err = client.GetServiceDependency("dep1")
+	assert.NoError(t.T(), err)
+	assert.Nil(t.T(), dependency)
+
+	// When error
+	client, _ = t.newCLAPIHandler(map[string]string{"show": "error"})
+	_, err = client.GetServiceDependency("dep1")
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestDiffServiceDependency() {
+	actual := &CentreonServiceDependency{
+		Name:                     "dep1",
+		Description:              "my dependency",
+		InheritsParent:           "1",
+		ExecutionFailureCriteria: "c,w",
+		ParentServices:           []string{"host1,service1", "host1,service2"},
+		ChildServices:            []string{"host2,service1"},
+	}
+
+	// When no diff
+	expected := &CentreonServiceDependency{
+		Name:                     "dep1",
+		Description:              "my dependency",
+		InheritsParent:           "1",
+		ExecutionFailureCriteria: "c,w",
+		ParentServices:           []string{"host1,service2", "host1,service1"},
+		ChildServices:            []string{"host2,service1"},
+	}
+	diff, err := t.client.DiffServiceDependency(actual, expected, nil)
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), diff.IsDiff)
+
+	// When diff
+	expected = &CentreonServiceDependency{
+		Name:                     "dep2",
+		Description:              "my dependency",
+		InheritsParent:           "0",
+		ExecutionFailureCriteria: "c,w",
+		ParentServices:           []string{"host1,service1", "host1,service3"},
+		ChildServices:            []string{"host2,service1"},
+	}
+	diff, err = t.client.DiffServiceDependency(actual, expected, nil)
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), diff.IsDiff)
+	assert.Equal(t.T(), "dep1", diff.Name)
+	assert.Equal(t.T(), map[string]string{"name": "dep2", "inherits_parent": "0"}, diff.ParamsToSet)
+	assert.Equal(t.T(), []string{"host1,service3"}, diff.ParentServicesToSet)
+	assert.Equal(t.T(), []string{"host1,service2"}, diff.ParentServicesToDelete)
+	assert.Empty(t.T(), diff.ChildServicesToSet)
+	assert.Empty(t.T(), diff.ChildServicesToDelete)
+
+	// When ignore fields
+	diff, err = t.client.DiffServiceDependency(actual, expected, []string{"name", "inheritsParent", "services"})
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), diff.IsDiff)
+}
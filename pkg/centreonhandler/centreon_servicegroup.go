@@ -18,7 +18,7 @@ func (h *CentreonHandlerImpl) CreateServiceGroup(sg *CentreonServiceGroup) (err
 	}
 
 	// Create main object
-	if err = h.client.API.ServiceGroup().Add(sg.Name, sg.Description); err != nil {
+	if err = h.getClient().API.ServiceGroup().Add(sg.Name, sg.Description); err != nil {
 		return err
 	}
 	h.log.Debug("Create serviceGroup core from Centreon")
@@ -30,7 +30,7 @@ func (h *CentreonHandlerImpl) CreateServiceGroup(sg *CentreonServiceGroup) (err
 	}
 	for param, value := range params {
 		if value != "" {
-			if err = h.client.API.ServiceGroup().SetParam(sg.Name, param, value); err != nil {
+			if err = h.getClient().API.ServiceGroup().SetParam(sg.Name, param, value); err != nil {
 				return err
 			}
 			h.log.Debugf("Set param %s on service from Centreon", param)
@@ -60,7 +60,7 @@ func (h *CentreonHandlerImpl) UpdateServiceGroup(serviceGroupDiff *CentreonServi
 	// Update properties
 	if len(serviceGroupDiff.ParamsToSet) > 0 {
 		for param, value := range serviceGroupDiff.ParamsToSet {
-			err = h.client.API.ServiceGroup().SetParam(serviceGroupDiff.Name, param, value)
+			err = h.getClient().API.ServiceGroup().SetParam(serviceGroupDiff.Name, param, value)
 			if err != nil {
 				return err
 			}
@@ -77,7 +77,7 @@ func (h *CentreonHandlerImpl) UpdateServiceGroup(serviceGroupDiff *CentreonServi
 
 // DeleteServiceGroup permit to delete an existing serviceGroup on Centreon
 func (h *CentreonHandlerImpl) DeleteServiceGroup(name string) (err error) {
-	err = h.client.API.ServiceGroup().Delete(name)
+	err = h.getClient().API.ServiceGroup().Delete(name)
 	if err != nil && IsErrorNotFound(err) {
 		return nil
 	}
@@ -125,7 +125,7 @@ func (h *CentreonHandlerImpl) GetServiceGroup(name string) (sg *CentreonServiceG
 	}
 
 	// Get serviceGroup from Centreon
-	baseSG, err := h.client.API.ServiceGroup().Get(name)
+	baseSG, err := h.getClient().API.ServiceGroup().Get(name)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +134,7 @@ func (h *CentreonHandlerImpl) GetServiceGroup(name string) (sg *CentreonServiceG
 	}
 
 	// Get extras params
-	extras, err := h.client.API.ServiceGroup().GetParam(name, []string{"activate", "comment"})
+	extras, err := h.getClient().API.ServiceGroup().GetParam(name, []string{"activate", "comment"})
 	if err != nil {
 		return nil, err
 	}
@@ -150,3 +150,22 @@ func (h *CentreonHandlerImpl) GetServiceGroup(name string) (sg *CentreonServiceG
 
 	return sg, nil
 }
+
+// ListServiceGroups permit to list all service groups on Centreon
+// It only return the name of service groups, use GetServiceGroup to read them
+func (h *CentreonHandlerImpl) ListServiceGroups() (sgs []*CentreonServiceGroup, err error) {
+	baseSGs, err := h.getClient().API.ServiceGroup().List()
+	if err != nil {
+		return nil, err
+	}
+
+	sgs = make([]*CentreonServiceGroup, 0, len(baseSGs))
+	for _, baseSG := range baseSGs {
+		sgs = append(sgs, &CentreonServiceGroup{
+			Name:        baseSG.Name,
+			Description: baseSG.Description,
+		})
+	}
+
+	return sgs, nil
+}
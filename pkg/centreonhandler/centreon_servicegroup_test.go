@@ -5,6 +5,7 @@ import (
 
 	"github.com/disaster37/go-centreon-rest/v21/models"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -159,6 +160,29 @@ func (t *CentreonHandlerTestSuite) TestGetServiceGroup() {
 	assert.Error(t.T(), err)
 }
 
+func (t *CentreonHandlerTestSuite) TestListServiceGroups() {
+	t.mockServiceGroup.EXPECT().
+		List().
+		Return([]*models.ServiceGroup{
+			{
+				ID:          "1",
+				Name:        "sg1",
+				Description: "my sg",
+			},
+		}, nil)
+
+	sgs, err := t.client.ListServiceGroups()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []*CentreonServiceGroup{{Name: "sg1", Description: "my sg"}}, sgs)
+
+	// When error
+	t.mockServiceGroup.EXPECT().
+		List().
+		Return(nil, errors.New("boom"))
+	_, err = t.client.ListServiceGroups()
+	assert.Error(t.T(), err)
+}
+
 func (t *CentreonHandlerTestSuite) TestDiffServiceGroup() {
 	tests := []struct {
 		Name            string
@@ -0,0 +1,87 @@
+package centreonhandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	centreonapi "github.com/disaster37/go-centreon-rest/v21/api"
+	"github.com/pkg/errors"
+)
+
+// nameResult is the object linked to another one returned by CLAPI, like the contacts of service
+type nameResult struct {
+	Name string `json:"name"`
+}
+
+// clapi permit to call the CLAPI actions that are not provided by the Centreon client
+// When result is provided, the result of action is decoded on it
+func (h *CentreonHandlerImpl) clapi(action, object, values string, result any) (err error) {
+	payload := centreonapi.NewPayload(action, object, "%s", values)
+	h.log.Debugf("Payload: %+v", payload)
+
+	resp, err := h.getClient().API.Client().R().
+		SetBody(payload).
+		Post("")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() >= 300 {
+		return errors.Errorf("Error when %s %s %s: %s", action, object, values, resp.Body())
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	r := new(centreonapi.Result)
+	if err = json.Unmarshal(resp.Body(), r); err != nil {
+		return errors.Wrapf(err, "Error when decode result of %s %s", action, object)
+	}
+	if len(r.Result) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(r.Result, result); err != nil {
+		return errors.Wrapf(err, "Error when decode result of %s %s", action, object)
+	}
+
+	return nil
+}
+
+// names permit to get the names of objects returned by CLAPI
+func names(results []nameResult) []string {
+	items := make([]string, 0, len(results))
+	for _, result := range results {
+		items = append(items, result.Name)
+	}
+
+	return items
+}
+
+// ServiceKey return the service as expected by CLAPI when it reference a service
+// The format is `host,service`
+func ServiceKey(host, name string) string {
+	return fmt.Sprintf("%s,%s", host, name)
+}
+
+// splitList permit to read the list returned by CLAPI, like `host1,service1|host2,service2`
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// sortOptions permit to read the options returned by CLAPI, like `w,c`
+// They are sorted to not detect diff when only the order change
+func sortOptions(value string) string {
+	options := splitList(strings.ReplaceAll(value, ",", "|"))
+	slices.Sort(options)
+
+	return strings.Join(options, ",")
+}
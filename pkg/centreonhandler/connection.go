@@ -0,0 +1,48 @@
+package centreonhandler
+
+import (
+	"net/http"
+
+	"github.com/disaster37/go-centreon-rest/v21"
+)
+
+// SetOnConnectionError permit to be notified when the Centreon client can't reach the API
+// It's called for network errors, like connection refused, TLS or timeout errors, but not when API answer with error status
+// It need to be called after all transport settings (TLS, proxy) are set on client, and before SetRateLimit
+func SetOnConnectionError(client *centreon.Client, onError func(err error)) {
+	if onError == nil {
+		return
+	}
+	restyClient := client.API.Client()
+
+	restyClient.SetTransport(newConnectionErrorTransport(restyClient.GetClient().Transport, onError))
+}
+
+// connectionErrorTransport is the HTTP transport that notify the connection errors
+type connectionErrorTransport struct {
+	next    http.RoundTripper
+	onError func(err error)
+}
+
+func newConnectionErrorTransport(next http.RoundTripper, onError func(err error)) *connectionErrorTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &connectionErrorTransport{
+		next:    next,
+		onError: onError,
+	}
+}
+
+// RoundTrip implement http.RoundTripper
+func (h *connectionErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := h.next.RoundTrip(req)
+
+	// The requests canceled by caller are not connection errors
+	if err != nil && req.Context().Err() == nil {
+		h.onError(err)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,41 @@
+package centreonhandler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionErrorTransport(t *testing.T) {
+	var errs []error
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	client := &http.Client{Transport: newConnectionErrorTransport(nil, func(err error) {
+		errs = append(errs, err)
+	})}
+
+	// When API answer with error status
+	resp, err := client.Get(ts.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, errs)
+
+	// When request is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	assert.NoError(t, err)
+	_, err = client.Do(req)
+	assert.Error(t, err)
+	assert.Empty(t, errs)
+
+	// When API is not reachable
+	ts.Close()
+	_, err = client.Get(ts.URL)
+	assert.Error(t, err)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,78 @@
+package centreonhandler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	usernameKey string = "username"
+	passwordKey string = "password"
+	tokenKey    string = "token"
+)
+
+// Credentials is the credentials used to authenticate on Centreon API
+type Credentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// CredentialsProvider permit to get the current credentials
+// It's called when the handler need to authenticate on Centreon API
+type CredentialsProvider func() (credentials *Credentials, err error)
+
+// NewCredentials permit to get credentials from key / value, like secret data
+// It need to have (`username` and `password`) or `token` key
+// The values are used as is, they are not trimmed
+func NewCredentials(data map[string][]byte) (credentials *Credentials, err error) {
+	credentials = &Credentials{}
+
+	if len(data[tokenKey]) > 0 {
+		credentials.Token = string(data[tokenKey])
+		return credentials, nil
+	}
+
+	credentials.Username = string(data[usernameKey])
+	credentials.Password = string(data[passwordKey])
+	if credentials.Username == "" || credentials.Password == "" {
+		return nil, errors.New("You need to set (username and password) or token")
+	}
+
+	return credentials, nil
+}
+
+// ReadCredentialsFromPath permit to read credentials from the files (`username` and `password`) or `token` on directory
+// The trailing newline of files is removed, because editors add it
+func ReadCredentialsFromPath(path string) (credentials *Credentials, err error) {
+	data := map[string][]byte{}
+	for _, key := range []string{usernameKey, passwordKey, tokenKey} {
+		b, err := os.ReadFile(filepath.Join(path, key))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "Error when read file %s", filepath.Join(path, key))
+		}
+		data[key] = []byte(strings.TrimSuffix(string(b), "\n"))
+	}
+
+	credentials, err = NewCredentials(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error when read credentials on %s", path)
+	}
+
+	return credentials, nil
+}
+
+// Hash return the sha256 of credentials
+func (c *Credentials) Hash() string {
+	sha := sha256.New()
+	sha.Write([]byte(c.Username + "\n" + c.Password + "\n" + c.Token))
+	return hex.EncodeToString(sha.Sum(nil))
+}
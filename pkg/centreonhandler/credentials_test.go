@@ -0,0 +1,102 @@
+package centreonhandler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disaster37/go-centreon-rest/v21"
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCredentials(t *testing.T) {
+	// With username and password
+	c, err := NewCredentials(map[string][]byte{"username": []byte("user"), "password": []byte("pass")})
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "user", Password: "pass"}, c)
+
+	// The values are used as is
+	c, err = NewCredentials(map[string][]byte{"username": []byte("user"), "password": []byte(" pass\n")})
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "user", Password: " pass\n"}, c)
+
+	// With token
+	c, err = NewCredentials(map[string][]byte{"token": []byte("token"), "username": []byte("user")})
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Token: "token"}, c)
+
+	// When credentials are missing
+	_, err = NewCredentials(map[string][]byte{"username": []byte("user")})
+	assert.Error(t, err)
+}
+
+func TestReadCredentialsFromPath(t *testing.T) {
+	dir := t.TempDir()
+
+	// When no files
+	_, err := ReadCredentialsFromPath(dir)
+	assert.Error(t, err)
+
+	// With username and password
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "username"), []byte("user"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte(" pass\n"), 0600))
+	c, err := ReadCredentialsFromPath(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "user", Password: " pass"}, c)
+	hash := c.Hash()
+
+	// When credentials are rotated
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("pass2"), 0600))
+	c, err = ReadCredentialsFromPath(dir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, c.Hash())
+}
+
+// TestRefreshCredentialsConcurrently need to be run with -race
+func TestRefreshCredentialsConcurrently(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"result": [{"id": "1", "name": "user1"}]}`))
+	}))
+	defer server.Close()
+
+	var version atomic.Int64
+	cfg := &models.Config{Address: server.URL + "/centreon/api/index.php", Token: "token0"}
+	ch, err := NewCentreonHandlerWithCredentials(cfg, centreon.NewClient, func() (*Credentials, error) {
+		return &Credentials{Token: fmt.Sprintf("token%d", version.Load())}, nil
+	}, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				contacts, err := ch.GetServiceContacts("central", "ping")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"user1"}, contacts)
+			}
+		}()
+	}
+
+	// The credentials are rotated while requests are in progress
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 1; j <= 20; j++ {
+			version.Store(int64(j))
+			assert.NoError(t, ch.Auth())
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, "token20", ch.(*CentreonHandlerImpl).config.Token)
+	assert.Equal(t, "token0", cfg.Token)
+}
@@ -0,0 +1,139 @@
+package centreonhandler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// OwnerMacroName is the macro that store the identity of resource that manage the service
+	OwnerMacroName string = "MONITORING_OPERATOR_OWNER"
+
+	// ownerMacroDescription is the description of owner macro displayed on Centreon
+	ownerMacroDescription string = "Managed by monitoring-operator, do not edit"
+)
+
+// Owner is the identity of resource that manage the object on Centreon
+type Owner struct {
+	// ClusterID is the unique ID of Kubernetes cluster
+	ClusterID string
+
+	// Namespace is the namespace of resource
+	Namespace string
+
+	// Name is the name of resource
+	Name string
+
+	// UID is the UID of resource
+	UID string
+}
+
+// String return the owner as it stored on Centreon
+// The format is `clusterID/namespace/name/uid`
+func (o *Owner) String() string {
+	return fmt.Sprintf("%s/%s/%s/%s", o.ClusterID, o.Namespace, o.Name, o.UID)
+}
+
+// IsSameResource return true if the owners are the same resource
+// The UID is not compared, so the resource can be recreated with the same name
+func (o *Owner) IsSameResource(other *Owner) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+
+	return o.ClusterID == other.ClusterID && o.Namespace == other.Namespace && o.Name == other.Name
+}
+
+// ParseOwner permit to read the owner stored on Centreon
+// The cluster ID can contain `/`, so the fields are read from the end
+func ParseOwner(value string) (owner *Owner, err error) {
+	fields := strings.Split(value, "/")
+	if len(fields) < 4 {
+		return nil, errors.Errorf("Owner '%s' must have the format 'clusterID/namespace/name/uid'", value)
+	}
+	n := len(fields)
+	owner = &Owner{
+		ClusterID: strings.Join(fields[:n-3], "/"),
+		Namespace: fields[n-3],
+		Name:      fields[n-2],
+		UID:       fields[n-1],
+	}
+	if owner.ClusterID == "" || owner.Namespace == "" || owner.Name == "" {
+		return nil, errors.Errorf("Owner '%s' must have the format 'clusterID/namespace/name/uid'", value)
+	}
+
+	return owner, nil
+}
+
+// OwnerConflictError is returned when the object on Centreon is managed by another resource
+type OwnerConflictError struct {
+	Object   string
+	Owner    *Owner
+	Expected *Owner
+}
+
+func (e *OwnerConflictError) Error() string {
+	return fmt.Sprintf("%s is managed by %s, it can't be managed by %s", e.Object, e.Owner, e.Expected)
+}
+
+// IsOwnerConflict return true if the error is an owner conflict
+// It also look on aggregated errors, like when the object is handled on multiple platforms
+func IsOwnerConflict(err error) bool {
+	var conflictErr *OwnerConflictError
+	if errors.As(err, &conflictErr) {
+		return true
+	}
+
+	var aggregateErr interface{ Errors() []error }
+	if errors.As(err, &aggregateErr) {
+		for _, e := range aggregateErr.Errors() {
+			if IsOwnerConflict(e) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CheckOwner return an OwnerConflictError if the object is managed by another resource
+// The object without owner can be managed by anyone, like the one created before the owner was stored
+func CheckOwner(object string, actual, expected *Owner) error {
+	if actual == nil || expected == nil || actual.IsSameResource(expected) {
+		return nil
+	}
+
+	return &OwnerConflictError{
+		Object:   object,
+		Owner:    actual,
+		Expected: expected,
+	}
+}
+
+// ownerMacro return the macro that store the owner
+func ownerMacro(owner *Owner) *models.Macro {
+	return &models.Macro{
+		Name:        OwnerMacroName,
+		Value:       owner.String(),
+		IsPassword:  "0",
+		Description: ownerMacroDescription,
+	}
+}
+
+// extractOwner permit to read the owner from macros
+// It return the other macros. An owner that can't be read is ignored, so it will be overwritten
+func extractOwner(macros []*models.Macro) (owner *Owner, others []*models.Macro) {
+	others = make([]*models.Macro, 0, len(macros))
+	for _, macro := range macros {
+		if macro.Name != OwnerMacroName {
+			others = append(others, macro)
+			continue
+		}
+		owner, _ = ParseOwner(macro.Value)
+	}
+
+	return owner, others
+}
@@ -0,0 +1,65 @@
+package centreonhandler
+
+import (
+	"testing"
+
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func TestOwner(t *testing.T) {
+	owner := &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"}
+	assert.Equal(t, "cluster1/default/ping/uid1", owner.String())
+
+	// Parse
+	parsed, err := ParseOwner("cluster1/default/ping/uid1")
+	assert.NoError(t, err)
+	assert.Equal(t, owner, parsed)
+
+	parsed, err = ParseOwner("https://k8s.domain.com/default/ping/uid1")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://k8s.domain.com", parsed.ClusterID)
+
+	_, err = ParseOwner("default/ping/uid1")
+	assert.Error(t, err)
+
+	_, err = ParseOwner("/default/ping/uid1")
+	assert.Error(t, err)
+
+	// Same resource
+	assert.True(t, owner.IsSameResource(&Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid2"}))
+	assert.False(t, owner.IsSameResource(&Owner{ClusterID: "cluster2", Namespace: "default", Name: "ping", UID: "uid1"}))
+	assert.False(t, owner.IsSameResource(nil))
+}
+
+func TestCheckOwner(t *testing.T) {
+	owner := &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"}
+
+	assert.NoError(t, CheckOwner("Service central/ping", nil, owner))
+	assert.NoError(t, CheckOwner("Service central/ping", owner, nil))
+	assert.NoError(t, CheckOwner("Service central/ping", owner, &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid2"}))
+
+	err := CheckOwner("Service central/ping", owner, &Owner{ClusterID: "cluster1", Namespace: "other", Name: "ping", UID: "uid2"})
+	assert.Error(t, err)
+	assert.True(t, IsOwnerConflict(err))
+	assert.True(t, IsOwnerConflict(errors.Wrap(err, "Error when diff")))
+	assert.True(t, IsOwnerConflict(utilerrors.NewAggregate([]error{errors.New("boom"), err})))
+	assert.False(t, IsOwnerConflict(errors.New("boom")))
+}
+
+func TestExtractOwner(t *testing.T) {
+	macros := []*models.Macro{
+		{Name: "USER", Value: "user"},
+		{Name: OwnerMacroName, Value: "cluster1/default/ping/uid1"},
+	}
+	owner, others := extractOwner(macros)
+	assert.Equal(t, &Owner{ClusterID: "cluster1", Namespace: "default", Name: "ping", UID: "uid1"}, owner)
+	assert.Equal(t, []*models.Macro{{Name: "USER", Value: "user"}}, others)
+
+	// When owner is invalid
+	owner, others = extractOwner([]*models.Macro{{Name: OwnerMacroName, Value: "bad"}})
+	assert.Nil(t, owner)
+	assert.Empty(t, others)
+}
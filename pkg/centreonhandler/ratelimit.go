@@ -0,0 +1,215 @@
+package centreonhandler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disaster37/go-centreon-rest/v21"
+	centreonapi "github.com/disaster37/go-centreon-rest/v21/api"
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries when Centreon API is overloaded
+	DefaultMaxRetries int = 3
+
+	// ThrottleReasonQPS is the reason when request wait because of QPS limit
+	ThrottleReasonQPS string = "qps"
+
+	// ThrottleReasonMaxInFlight is the reason when request wait because of concurrent requests limit
+	ThrottleReasonMaxInFlight string = "maxInFlight"
+
+	// ThrottleReasonBackoff is the reason when request is retried because Centreon API is overloaded
+	ThrottleReasonBackoff string = "backoff"
+
+	retryWaitTime    = 1 * time.Second
+	retryMaxWaitTime = 30 * time.Second
+)
+
+// RateLimitSettings is the client side limits to access on Centreon API
+type RateLimitSettings struct {
+	// QPS is the maximum number of requests per second. 0 is unlimited
+	QPS float64
+
+	// Burst is the maximum number of requests that can exceed the QPS. Default to QPS
+	Burst int
+
+	// MaxInFlight is the maximum number of concurrent requests. 0 is unlimited
+	MaxInFlight int
+
+	// MaxRetries is the number of retries with backoff when API return 429 or 5xx
+	// The 5xx are only retried for the actions that read objects, because the other actions can be already applied
+	MaxRetries int
+
+	// OnThrottle is called each time a request is throttled, with the reason
+	OnThrottle func(reason string)
+}
+
+// RateLimiter is the client side limits to access on Centreon API
+// It can be shared between clients, like the clients of each endpoint of platform, so the limits apply on all of them
+type RateLimiter struct {
+	settings *RateLimitSettings
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+}
+
+// NewRateLimiter permit to get the limiter from settings
+// It return nil if settings is nil
+func NewRateLimiter(settings *RateLimitSettings) *RateLimiter {
+	if settings == nil {
+		return nil
+	}
+	l := &RateLimiter{
+		settings: settings,
+	}
+
+	if settings.QPS > 0 {
+		burst := settings.Burst
+		if burst <= 0 {
+			burst = max(int(settings.QPS), 1)
+		}
+		l.limiter = rate.NewLimiter(rate.Limit(settings.QPS), burst)
+	}
+	if settings.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, settings.MaxInFlight)
+	}
+
+	return l
+}
+
+// SetRateLimit permit to limit the requests made by Centreon client
+// It need to be called after all transport settings (TLS, proxy) are set on client
+func SetRateLimit(client *centreon.Client, limiter *RateLimiter) {
+	if limiter == nil {
+		return
+	}
+	settings := limiter.settings
+	restyClient := client.API.Client()
+
+	restyClient.SetTransport(newRateLimitTransport(restyClient.GetClient().Transport, limiter))
+
+	// Backoff when API is overloaded
+	// The client need at least one retry to authenticate again when the token expire
+	restyClient.
+		SetRetryCount(max(settings.MaxRetries, 1)).
+		SetRetryWaitTime(retryWaitTime).
+		SetRetryMaxWaitTime(retryMaxWaitTime).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(func(r *resty.Response, err error) bool {
+			if !isRetryable(r, settings.MaxRetries) {
+				return false
+			}
+			settings.throttled(ThrottleReasonBackoff)
+			return true
+		})
+}
+
+// isRetryable return true if the request can be retried because the API is overloaded
+// The API not handle the request when it return too many requests, else only the actions that read objects are retried
+func isRetryable(r *resty.Response, maxRetries int) bool {
+	if r == nil || r.Request == nil || !isOverloaded(r.StatusCode()) {
+		return false
+	}
+	if r.Request.Attempt > maxRetries {
+		return false
+	}
+
+	return r.StatusCode() == http.StatusTooManyRequests || isIdempotent(r.Request)
+}
+
+// isIdempotent return true if the request not modify objects on Centreon
+// CLAPI use POST for all actions, so it read the action from payload
+func isIdempotent(req *resty.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+
+	payload, ok := req.Body.(*centreonapi.Payload)
+	if !ok {
+		return false
+	}
+	action := strings.ToLower(payload.Action)
+
+	return action == "show" || strings.HasPrefix(action, "get")
+}
+
+// isOverloaded return true if the status code means the API can't handle the request now
+func isOverloaded(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter permit to use the Retry-After header when API return it
+// Else, it use the default exponential backoff
+func retryAfter(c *resty.Client, r *resty.Response) (time.Duration, error) {
+	if r == nil {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(r.Header().Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return 0, nil
+}
+
+func (h *RateLimitSettings) throttled(reason string) {
+	if h.OnThrottle != nil {
+		h.OnThrottle(reason)
+	}
+}
+
+// rateLimitTransport is the HTTP transport that limit the requests
+// The limiter can be shared with other transports
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func newRateLimitTransport(next http.RoundTripper, limiter *RateLimiter) *rateLimitTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &rateLimitTransport{
+		next:    next,
+		limiter: limiter,
+	}
+}
+
+// RoundTrip implement http.RoundTripper
+func (h *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	l := h.limiter
+
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		default:
+			l.settings.throttled(ThrottleReasonMaxInFlight)
+			select {
+			case l.inFlight <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		defer func() { <-l.inFlight }()
+	}
+
+	if l.limiter != nil {
+		if !l.limiter.Allow() {
+			l.settings.throttled(ThrottleReasonQPS)
+			if err := l.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return h.next.RoundTrip(req)
+}
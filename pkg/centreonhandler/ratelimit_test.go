@@ -0,0 +1,145 @@
+package centreonhandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disaster37/go-centreon-rest/v21"
+	centreonapi "github.com/disaster37/go-centreon-rest/v21/api"
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	var (
+		inFlight    int32
+		maxInFlight int32
+		mu          sync.Mutex
+		reasons     []string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			old := atomic.LoadInt32(&maxInFlight)
+			if current <= old || atomic.CompareAndSwapInt32(&maxInFlight, old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	settings := &RateLimitSettings{
+		MaxInFlight: 2,
+		OnThrottle: func(reason string) {
+			mu.Lock()
+			defer mu.Unlock()
+			reasons = append(reasons, reason)
+		},
+	}
+	// The limiter is shared between clients
+	limiter := NewRateLimiter(settings)
+	clients := []*http.Client{
+		{Transport: newRateLimitTransport(nil, limiter)},
+		{Transport: newRateLimitTransport(nil, limiter)},
+	}
+
+	// Concurrent requests are limited
+	wg := sync.WaitGroup{}
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(client *http.Client) {
+			defer wg.Done()
+			resp, err := client.Get(ts.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}(clients[i%2])
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	assert.Contains(t, reasons, ThrottleReasonMaxInFlight)
+
+	// QPS is limited
+	reasons = nil
+	settings = &RateLimitSettings{
+		QPS:   10,
+		Burst: 1,
+		OnThrottle: func(reason string) {
+			reasons = append(reasons, reason)
+		},
+	}
+	client := &http.Client{Transport: newRateLimitTransport(nil, NewRateLimiter(settings))}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(ts.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	assert.Equal(t, []string{ThrottleReasonQPS, ThrottleReasonQPS}, reasons)
+}
+
+func TestIsOverloaded(t *testing.T) {
+	assert.True(t, isOverloaded(http.StatusTooManyRequests))
+	assert.True(t, isOverloaded(http.StatusServiceUnavailable))
+	assert.False(t, isOverloaded(http.StatusOK))
+	assert.False(t, isOverloaded(http.StatusNotFound))
+}
+
+func TestSetRateLimitRetry(t *testing.T) {
+	var (
+		attempts atomic.Int32
+		status   atomic.Int32
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`{"result": []}`))
+	}))
+	defer ts.Close()
+
+	newClient := func(maxRetries int) *centreon.Client {
+		client, err := centreon.NewClient(&models.Config{Address: ts.URL, Token: "token"})
+		assert.NoError(t, err)
+		SetRateLimit(client, NewRateLimiter(&RateLimitSettings{MaxRetries: maxRetries}))
+		client.API.Client().SetRetryWaitTime(time.Millisecond).SetRetryMaxWaitTime(time.Millisecond)
+		return client
+	}
+	call := func(client *centreon.Client, action string) {
+		attempts.Store(0)
+		_, _ = client.API.Client().R().SetBody(centreonapi.NewPayload(action, "SERVICE", "central;ping")).Post("")
+	}
+
+	// The actions that read objects are retried when API is overloaded
+	status.Store(http.StatusBadGateway)
+	call(newClient(2), "show")
+	assert.Equal(t, int32(3), attempts.Load())
+	call(newClient(2), "getcontact")
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// The actions that modify objects are not retried on 5xx, because they can be already applied
+	call(newClient(2), "add")
+	assert.Equal(t, int32(1), attempts.Load())
+
+	// All actions are retried when API return too many requests
+	status.Store(http.StatusTooManyRequests)
+	call(newClient(2), "add")
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// The retry on unauthorized is kept when retries are disabled
+	status.Store(http.StatusUnauthorized)
+	call(newClient(0), "show")
+	assert.Equal(t, int32(2), attempts.Load())
+	status.Store(http.StatusBadGateway)
+	call(newClient(0), "show")
+	assert.Equal(t, int32(1), attempts.Load())
+}
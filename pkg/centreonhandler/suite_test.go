@@ -1,11 +1,18 @@
 package centreonhandler
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/disaster37/go-centreon-rest/v21"
+	centreonapi "github.com/disaster37/go-centreon-rest/v21/api"
 	"github.com/disaster37/go-centreon-rest/v21/mocks"
+	"github.com/disaster37/go-centreon-rest/v21/models"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -50,6 +57,47 @@ func (t *CentreonHandlerTestSuite) AfterTest(suiteName, testName string) {
 	defer t.mockCtrl.Finish()
 }
 
+// newCLAPIHandler permit to get handler that call a fake CLAPI
+// The response is returned by action. A response that is not a JSON object is returned as error.
+// The payloads received by CLAPI are recorded
+func (t *CentreonHandlerTestSuite) newCLAPIHandler(responses map[string]string) (handler CentreonHandler, payloads *[]centreonapi.Payload) {
+	client, payloads := t.newCLAPIClient(responses)
+
+	return NewCentreonHandler(client, logrus.NewEntry(logrus.New())), payloads
+}
+
+// newCLAPIClient permit to get Centreon client that call a fake CLAPI
+// It can be returned by the mocked API when the handler call CLAPI directly
+func (t *CentreonHandlerTestSuite) newCLAPIClient(responses map[string]string) (client *centreon.Client, payloads *[]centreonapi.Payload) {
+	payloads = &[]centreonapi.Payload{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := centreonapi.Payload{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*payloads = append(*payloads, payload)
+
+		response, ok := responses[payload.Action]
+		if !ok {
+			response = `{"result": []}`
+		}
+		if !strings.HasPrefix(response, "{") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.T().Cleanup(server.Close)
+
+	client, err := centreon.NewClient(&models.Config{Address: server.URL + "/centreon/api/index.php"})
+	if err != nil {
+		panic(err)
+	}
+	client.API.Client().SetRetryCount(0)
+
+	return client, payloads
+}
+
 func (t *CentreonHandlerTestSuite) TestSetLogger() {
 	log := logrus.NewEntry(logrus.New())
 	t.client.SetLogger(log)
@@ -63,3 +111,72 @@ func (t *CentreonHandlerTestSuite) TestNewCentreonHandler() {
 	assert.Equal(t.T(), client, ch.(*CentreonHandlerImpl).client)
 	assert.Equal(t.T(), log, ch.(*CentreonHandlerImpl).log)
 }
+
+func (t *CentreonHandlerTestSuite) TestGetVersion() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/centreon/api/latest/platform/versions":
+			_, _ = w.Write([]byte(`{"web": {"version": "21.10.4", "major": "21", "minor": "10", "fix": "4"}, "modules": {}, "widgets": {}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Normal use case
+	client, err := centreon.NewClient(&models.Config{Address: server.URL + "/centreon/api/index.php"})
+	assert.NoError(t.T(), err)
+	ch := NewCentreonHandler(client, logrus.NewEntry(logrus.New()))
+	version, err := ch.GetVersion()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "21.10.4", version)
+
+	// When API v2 not exist
+	client, err = centreon.NewClient(&models.Config{Address: server.URL + "/api/index.php"})
+	assert.NoError(t.T(), err)
+	ch = NewCentreonHandler(client, logrus.NewEntry(logrus.New()))
+	_, err = ch.GetVersion()
+	assert.Error(t.T(), err)
+}
+
+func (t *CentreonHandlerTestSuite) TestAuthWithCredentials() {
+	password := "pass1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("action") == "authenticate" {
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if r.PostForm.Get("password") != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"authToken": "token"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &models.Config{Address: server.URL + "/centreon/api/index.php", Username: "user", Password: "pass1"}
+	credentials := &Credentials{Username: "user", Password: "pass1"}
+	ch, err := NewCentreonHandlerWithCredentials(cfg, centreon.NewClient, func() (*Credentials, error) { return credentials, nil }, logrus.NewEntry(logrus.New()))
+	assert.NoError(t.T(), err)
+	client := ch.(*CentreonHandlerImpl).getClient()
+
+	// Normal use case, the client is kept because the credentials not change
+	assert.NoError(t.T(), ch.Auth())
+	assert.Equal(t.T(), client, ch.(*CentreonHandlerImpl).getClient())
+
+	// When credentials are rotated, the client is replaced and the config is not modified
+	password = "pass2"
+	credentials = &Credentials{Username: "user", Password: "pass2"}
+	assert.NoError(t.T(), ch.Auth())
+	assert.NotEqual(t.T(), client, ch.(*CentreonHandlerImpl).getClient())
+	assert.Equal(t.T(), "pass1", cfg.Password)
+
+	// When credentials provider failed
+	ch, err = NewCentreonHandlerWithCredentials(cfg, centreon.NewClient, func() (*Credentials, error) { return nil, errors.New("not found") }, logrus.NewEntry(logrus.New()))
+	assert.NoError(t.T(), err)
+	assert.Error(t.T(), ch.Auth())
+}
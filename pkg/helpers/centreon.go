@@ -1,13 +1,22 @@
 package helpers
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	operatorNamespaceEnvVar = "POD_NAMESPACE"
+	clusterIDEnvVar         = "CLUSTER_ID"
+
+	// clusterIDNamespace is the namespace that exist on all clusters, its UID is used as cluster ID
+	clusterIDNamespace = "kube-system"
 )
 
 func GetOperatorNamespace() (ns string, err error) {
@@ -18,3 +27,37 @@ func GetOperatorNamespace() (ns string, err error) {
 
 	return ns, nil
 }
+
+// GetClusterID return the unique ID of Kubernetes cluster
+// It use the env CLUSTER_ID if set, else the UID of namespace kube-system
+func GetClusterID(ctx context.Context, c client.Client) (clusterID string, err error) {
+	if clusterID = os.Getenv(clusterIDEnvVar); clusterID != "" {
+		return clusterID, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err = c.Get(ctx, types.NamespacedName{Name: clusterIDNamespace}, ns); err != nil {
+		return "", errors.Wrapf(err, "Error when get namespace %s to compute cluster ID, you can set it with %s", clusterIDNamespace, clusterIDEnvVar)
+	}
+
+	return string(ns.UID), nil
+}
+
+// GetResyncIntervalFromEnv return the interval to reconcile periodically the resources, to detect the changes made on Centreon
+// It return 0 when the env is not set, so the periodic resync is disabled
+func GetResyncIntervalFromEnv(envVar string) (interval time.Duration, err error) {
+	value, found := os.LookupEnv(envVar)
+	if !found || value == "" {
+		return 0, nil
+	}
+
+	interval, err = time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "%s must be a valid duration", envVar)
+	}
+	if interval < 0 {
+		return 0, errors.Errorf("%s must be a positive duration", envVar)
+	}
+
+	return interval, nil
+}
@@ -1,10 +1,15 @@
 package helpers
 
 import (
+	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestGetOperatorNamespace(t *testing.T) {
@@ -17,3 +22,53 @@ func TestGetOperatorNamespace(t *testing.T) {
 	_, err = GetOperatorNamespace()
 	assert.Error(t, err)
 }
+
+func TestGetClusterID(t *testing.T) {
+	c := fake.NewClientBuilder().
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: "uid1"}}).
+		Build()
+
+	// From namespace kube-system
+	_ = os.Unsetenv(clusterIDEnvVar)
+	clusterID, err := GetClusterID(context.Background(), c)
+	assert.NoError(t, err)
+	assert.Equal(t, "uid1", clusterID)
+
+	// From env
+	_ = os.Setenv(clusterIDEnvVar, "cluster1")
+	defer os.Unsetenv(clusterIDEnvVar)
+	clusterID, err = GetClusterID(context.Background(), c)
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster1", clusterID)
+
+	// When namespace not found
+	_ = os.Unsetenv(clusterIDEnvVar)
+	_, err = GetClusterID(context.Background(), fake.NewClientBuilder().Build())
+	assert.Error(t, err)
+}
+
+func TestGetResyncIntervalFromEnv(t *testing.T) {
+	envVar := "TEST_RESYNC_INTERVAL"
+	defer os.Unsetenv(envVar)
+
+	// When not set
+	_ = os.Unsetenv(envVar)
+	interval, err := GetResyncIntervalFromEnv(envVar)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+
+	// When set
+	_ = os.Setenv(envVar, "10m")
+	interval, err = GetResyncIntervalFromEnv(envVar)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, interval)
+
+	// When invalid
+	_ = os.Setenv(envVar, "foo")
+	_, err = GetResyncIntervalFromEnv(envVar)
+	assert.Error(t, err)
+
+	_ = os.Setenv(envVar, "-10m")
+	_, err = GetResyncIntervalFromEnv(envVar)
+	assert.Error(t, err)
+}
@@ -47,3 +47,13 @@ func CheckArgumentsToString(args []string) string {
 
 	return fmt.Sprintf("!%s", strings.Join(args, "!"))
 }
+
+// CheckArgumentsFromString permit to get the list of arguments from the Centreon check arguments
+// It's the reverse of CheckArgumentsToString
+func CheckArgumentsFromString(args string) []string {
+	if args == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimPrefix(args, "!"), "!")
+}
@@ -29,6 +29,13 @@ func TestCheckArgumentsToString(t *testing.T) {
 	assert.Equal(t, "!arg1!arg2", CheckArgumentsToString([]string{"arg1", "arg2"}))
 }
 
+func TestCheckArgumentsFromString(t *testing.T) {
+	assert.Nil(t, CheckArgumentsFromString(""))
+	assert.Equal(t, []string{"arg1"}, CheckArgumentsFromString("!arg1"))
+	assert.Equal(t, []string{"arg1", "arg2"}, CheckArgumentsFromString("!arg1!arg2"))
+	assert.Equal(t, []string{"arg1", "arg2"}, CheckArgumentsFromString(CheckArgumentsToString([]string{"arg1", "arg2"})))
+}
+
 func TestStringToSlice(t *testing.T) {
 	assert.Equal(t, []string{"test"}, StringToSlice("test", ","))
 	assert.Equal(t, []string{}, StringToSlice("", ","))
@@ -3,6 +3,8 @@ package helpers
 import (
 	"fmt"
 	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 func PlaceholdersInString(str string, values map[string]string) (result string) {
@@ -16,3 +18,18 @@ func PlaceholdersInString(str string, values map[string]string) (result string)
 
 	return str
 }
+
+// ResourceListToPlaceholders permit to convert resource list on placeholders
+// Each resource expose the quantity as string and the numeric values to use them on macros
+func ResourceListToPlaceholders(resources corev1.ResourceList) map[string]any {
+	placeholders := make(map[string]any, len(resources))
+	for name, quantity := range resources {
+		placeholders[string(name)] = map[string]any{
+			"value": quantity.String(),
+			"int":   quantity.Value(),
+			"milli": quantity.MilliValue(),
+		}
+	}
+
+	return placeholders
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestPlaceholdersInString(t *testing.T) {
@@ -39,3 +41,27 @@ func TestPlaceholdersInString(t *testing.T) {
 	str = PlaceholdersInString(str, values)
 	assert.Equal(t, "plop test-namespace/test-name on test-namespace", str)
 }
+
+func TestResourceListToPlaceholders(t *testing.T) {
+	// When empty
+	assert.Equal(t, map[string]any{}, ResourceListToPlaceholders(nil))
+
+	// When resources
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1500m"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+	expected := map[string]any{
+		"cpu": map[string]any{
+			"value": "1500m",
+			"int":   int64(2),
+			"milli": int64(1500),
+		},
+		"memory": map[string]any{
+			"value": "1Gi",
+			"int":   int64(1073741824),
+			"milli": int64(1073741824000),
+		},
+	}
+	assert.Equal(t, expected, ResourceListToPlaceholders(resources))
+}
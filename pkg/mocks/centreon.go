@@ -49,6 +49,20 @@ func (mr *MockCentreonHandlerMockRecorder) Auth() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*MockCentreonHandler)(nil).Auth))
 }
 
+// CreateEscalation mocks base method.
+func (m *MockCentreonHandler) CreateEscalation(arg0 *centreonhandler.CentreonEscalation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscalation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEscalation indicates an expected call of CreateEscalation.
+func (mr *MockCentreonHandlerMockRecorder) CreateEscalation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscalation", reflect.TypeOf((*MockCentreonHandler)(nil).CreateEscalation), arg0)
+}
+
 // CreateService mocks base method.
 func (m *MockCentreonHandler) CreateService(arg0 *centreonhandler.CentreonService) error {
 	m.ctrl.T.Helper()
@@ -63,6 +77,20 @@ func (mr *MockCentreonHandlerMockRecorder) CreateService(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockCentreonHandler)(nil).CreateService), arg0)
 }
 
+// CreateServiceDependency mocks base method.
+func (m *MockCentreonHandler) CreateServiceDependency(arg0 *centreonhandler.CentreonServiceDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceDependency", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateServiceDependency indicates an expected call of CreateServiceDependency.
+func (mr *MockCentreonHandlerMockRecorder) CreateServiceDependency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceDependency", reflect.TypeOf((*MockCentreonHandler)(nil).CreateServiceDependency), arg0)
+}
+
 // CreateServiceGroup mocks base method.
 func (m *MockCentreonHandler) CreateServiceGroup(arg0 *centreonhandler.CentreonServiceGroup) error {
 	m.ctrl.T.Helper()
@@ -77,6 +105,20 @@ func (mr *MockCentreonHandlerMockRecorder) CreateServiceGroup(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceGroup", reflect.TypeOf((*MockCentreonHandler)(nil).CreateServiceGroup), arg0)
 }
 
+// DeleteEscalation mocks base method.
+func (m *MockCentreonHandler) DeleteEscalation(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEscalation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEscalation indicates an expected call of DeleteEscalation.
+func (mr *MockCentreonHandlerMockRecorder) DeleteEscalation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEscalation", reflect.TypeOf((*MockCentreonHandler)(nil).DeleteEscalation), arg0)
+}
+
 // DeleteService mocks base method.
 func (m *MockCentreonHandler) DeleteService(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -91,6 +133,20 @@ func (mr *MockCentreonHandlerMockRecorder) DeleteService(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockCentreonHandler)(nil).DeleteService), arg0, arg1)
 }
 
+// DeleteServiceDependency mocks base method.
+func (m *MockCentreonHandler) DeleteServiceDependency(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceDependency", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServiceDependency indicates an expected call of DeleteServiceDependency.
+func (mr *MockCentreonHandlerMockRecorder) DeleteServiceDependency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceDependency", reflect.TypeOf((*MockCentreonHandler)(nil).DeleteServiceDependency), arg0)
+}
+
 // DeleteServiceGroup mocks base method.
 func (m *MockCentreonHandler) DeleteServiceGroup(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -105,6 +161,21 @@ func (mr *MockCentreonHandlerMockRecorder) DeleteServiceGroup(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceGroup", reflect.TypeOf((*MockCentreonHandler)(nil).DeleteServiceGroup), arg0)
 }
 
+// DiffEscalation mocks base method.
+func (m *MockCentreonHandler) DiffEscalation(arg0, arg1 *centreonhandler.CentreonEscalation, arg2 []string) (*centreonhandler.CentreonEscalationDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffEscalation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*centreonhandler.CentreonEscalationDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffEscalation indicates an expected call of DiffEscalation.
+func (mr *MockCentreonHandlerMockRecorder) DiffEscalation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffEscalation", reflect.TypeOf((*MockCentreonHandler)(nil).DiffEscalation), arg0, arg1, arg2)
+}
+
 // DiffService mocks base method.
 func (m *MockCentreonHandler) DiffService(arg0, arg1 *centreonhandler.CentreonService, arg2 []string) (*centreonhandler.CentreonServiceDiff, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +191,21 @@ func (mr *MockCentreonHandlerMockRecorder) DiffService(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffService", reflect.TypeOf((*MockCentreonHandler)(nil).DiffService), arg0, arg1, arg2)
 }
 
+// DiffServiceDependency mocks base method.
+func (m *MockCentreonHandler) DiffServiceDependency(arg0, arg1 *centreonhandler.CentreonServiceDependency, arg2 []string) (*centreonhandler.CentreonServiceDependencyDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffServiceDependency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*centreonhandler.CentreonServiceDependencyDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffServiceDependency indicates an expected call of DiffServiceDependency.
+func (mr *MockCentreonHandlerMockRecorder) DiffServiceDependency(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffServiceDependency", reflect.TypeOf((*MockCentreonHandler)(nil).DiffServiceDependency), arg0, arg1, arg2)
+}
+
 // DiffServiceGroup mocks base method.
 func (m *MockCentreonHandler) DiffServiceGroup(arg0, arg1 *centreonhandler.CentreonServiceGroup, arg2 []string) (*centreonhandler.CentreonServiceGroupDiff, error) {
 	m.ctrl.T.Helper()
@@ -135,6 +221,21 @@ func (mr *MockCentreonHandlerMockRecorder) DiffServiceGroup(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffServiceGroup", reflect.TypeOf((*MockCentreonHandler)(nil).DiffServiceGroup), arg0, arg1, arg2)
 }
 
+// GetEscalation mocks base method.
+func (m *MockCentreonHandler) GetEscalation(arg0 string) (*centreonhandler.CentreonEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscalation", arg0)
+	ret0, _ := ret[0].(*centreonhandler.CentreonEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscalation indicates an expected call of GetEscalation.
+func (mr *MockCentreonHandlerMockRecorder) GetEscalation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscalation", reflect.TypeOf((*MockCentreonHandler)(nil).GetEscalation), arg0)
+}
+
 // GetService mocks base method.
 func (m *MockCentreonHandler) GetService(arg0, arg1 string) (*centreonhandler.CentreonService, error) {
 	m.ctrl.T.Helper()
@@ -150,6 +251,66 @@ func (mr *MockCentreonHandlerMockRecorder) GetService(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockCentreonHandler)(nil).GetService), arg0, arg1)
 }
 
+// GetServiceComment mocks base method.
+func (m *MockCentreonHandler) GetServiceComment(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceComment", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceComment indicates an expected call of GetServiceComment.
+func (mr *MockCentreonHandlerMockRecorder) GetServiceComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceComment", reflect.TypeOf((*MockCentreonHandler)(nil).GetServiceComment), arg0, arg1)
+}
+
+// GetServiceContactGroups mocks base method.
+func (m *MockCentreonHandler) GetServiceContactGroups(arg0, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceContactGroups", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceContactGroups indicates an expected call of GetServiceContactGroups.
+func (mr *MockCentreonHandlerMockRecorder) GetServiceContactGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceContactGroups", reflect.TypeOf((*MockCentreonHandler)(nil).GetServiceContactGroups), arg0, arg1)
+}
+
+// GetServiceContacts mocks base method.
+func (m *MockCentreonHandler) GetServiceContacts(arg0, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceContacts", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceContacts indicates an expected call of GetServiceContacts.
+func (mr *MockCentreonHandlerMockRecorder) GetServiceContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceContacts", reflect.TypeOf((*MockCentreonHandler)(nil).GetServiceContacts), arg0, arg1)
+}
+
+// GetServiceDependency mocks base method.
+func (m *MockCentreonHandler) GetServiceDependency(arg0 string) (*centreonhandler.CentreonServiceDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceDependency", arg0)
+	ret0, _ := ret[0].(*centreonhandler.CentreonServiceDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceDependency indicates an expected call of GetServiceDependency.
+func (mr *MockCentreonHandlerMockRecorder) GetServiceDependency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceDependency", reflect.TypeOf((*MockCentreonHandler)(nil).GetServiceDependency), arg0)
+}
+
 // GetServiceGroup mocks base method.
 func (m *MockCentreonHandler) GetServiceGroup(arg0 string) (*centreonhandler.CentreonServiceGroup, error) {
 	m.ctrl.T.Helper()
@@ -165,6 +326,66 @@ func (mr *MockCentreonHandlerMockRecorder) GetServiceGroup(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceGroup", reflect.TypeOf((*MockCentreonHandler)(nil).GetServiceGroup), arg0)
 }
 
+// GetServiceOwner mocks base method.
+func (m *MockCentreonHandler) GetServiceOwner(arg0, arg1 string) (*centreonhandler.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceOwner", arg0, arg1)
+	ret0, _ := ret[0].(*centreonhandler.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceOwner indicates an expected call of GetServiceOwner.
+func (mr *MockCentreonHandlerMockRecorder) GetServiceOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceOwner", reflect.TypeOf((*MockCentreonHandler)(nil).GetServiceOwner), arg0, arg1)
+}
+
+// GetVersion mocks base method.
+func (m *MockCentreonHandler) GetVersion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockCentreonHandlerMockRecorder) GetVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockCentreonHandler)(nil).GetVersion))
+}
+
+// ListServiceGroups mocks base method.
+func (m *MockCentreonHandler) ListServiceGroups() ([]*centreonhandler.CentreonServiceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceGroups")
+	ret0, _ := ret[0].([]*centreonhandler.CentreonServiceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceGroups indicates an expected call of ListServiceGroups.
+func (mr *MockCentreonHandlerMockRecorder) ListServiceGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceGroups", reflect.TypeOf((*MockCentreonHandler)(nil).ListServiceGroups))
+}
+
+// ListServices mocks base method.
+func (m *MockCentreonHandler) ListServices() ([]*centreonhandler.CentreonService, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServices")
+	ret0, _ := ret[0].([]*centreonhandler.CentreonService)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServices indicates an expected call of ListServices.
+func (mr *MockCentreonHandlerMockRecorder) ListServices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockCentreonHandler)(nil).ListServices))
+}
+
 // SetLogger mocks base method.
 func (m *MockCentreonHandler) SetLogger(arg0 *logrus.Entry) {
 	m.ctrl.T.Helper()
@@ -177,6 +398,20 @@ func (mr *MockCentreonHandlerMockRecorder) SetLogger(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogger", reflect.TypeOf((*MockCentreonHandler)(nil).SetLogger), arg0)
 }
 
+// UpdateEscalation mocks base method.
+func (m *MockCentreonHandler) UpdateEscalation(arg0 *centreonhandler.CentreonEscalationDiff) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEscalation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEscalation indicates an expected call of UpdateEscalation.
+func (mr *MockCentreonHandlerMockRecorder) UpdateEscalation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEscalation", reflect.TypeOf((*MockCentreonHandler)(nil).UpdateEscalation), arg0)
+}
+
 // UpdateService mocks base method.
 func (m *MockCentreonHandler) UpdateService(arg0 *centreonhandler.CentreonServiceDiff) error {
 	m.ctrl.T.Helper()
@@ -191,6 +426,20 @@ func (mr *MockCentreonHandlerMockRecorder) UpdateService(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockCentreonHandler)(nil).UpdateService), arg0)
 }
 
+// UpdateServiceDependency mocks base method.
+func (m *MockCentreonHandler) UpdateServiceDependency(arg0 *centreonhandler.CentreonServiceDependencyDiff) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceDependency", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateServiceDependency indicates an expected call of UpdateServiceDependency.
+func (mr *MockCentreonHandlerMockRecorder) UpdateServiceDependency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceDependency", reflect.TypeOf((*MockCentreonHandler)(nil).UpdateServiceDependency), arg0)
+}
+
 // UpdateServiceGroup mocks base method.
 func (m *MockCentreonHandler) UpdateServiceGroup(arg0 *centreonhandler.CentreonServiceGroupDiff) error {
 	m.ctrl.T.Helper()
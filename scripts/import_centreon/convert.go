@@ -0,0 +1,178 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+
+	centreoncrd "github.com/disaster37/monitoring-operator/api/v1"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/disaster37/monitoring-operator/pkg/helpers"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// invalidNameChars is the characters not allowed on resource name
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+const (
+	// maxNameLength is the max length of resource name
+	maxNameLength int = 253
+
+	// nameHashLength is the length of hash added on resource name when it's already used
+	nameHashLength int = 8
+)
+
+// importOptions is the settings of imported resources
+type importOptions struct {
+	Namespace   string
+	PlatformRef string
+	Adopt       bool
+}
+
+// toCentreonService permit to convert the Centreon service on CentreonService resource
+// It's the reverse of the mapping done by the controller
+// The password macros values are not returned by Centreon, so they are read from secret named like the resource
+func toCentreonService(service *centreonhandler.CentreonService, name string, opts importOptions) *centreoncrd.CentreonService {
+	cs := &centreoncrd.CentreonService{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: centreoncrd.GroupVersion.String(),
+			Kind:       "CentreonService",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: opts.Namespace,
+		},
+		Spec: centreoncrd.CentreonServiceSpec{
+			PlatformRef:          opts.PlatformRef,
+			Host:                 service.Host,
+			Name:                 service.Name,
+			Template:             service.Template,
+			Groups:               service.Groups,
+			Categories:           service.Categories,
+			Arguments:            helpers.CheckArgumentsFromString(service.CheckCommandArgs),
+			CheckCommand:         service.CheckCommand,
+			NormalCheckInterval:  service.NormalCheckInterval,
+			RetryCheckInterval:   service.RetryCheckInterval,
+			MaxCheckAttempts:     service.MaxCheckAttempts,
+			ActiveCheckEnabled:   helpers.StringToBool(service.ActiveCheckEnabled),
+			PassiveCheckEnabled:  helpers.StringToBool(service.PassiveCheckEnabled),
+			Activated:            service.Activated == "1",
+			Contacts:             service.Contacts,
+			ContactGroups:        service.ContactGroups,
+			NotificationOptions:  helpers.StringToSlice(service.NotificationOptions, ","),
+			NotificationInterval: service.NotificationInterval,
+			NotificationPeriod:   service.NotificationPeriod,
+			NotificationsEnabled: helpers.StringToBool(service.NotificationsEnabled),
+		},
+	}
+	cs.Spec.Policy.Adopt = opts.Adopt
+
+	// Only the macros set directly on service are managed
+	for _, macro := range service.Macros {
+		if macro.Source != "" && macro.Source != "direct" {
+			continue
+		}
+		if macro.IsPassword == "1" {
+			cs.Spec.MacrosFrom = append(cs.Spec.MacrosFrom, centreoncrd.MacroFrom{
+				Name: macro.Name,
+				ValueFrom: centreoncrd.MacroValueSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: name + "-macros"},
+						Key:                  macro.Name,
+					},
+				},
+			})
+			continue
+		}
+		if cs.Spec.Macros == nil {
+			cs.Spec.Macros = map[string]string{}
+		}
+		cs.Spec.Macros[macro.Name] = macro.Value
+	}
+	sort.Slice(cs.Spec.MacrosFrom, func(i, j int) bool {
+		return cs.Spec.MacrosFrom[i].Name < cs.Spec.MacrosFrom[j].Name
+	})
+
+	return cs
+}
+
+// toCentreonServiceGroup permit to convert the Centreon service group on CentreonServiceGroup resource
+func toCentreonServiceGroup(sg *centreonhandler.CentreonServiceGroup, name string, opts importOptions) *centreoncrd.CentreonServiceGroup {
+	csg := &centreoncrd.CentreonServiceGroup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: centreoncrd.GroupVersion.String(),
+			Kind:       "CentreonServiceGroup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: opts.Namespace,
+		},
+		Spec: centreoncrd.CentreonServiceGroupSpec{
+			PlatformRef: opts.PlatformRef,
+			Name:        sg.Name,
+			Description: sg.Description,
+			Activated:   sg.Activated == "1",
+		},
+	}
+	csg.Spec.Policy.Adopt = opts.Adopt
+
+	return csg
+}
+
+// toResourceName permit to get a valid resource name from Centreon names
+func toResourceName(names ...string) string {
+	name := strings.ToLower(strings.Join(names, "-"))
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-")
+	if len(name) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength], "-")
+	}
+
+	return name
+}
+
+// resourceNames permit to get an unique resource name for each Centreon resource of the same kind
+// Different Centreon names can give the same resource name, like `App_1` and `app-1`
+type resourceNames struct {
+	used map[string]string
+}
+
+func newResourceNames() *resourceNames {
+	return &resourceNames{
+		used: map[string]string{},
+	}
+}
+
+// get return the resource name of the Centreon resource identified by names
+// A short hash of Centreon names is added when the resource name is empty or already used by another Centreon resource
+func (h *resourceNames) get(names ...string) (name string, err error) {
+	id := strings.Join(names, "/")
+	name = toResourceName(names...)
+
+	if other, ok := h.used[name]; name == "" || (ok && other != id) {
+		name = addNameHash(name, id)
+	}
+	if other, ok := h.used[name]; ok && other != id {
+		return "", errors.Errorf("Resource name %s of %s is already used by %s", name, id, other)
+	}
+	h.used[name] = id
+
+	return name, nil
+}
+
+// addNameHash permit to add the short hash of id on resource name
+func addNameHash(name, id string) string {
+	sum := sha256.Sum256([]byte(id))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+	if name == "" {
+		return hash
+	}
+	if len(name) > maxNameLength-nameHashLength-1 {
+		name = strings.TrimRight(name[:maxNameLength-nameHashLength-1], "-")
+	}
+
+	return name + "-" + hash
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestToCentreonService(t *testing.T) {
+	service := &centreonhandler.CentreonService{
+		Host:                 "central",
+		Name:                 "Ping_App",
+		Template:             "template1",
+		CheckCommand:         "check",
+		CheckCommandArgs:     "!arg1!arg2",
+		NormalCheckInterval:  "1",
+		RetryCheckInterval:   "2",
+		MaxCheckAttempts:     "3",
+		ActiveCheckEnabled:   "1",
+		PassiveCheckEnabled:  "2",
+		Activated:            "1",
+		Groups:               []string{"sg1"},
+		Categories:           []string{"cat1"},
+		Contacts:             []string{"user1"},
+		ContactGroups:        []string{"team1"},
+		NotificationOptions:  "c,w",
+		NotificationInterval: "30",
+		NotificationPeriod:   "24x7",
+		NotificationsEnabled: "2",
+		Macros: []*models.Macro{
+			{
+				Name:       "MAC1",
+				Value:      "value1",
+				IsPassword: "0",
+				Source:     "direct",
+			},
+			{
+				Name:       "TOKEN",
+				IsPassword: "1",
+				Source:     "direct",
+			},
+			{
+				Name:       "FROM_TEMPLATE",
+				Value:      "value2",
+				IsPassword: "0",
+				Source:     "template1",
+			},
+		},
+	}
+
+	cs := toCentreonService(service, toResourceName(service.Host, service.Name), importOptions{Namespace: "monitoring", PlatformRef: "default", Adopt: true})
+	assert.Equal(t, "CentreonService", cs.Kind)
+	assert.Equal(t, "central-ping-app", cs.Name)
+	assert.Equal(t, "monitoring", cs.Namespace)
+	assert.Equal(t, "default", cs.Spec.PlatformRef)
+	assert.True(t, cs.Spec.Policy.Adopt)
+	assert.Equal(t, "central", cs.Spec.Host)
+	assert.Equal(t, "Ping_App", cs.Spec.Name)
+	assert.Equal(t, "template1", cs.Spec.Template)
+	assert.Equal(t, []string{"arg1", "arg2"}, cs.Spec.Arguments)
+	assert.Equal(t, ptr.To(true), cs.Spec.ActiveCheckEnabled)
+	assert.Nil(t, cs.Spec.PassiveCheckEnabled)
+	assert.True(t, cs.Spec.Activated)
+	assert.Equal(t, []string{"sg1"}, cs.Spec.Groups)
+	assert.Equal(t, []string{"cat1"}, cs.Spec.Categories)
+	assert.Equal(t, []string{"user1"}, cs.Spec.Contacts)
+	assert.Equal(t, []string{"team1"}, cs.Spec.ContactGroups)
+	assert.Equal(t, []string{"c", "w"}, cs.Spec.NotificationOptions)
+	assert.Equal(t, "30", cs.Spec.NotificationInterval)
+	assert.Equal(t, "24x7", cs.Spec.NotificationPeriod)
+	assert.Nil(t, cs.Spec.NotificationsEnabled)
+	assert.Equal(t, map[string]string{"MAC1": "value1"}, cs.Spec.Macros)
+	assert.Len(t, cs.Spec.MacrosFrom, 1)
+	assert.Equal(t, "TOKEN", cs.Spec.MacrosFrom[0].Name)
+	assert.Equal(t, &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "central-ping-app-macros"}, Key: "TOKEN"}, cs.Spec.MacrosFrom[0].ValueFrom.SecretKeyRef)
+}
+
+func TestToCentreonServiceGroup(t *testing.T) {
+	sg := &centreonhandler.CentreonServiceGroup{
+		Name:        "SG_App",
+		Description: "my sg",
+		Activated:   "1",
+	}
+
+	csg := toCentreonServiceGroup(sg, toResourceName(sg.Name), importOptions{})
+	assert.Equal(t, "CentreonServiceGroup", csg.Kind)
+	assert.Equal(t, "sg-app", csg.Name)
+	assert.Equal(t, "SG_App", csg.Spec.Name)
+	assert.Equal(t, "my sg", csg.Spec.Description)
+	assert.True(t, csg.Spec.Activated)
+	assert.False(t, csg.Spec.Policy.Adopt)
+}
+
+func TestResourceNames(t *testing.T) {
+	names := newResourceNames()
+
+	name, err := names.get("central", "App_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "central-app-1", name)
+
+	// The same Centreon resource get the same name
+	name, err = names.get("central", "App_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "central-app-1", name)
+
+	// Another Centreon resource with the same resource name
+	name, err = names.get("central", "app-1")
+	assert.NoError(t, err)
+	assert.Regexp(t, "^central-app-1-[0-9a-f]{8}$", name)
+
+	// When the name is empty
+	name, err = names.get("__")
+	assert.NoError(t, err)
+	assert.Regexp(t, "^[0-9a-f]{8}$", name)
+
+	// When the name is too long
+	name, err = names.get(strings.Repeat("a", 300))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 253), name)
+	name, err = names.get(strings.Repeat("A", 300))
+	assert.NoError(t, err)
+	assert.Len(t, name, 253)
+	assert.NotEqual(t, strings.Repeat("a", 253), name)
+}
+
+func TestWriteObjects(t *testing.T) {
+	sg := toCentreonServiceGroup(&centreonhandler.CentreonServiceGroup{Name: "sg1"}, "sg1", importOptions{})
+	b := &bytes.Buffer{}
+
+	assert.NoError(t, writeObjects([]client.Object{sg, sg}, "", b))
+	assert.Equal(t, 2, bytes.Count(b.Bytes(), []byte("---\n")))
+	assert.Contains(t, b.String(), "kind: CentreonServiceGroup")
+
+	// One file per object
+	dir := t.TempDir()
+	assert.NoError(t, writeObjects([]client.Object{sg}, dir, b))
+	assert.FileExists(t, dir+"/CentreonServiceGroup-sg1.yaml")
+}
+
+func TestImportFilters(t *testing.T) {
+	filters, err := newImportFilters("^central$", "^App_", "")
+	assert.NoError(t, err)
+	assert.True(t, filters.matchService("central", "App_ping"))
+	assert.False(t, filters.matchService("central2", "App_ping"))
+	assert.False(t, filters.matchService("central", "ping"))
+	assert.True(t, filters.matchServiceGroup("sg1"))
+
+	_, err = newImportFilters("[", "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/go-centreon-rest/v21"
+	"github.com/disaster37/go-centreon-rest/v21/models"
+	"github.com/disaster37/monitoring-operator/pkg/centreonhandler"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+	prefixed "github.com/x-cray/logrus-prefixed-formatter"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	version string
+	commit  string
+)
+
+func run(args []string) error {
+	// Logger setting
+	formatter := new(prefixed.TextFormatter)
+	formatter.FullTimestamp = true
+	formatter.ForceFormatting = true
+	logrus.SetFormatter(formatter)
+	logrus.SetOutput(os.Stderr)
+
+	// CLI settings
+	app := cli.NewApp()
+	app.Usage = "Import Centreon configuration as monitoring-operator resources"
+	app.Version = fmt.Sprintf("%s-%s", version, commit)
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "config",
+			Usage: "Load configuration from `FILE`",
+		},
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:     "url",
+			Usage:    "The Centreon API URL",
+			EnvVars:  []string{"CENTREON_URL"},
+			Required: true,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    "username",
+			Usage:   "The Centreon username",
+			EnvVars: []string{"CENTREON_USERNAME"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    "password",
+			Usage:   "The Centreon password",
+			EnvVars: []string{"CENTREON_PASSWORD"},
+		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:  "self-signed-certificate",
+			Usage: "Disable the check of Centreon certificate",
+		}),
+		&cli.BoolFlag{
+			Name:  "debug",
+			Usage: "Display debug output",
+		},
+		&cli.BoolFlag{
+			Name:  "no-color",
+			Usage: "No print color",
+		},
+	}
+	app.Commands = []*cli.Command{
+		{
+			Name:  "import",
+			Usage: "Write the CentreonService and CentreonServiceGroup manifests from Centreon",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "host-filter",
+					Usage: "The regexp to select services by host name",
+				},
+				&cli.StringFlag{
+					Name:  "service-filter",
+					Usage: "The regexp to select services by name",
+				},
+				&cli.StringFlag{
+					Name:  "service-group-filter",
+					Usage: "The regexp to select service groups by name",
+				},
+				&cli.BoolFlag{
+					Name:  "no-services",
+					Usage: "Not import services",
+				},
+				&cli.BoolFlag{
+					Name:  "no-service-groups",
+					Usage: "Not import service groups",
+				},
+				&cli.StringFlag{
+					Name:  "namespace",
+					Usage: "The namespace of resources",
+				},
+				&cli.StringFlag{
+					Name:  "platform-ref",
+					Usage: "The target platform of resources",
+				},
+				&cli.BoolFlag{
+					Name:  "adopt",
+					Usage: "Set the policy adopt on resources to take ownership of existing Centreon resources",
+				},
+				&cli.StringFlag{
+					Name:  "output",
+					Usage: "The directory where to write one file per resource. It write on stdout if not set",
+				},
+			},
+			Action: importCentreon,
+		},
+	}
+
+	app.Before = func(c *cli.Context) error {
+		if c.Bool("debug") {
+			logrus.SetLevel(logrus.DebugLevel)
+		}
+
+		if !c.Bool("no-color") {
+			formatter := new(prefixed.TextFormatter)
+			formatter.FullTimestamp = true
+			formatter.ForceFormatting = true
+			logrus.SetFormatter(formatter)
+		}
+
+		if c.String("config") != "" {
+			before := altsrc.InitInputSourceWithContext(app.Flags, altsrc.NewYamlSourceFromFlagFunc("config"))
+			return before(c)
+		}
+		return nil
+	}
+
+	sort.Sort(cli.CommandsByName(app.Commands))
+
+	return app.Run(args)
+}
+
+func main() {
+	err := run(os.Args)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func importCentreon(c *cli.Context) error {
+	logger := logrus.NewEntry(logrus.StandardLogger())
+
+	filters, err := newImportFilters(c.String("host-filter"), c.String("service-filter"), c.String("service-group-filter"))
+	if err != nil {
+		return err
+	}
+	opts := importOptions{
+		Namespace:   c.String("namespace"),
+		PlatformRef: c.String("platform-ref"),
+		Adopt:       c.Bool("adopt"),
+	}
+
+	cfg := &models.Config{
+		Address:          c.String("url"),
+		Username:         c.String("username"),
+		Password:         c.String("password"),
+		DisableVerifySSL: c.Bool("self-signed-certificate"),
+		Debug:            c.Bool("debug"),
+		Logger:           logger.WithField("component", "centreon-client"),
+	}
+	centreonClient, err := centreon.NewClient(cfg)
+	if err != nil {
+		return errors.Wrap(err, "Error when create Centreon client")
+	}
+	handler := centreonhandler.NewCentreonHandler(centreonClient, logger)
+
+	objects := make([]client.Object, 0)
+
+	// Service groups
+	if !c.Bool("no-service-groups") {
+		logger.Info("Start import service groups")
+		serviceGroupNames := newResourceNames()
+		sgs, err := handler.ListServiceGroups()
+		if err != nil {
+			return errors.Wrap(err, "Error when list service groups")
+		}
+		for _, item := range sgs {
+			if !filters.matchServiceGroup(item.Name) {
+				continue
+			}
+			sg, err := handler.GetServiceGroup(item.Name)
+			if err != nil {
+				return errors.Wrapf(err, "Error when get service group %s", item.Name)
+			}
+			if sg == nil {
+				continue
+			}
+			name, err := serviceGroupNames.get(sg.Name)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, toCentreonServiceGroup(sg, name, opts))
+			logger.Debugf("Service group %s imported", sg.Name)
+		}
+	}
+
+	// Services
+	if !c.Bool("no-services") {
+		logger.Info("Start import services")
+		serviceNames := newResourceNames()
+		services, err := handler.ListServices()
+		if err != nil {
+			return errors.Wrap(err, "Error when list services")
+		}
+		for _, item := range services {
+			if !filters.matchService(item.Host, item.Name) {
+				continue
+			}
+			service, err := handler.GetService(item.Host, item.Name)
+			if err != nil {
+				return errors.Wrapf(err, "Error when get service %s/%s", item.Host, item.Name)
+			}
+			if service == nil {
+				continue
+			}
+			if service.Contacts, err = handler.GetServiceContacts(item.Host, item.Name); err != nil {
+				return errors.Wrapf(err, "Error when get contacts of service %s/%s", item.Host, item.Name)
+			}
+			if service.ContactGroups, err = handler.GetServiceContactGroups(item.Host, item.Name); err != nil {
+				return errors.Wrapf(err, "Error when get contact groups of service %s/%s", item.Host, item.Name)
+			}
+			name, err := serviceNames.get(service.Host, service.Name)
+			if err != nil {
+				return err
+			}
+			cs := toCentreonService(service, name, opts)
+			if len(cs.Spec.MacrosFrom) > 0 {
+				logger.Warnf("Service %s/%s has password macros, you need to create the secret %s with their values", service.Host, service.Name, cs.Spec.MacrosFrom[0].ValueFrom.SecretKeyRef.Name)
+			}
+			objects = append(objects, cs)
+			logger.Debugf("Service %s/%s imported", service.Host, service.Name)
+		}
+	}
+
+	logger.Infof("%d resources imported", len(objects))
+
+	return writeObjects(objects, c.String("output"), os.Stdout)
+}
+
+// writeObjects permit to write the manifests on stdout or one file per object on directory
+func writeObjects(objects []client.Object, output string, stdout io.Writer) (err error) {
+	for _, o := range objects {
+		b, err := yaml.Marshal(o)
+		if err != nil {
+			return errors.Wrapf(err, "Error when marshall %s", o.GetName())
+		}
+
+		if output == "" {
+			if _, err = fmt.Fprintf(stdout, "---\n%s", b); err != nil {
+				return errors.Wrap(err, "Error when write manifest")
+			}
+			continue
+		}
+
+		file := filepath.Join(output, fmt.Sprintf("%s-%s.yaml", o.GetObjectKind().GroupVersionKind().Kind, o.GetName()))
+		if err = os.WriteFile(file, b, 0o644); err != nil {
+			return errors.Wrapf(err, "Error when write file %s", file)
+		}
+	}
+
+	return nil
+}
+
+// importFilters is the filters to select the Centreon resources to import
+type importFilters struct {
+	host         *regexp.Regexp
+	service      *regexp.Regexp
+	serviceGroup *regexp.Regexp
+}
+
+func newImportFilters(host, service, serviceGroup string) (filters *importFilters, err error) {
+	filters = &importFilters{}
+	if filters.host, err = compileFilter(host); err != nil {
+		return nil, errors.Wrap(err, "Error when compile host filter")
+	}
+	if filters.service, err = compileFilter(service); err != nil {
+		return nil, errors.Wrap(err, "Error when compile service filter")
+	}
+	if filters.serviceGroup, err = compileFilter(serviceGroup); err != nil {
+		return nil, errors.Wrap(err, "Error when compile service group filter")
+	}
+
+	return filters, nil
+}
+
+func compileFilter(filter string) (*regexp.Regexp, error) {
+	if filter == "" {
+		return nil, nil
+	}
+
+	return regexp.Compile(filter)
+}
+
+func (h *importFilters) matchService(host, name string) bool {
+	return (h.host == nil || h.host.MatchString(host)) && (h.service == nil || h.service.MatchString(name))
+}
+
+func (h *importFilters) matchServiceGroup(name string) bool {
+	return h.serviceGroup == nil || h.serviceGroup.MatchString(name)
+}